@@ -6,12 +6,13 @@ require (
 	github.com/gofrs/flock v0.12.1
 	github.com/mattn/go-runewidth v0.0.16
 	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.29.0
 	golang.org/x/term v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/sys v0.29.0 // indirect
 )
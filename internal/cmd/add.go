@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -14,17 +16,28 @@ import (
 )
 
 var (
-	addPaths     []string
-	addPriority  string
-	addNoGit     bool
-	addTags      []string
-	addDue       string
-	addJSON      bool
-	addNotes     string
-	addBlockedBy []string
-	addBlocks    []string
-	addRecur     string
-	addAssign    string
+	addPaths        []string
+	addPriority     string
+	addNoGit        bool
+	addTags         []string
+	addDue          string
+	addJSON         bool
+	addNotes        string
+	addBlockedBy    []string
+	addBlocks       []string
+	addRecur        string
+	addAssign       string
+	addAt           []string
+	addFromStdin    bool
+	addCommit       bool
+	addBefore       string
+	addAfter        string
+	addEdit         bool
+	addFormat       string
+	addEstimate     int
+	addAIHint       string
+	addForce        bool
+	addTemplateName string
 )
 
 var addCmd = &cobra.Command{
@@ -33,14 +46,50 @@ var addCmd = &cobra.Command{
 	Long: `Add a new todo item to the project.
 
 Todos can be associated with file paths for context-aware tracking.
-Git branch and commit information is automatically captured unless --no-git is specified.`,
+Git branch and commit information is automatically captured unless --no-git is specified.
+
+--template <name> starts from a saved template (see todo template): its
+priority, notes, estimate, and recurrence apply unless given as flags, and its
+paths and tags are combined with the ones given.
+
+A single - as the text reads it from stdin: one line, one todo. Use
+--from-stdin to add a todo per line.`,
 	Example: `  todo add "Fix authentication bug"
   todo add "Refactor middleware" --path src/auth
   todo add "Update tests" -p src/tests -p src/utils
   todo add "Quick fix" --no-git
   todo add "Important task" --priority high
-  todo add "Ship billing flow" --tag billing --tag backend --due 2026-03-01`,
-	Args: cobra.MinimumNArgs(1),
+  todo add "Check token expiry" --at src/auth.go:42
+  todo add "Ship billing flow" --tag billing --tag backend --due 2026-03-01
+  todo add "Write migration" --after 3
+  todo add "Split billing service" --estimate 5
+  todo add "Fix authentication bug" --force   # even if it already exists
+  todo add --template bug "Login fails on Safari"
+  todo add --edit
+  todo add "Plan the release" --edit --format json
+  cat tasks.txt | todo add --from-stdin --priority high --path src/api
+  git log -1 --pretty=%s | todo add - --path src/api`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if textFromStdin(args) && len(args) > 1 {
+			return fmt.Errorf("- reads the todo text from stdin and cannot be combined with todo text arguments")
+		}
+		if addEdit {
+			if textFromStdin(args) {
+				return fmt.Errorf("--edit cannot be combined with - (text from stdin)")
+			}
+			if addFromStdin {
+				return fmt.Errorf("--edit cannot be combined with --from-stdin")
+			}
+			return nil
+		}
+		if addFromStdin {
+			if len(args) > 0 {
+				return fmt.Errorf("--from-stdin cannot be combined with todo text arguments")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runAdd,
 }
 
@@ -48,7 +97,8 @@ func init() {
 	rootCmd.AddCommand(addCmd)
 
 	addCmd.Flags().StringArrayVarP(&addPaths, "path", "p", []string{}, "Associate with file/folder paths (can be used multiple times)")
-	addCmd.Flags().StringVar(&addPriority, "priority", "medium", "Priority level: low, medium, high")
+	addCmd.Flags().StringVar(&addPriority, "priority", "medium", "Priority level: low, medium, high (or l, m, h)")
+	addCmd.Flags().StringArrayVar(&addAt, "at", []string{}, "Associate with a file location as path:line (can be used multiple times)")
 	addCmd.Flags().BoolVar(&addNoGit, "no-git", false, "Don't capture git context (branch/commit)")
 	addCmd.Flags().StringArrayVarP(&addTags, "tag", "t", []string{}, "Tag(s) for organizing and filtering (repeat or comma-separate)")
 	addCmd.Flags().StringVar(&addDue, "due", "", "Due date/time (YYYY-MM-DD, YYYY-MM-DDTHH:MM, RFC3339, today, tomorrow, +2d)")
@@ -56,22 +106,51 @@ func init() {
 	addCmd.Flags().StringArrayVar(&addBlockedBy, "blocked-by", []string{}, "IDs of todos that block this one")
 	addCmd.Flags().StringArrayVar(&addBlocks, "blocks", []string{}, "IDs of todos that this one blocks")
 	addCmd.Flags().StringVar(&addRecur, "recur", "", "Recurrence when completed: daily, weekly, monthly")
+	addCmd.Flags().IntVar(&addEstimate, "estimate", 0, "Estimated effort in story points")
+	addCmd.Flags().StringVar(&addAIHint, "ai-hint", "", "Guidance for an AI assistant working on this todo (see todo explain)")
 	addCmd.Flags().StringVar(&addAssign, "assign", "", "Assign to a git contributor (name, email prefix, or me)")
 	addCmd.Flags().BoolVar(&addJSON, "json", false, "Output the created todo as JSON")
+	addCmd.Flags().BoolVar(&addCommit, "commit", false, "Commit the todo files afterwards (default from autoCommit in config.json)")
+	addCmd.Flags().BoolVar(&addFromStdin, "from-stdin", false, "Create one todo per non-empty stdin line (lines starting with # are skipped)")
+	addCmd.Flags().StringVar(&addBefore, "before", "", "Insert before the todo with this ID or index instead of appending")
+	addCmd.Flags().StringVar(&addAfter, "after", "", "Insert after the todo with this ID or index instead of appending")
+	addCmd.Flags().BoolVar(&addEdit, "edit", false, "Fill out the new todo in $EDITOR (text, priority, status, paths, tags, notes, due)")
+	addCmd.Flags().BoolVarP(&addForce, "force", "f", false, "Add even when an unfinished todo with the same text exists")
+	addCmd.Flags().StringVar(&addFormat, "format", "yaml", "Template format for --edit: yaml, json")
+	addCmd.Flags().StringVar(&addTemplateName, "template", "", "Start from a saved template (see todo template)")
 
 	// Project-aware path completion
 	registerPathFlagCompletion(addCmd, "path")
+	registerPriorityFlagCompletion(addCmd, "priority")
 	registerAssigneeFlagCompletion(addCmd, "assign")
+	_ = addCmd.RegisterFlagCompletionFunc("template", completeTemplateNames)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 	Verbosef("project root: %s", projectRoot)
 
-	pathFlagUsed := cmd.Flags().Changed("path")
+	// Checked before a template adds its paths, so only paths given on the
+	// command line make trailing words of the text count as paths.
+	pathFlagUsed := cmd.Flags().Changed("path") || len(addPaths) > 0
+
+	if addTemplateName != "" {
+		tmpl, err := storage.LoadTemplate(projectRoot, addTemplateName)
+		if err != nil {
+			return err
+		}
+		applyTodoTemplate(cmd, tmpl)
+	}
+
+	if addBefore != "" && addAfter != "" {
+		return fmt.Errorf("--before and --after cannot be used together")
+	}
+	if err := types.ValidateEstimate(addEstimate); err != nil {
+		return err
+	}
 
 	config, err := storage.LoadConfig(projectRoot)
 	if err != nil {
@@ -79,28 +158,83 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 	Verbosef("config: autoGit=%v, defaultBranch=%q", config.AutoGit, config.DefaultBranch)
 
-	text := strings.Join(args, " ")
-	if strings.TrimSpace(text) == "" {
-		return fmt.Errorf("todo text cannot be empty")
-	}
-	if pathFlagUsed || len(addPaths) > 0 {
-		switch {
-		case len(args) > 1:
-			text = strings.TrimSpace(args[0])
-			addPaths = append(addPaths, args[1:]...)
-		case len(args) == 1:
-			text, addPaths = splitTrailingPaths(text, addPaths)
+	var texts []string
+	var status types.Status
+	dueSet := cmd.Flags().Changed("due")
+	if addEdit {
+		if terminal.InteractionDisabled() {
+			return fmt.Errorf("--edit needs an interactive terminal; interaction is turned off by --no-interactive or %s", terminal.NonInteractiveEnv)
+		}
+		tmpl := addTemplate{
+			Text:     strings.Join(args, " "),
+			Priority: addPriority,
+			Paths:    normalizePaths(addPaths),
+			Tags:     storage.NormalizeTags(addTags),
+			Notes:    addNotes,
+			Due:      addDue,
+		}
+		edited, ok, err := editAddTemplate(projectRoot, tmpl, strings.ToLower(addFormat))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			terminal.PrintInfo("Aborted: template left unchanged")
+			fmt.Println()
+			return nil
+		}
+		// The template replaces the flags it covers.
+		texts = []string{edited.Text}
+		addPriority, addPaths, addTags, addNotes, addDue = edited.Priority, edited.Paths, edited.Tags, edited.Notes, edited.Due
+		status = types.Status(edited.Status)
+		dueSet = addDue != ""
+	} else if addFromStdin {
+		texts, err = readTodoLines(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		if len(texts) == 0 {
+			return fmt.Errorf("no todos on stdin")
+		}
+	} else if textFromStdin(args) {
+		text, err := readTodoText(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		texts = []string{text}
+	} else {
+		text := strings.Join(args, " ")
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("todo text cannot be empty")
 		}
+		if pathFlagUsed {
+			switch {
+			case len(args) > 1:
+				text = strings.TrimSpace(args[0])
+				addPaths = append(addPaths, args[1:]...)
+			case len(args) == 1:
+				text, addPaths = splitTrailingPaths(text, addPaths)
+			}
+		}
+		texts = []string{text}
 	}
 
-	priority := types.Priority(addPriority)
-	if priority != types.PriorityLow && priority != types.PriorityMedium && priority != types.PriorityHigh {
-		return fmt.Errorf("invalid priority: %s. Use: low, medium, high", addPriority)
+	priority, err := types.ParsePriority(addPriority)
+	if err != nil {
+		return err
+	}
+
+	locations := make([]types.Location, 0, len(addAt))
+	for _, raw := range normalizePaths(addAt) {
+		loc, err := parseLocation(raw)
+		if err != nil {
+			return err
+		}
+		locations = append(locations, loc)
 	}
 
 	var dueAt *time.Time
-	if cmd.Flags().Changed("due") {
-		d, err := parseDueDateInput(addDue, time.Now())
+	if dueSet {
+		d, err := types.ParseDueDate(addDue, time.Now())
 		if err != nil {
 			return err
 		}
@@ -114,86 +248,134 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	var todo *types.Todo
+	var assignee string
+	if cmd.Flags().Changed("assign") {
+		assignee, err = resolveAssignee(projectRoot, addAssign)
+		if err != nil {
+			return err
+		}
+	}
+
+	var branch, commit string
+	if !addNoGit {
+		branch, commit = captureGitContext(projectRoot, config)
+	}
+
+	var created []types.Todo
 	err = storage.WithLock(projectRoot, func() error {
 		todos, err := storage.LoadTodos(projectRoot)
 		if err != nil {
 			return fmt.Errorf("failed to load todos: %w", err)
 		}
 
-		id, err := storage.GenerateID()
-		if err != nil {
-			return fmt.Errorf("failed to generate ID: %w", err)
+		// Resolve the reference before creating anything so a bad ID leaves
+		// the project untouched.
+		insertAt := len(todos)
+		var ref *types.Todo
+		if reference := addBefore + addAfter; reference != "" {
+			todo, idx, err := storage.ResolveTodo(todos, reference)
+			if err != nil {
+				return err
+			}
+			ref, insertAt = todo, idx
+			if addAfter != "" {
+				insertAt++
+			}
 		}
 
-		todo = types.NewTodo(id, text)
-		todo.Priority = priority
-
-		if err := storage.ApplyCreator(todo); err != nil {
+		warnings, err := checkAddDuplicates(todos, texts, addForce, terminal.ShouldInteract())
+		if err != nil {
 			return err
 		}
-
-		normalizedPaths := normalizePaths(addPaths)
-		if len(normalizedPaths) > 0 {
-			todo.SetPaths(normalizedPaths)
-		}
-		todo.Tags = normalizeTags(addTags)
-		if addNotes != "" {
-			todo.Notes = addNotes
-		}
-		todo.DueAt = dueAt
-
-		if addRecur != "" {
-			todo.Recur = types.Recurrence(strings.ToLower(addRecur))
-		}
-		if len(addBlockedBy) > 0 {
-			todo.BlockedBy = addBlockedBy
-		}
-		if len(addBlocks) > 0 {
-			todo.Blocks = addBlocks
+		if !addJSON {
+			for _, w := range warnings {
+				terminal.PrintWarning(w)
+			}
 		}
 
-		if cmd.Flags().Changed("assign") {
-			email, err := resolveAssignee(projectRoot, addAssign)
+		for _, text := range texts {
+			todo, err := newAddTodo(text, priority, locations, dueAt, assignee)
 			if err != nil {
 				return err
 			}
-			todo.Assignee = email
-		}
-
-		if !addNoGit && config.AutoGit && git.IsGitRepo() {
-			branch, commit, err := git.GetGitContext()
-			if err == nil && branch != "" {
+			if branch != "" {
 				todo.SetGitContext(branch, commit)
 			}
-		} else if !addNoGit && config.AutoGit && config.DefaultBranch != "" {
-			todo.SetGitContext(config.DefaultBranch, "")
+			if status != "" && status != todo.Status {
+				todo.SetStatus(status)
+			}
+			created = append(created, *todo)
+		}
+
+		if ref != nil && ref.CreatedBy != created[0].CreatedBy && !addJSON {
+			// Order is stored per owner file, so the position only holds
+			// relative to the creator's own todos.
+			terminal.PrintWarning(fmt.Sprintf("%s belongs to %s; the new todo is placed among your own todos", shortTodoID(ref.ID), ref.CreatedBy))
 		}
 
-		todos = append(todos, *todo)
+		todos = storage.InsertTodos(todos, insertAt, created...)
 		return storage.SaveTodos(projectRoot, todos)
 	})
 	if err != nil {
 		return err
 	}
+	createdTexts := make([]string, len(created))
+	for i, t := range created {
+		createdTexts[i] = t.Text
+	}
+	committed := commitTodoChange(cmd, projectRoot, "add", createdTexts, addCommit)
 
+	if addFromStdin {
+		if addJSON {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(map[string]interface{}{
+				"todos": created,
+				"count": len(created),
+			})
+		}
+		if quiet {
+			for _, t := range created {
+				fmt.Fprintln(cmd.OutOrStdout(), t.ID)
+			}
+			return nil
+		}
+		terminal.PrintSuccess(fmt.Sprintf("Added %d todo(s)", len(created)))
+		if committed != "" {
+			terminal.PrintDim("Committed: " + committed)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	todo := &created[0]
 	if addJSON {
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
 		return enc.Encode(todo)
 	}
+	if quiet {
+		fmt.Fprintln(cmd.OutOrStdout(), todo.ID)
+		return nil
+	}
 
-	terminal.PrintSuccess(fmt.Sprintf("Added: %s", text))
+	terminal.PrintSuccess(fmt.Sprintf("Added: %s", todo.Text))
 
 	if len(todo.Context.Paths) > 0 {
 		fmt.Printf("  %s📁 Paths: %s%s\n", terminal.Dim, strings.Join(todo.Context.Paths, ", "), terminal.Reset)
 	}
+	if len(todo.Context.Locations) > 0 {
+		fmt.Printf("  %s📍 At: %s%s\n", terminal.Dim, formatLocations(todo.Context.Locations), terminal.Reset)
+	}
 	if len(todo.Tags) > 0 {
 		fmt.Printf("  %s🏷️ Tags: %s%s\n", terminal.Dim, strings.Join(todo.Tags, ", "), terminal.Reset)
 	}
 	if todo.DueAt != nil {
 		fmt.Printf("  %s⏳ %s%s\n", terminal.Dim, formatDueLabel(todo.DueAt, time.Now()), terminal.Reset)
 	}
+	if todo.Estimate > 0 {
+		fmt.Printf("  %s🎯 Estimate: %d pts%s\n", terminal.Dim, todo.Estimate, terminal.Reset)
+	}
 	if todo.Context.Branch != "" {
 		fmt.Printf("  %s🌿 Branch: %s%s\n", terminal.Dim, todo.Context.Branch, terminal.Reset)
 	}
@@ -205,7 +387,141 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("  %s🆔 ID: %s%s\n", terminal.Dim, todo.ID[:8], terminal.Reset)
 	printAssigneeHint(projectRoot, todo.Context.Paths)
+	if committed != "" {
+		terminal.PrintDim("Committed: " + committed)
+	}
 	fmt.Println()
 
 	return nil
 }
+
+// captureGitContext returns the branch and commit to record on a new todo:
+// the current ones inside a git repository, else the configured default
+// branch. Both are empty when AutoGit is off.
+func captureGitContext(projectRoot string, config *types.Config) (branch, commit string) {
+	if !config.AutoGit {
+		return "", ""
+	}
+	if git.IsGitRepoAt(projectRoot) {
+		if b, c, err := git.GetGitContext(projectRoot); err == nil && b != "" {
+			return b, c
+		}
+		return "", ""
+	}
+	return config.DefaultBranch, ""
+}
+
+// newAddTodo builds a todo from text and the shared add flags. Git context is
+// applied by the caller.
+func newAddTodo(text string, priority types.Priority, locations []types.Location, dueAt *time.Time, assignee string) (*types.Todo, error) {
+	id, err := storage.GenerateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	todo := types.NewTodo(id, text)
+	todo.Priority = priority
+	todo.Estimate = addEstimate
+
+	if err := storage.ApplyCreator(todo); err != nil {
+		return nil, err
+	}
+
+	normalizedPaths, pathLocations := splitPathLocations(normalizePaths(addPaths))
+	if len(normalizedPaths) > 0 {
+		todo.SetPaths(normalizedPaths)
+	}
+	for _, loc := range append(pathLocations, locations...) {
+		todo.AddLocation(loc)
+	}
+	todo.Tags = storage.NormalizeTags(addTags)
+	if addNotes != "" {
+		todo.Notes = addNotes
+	}
+	todo.Meta.AIHint = strings.TrimSpace(addAIHint)
+	todo.DueAt = dueAt
+
+	if addRecur != "" {
+		todo.Recur = types.Recurrence(strings.ToLower(addRecur))
+	}
+	if len(addBlockedBy) > 0 {
+		todo.BlockedBy = addBlockedBy
+	}
+	if len(addBlocks) > 0 {
+		todo.Blocks = addBlocks
+	}
+	todo.Assignee = assignee
+
+	return todo, nil
+}
+
+// checkAddDuplicates looks for unfinished todos whose text matches one being
+// added, ignoring case and spacing like `todo doctor`. Interactively a match is
+// an error unless force is set; otherwise each match becomes a warning so
+// scripts keep working.
+func checkAddDuplicates(todos []types.Todo, texts []string, force, interactive bool) ([]string, error) {
+	existing := make(map[string]int)
+	for i, todo := range todos {
+		if todo.Status == types.StatusDone {
+			continue
+		}
+		key := duplicateKey(todo.Text, false)
+		if _, ok := existing[key]; !ok {
+			existing[key] = i
+		}
+	}
+
+	var warnings []string
+	for _, text := range texts {
+		i, ok := existing[duplicateKey(text, false)]
+		if !ok {
+			continue
+		}
+		msg := fmt.Sprintf("Already exists: #%d (%s) %s", i+1, shortTodoID(todos[i].ID), todos[i].Text)
+		if interactive && !force {
+			return nil, fmt.Errorf("%s\n\nUse --force to add it anyway", msg)
+		}
+		if !force {
+			warnings = append(warnings, msg)
+		}
+	}
+	return warnings, nil
+}
+
+// textFromStdin reports whether the first argument is "-", which asks for
+// the todo text to be read from stdin.
+func textFromStdin(args []string) bool {
+	return len(args) > 0 && args[0] == "-"
+}
+
+// readTodoText reads the text of a single todo from r for todo add -. The
+// trailing newline and surrounding space are trimmed; more than one line is
+// refused so a multi-line pipe doesn't silently become one long todo.
+func readTodoText(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", fmt.Errorf("no todo text on stdin")
+	}
+	if strings.ContainsAny(text, "\r\n") {
+		return "", fmt.Errorf("stdin has more than one line; use --from-stdin to add a todo per line")
+	}
+	return text, nil
+}
+
+// readTodoLines returns the trimmed, non-empty lines of r, skipping # comments.
+func readTodoLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
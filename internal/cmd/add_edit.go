@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// addTemplate is the editable form of a new todo written by `todo add --edit`.
+type addTemplate struct {
+	Text     string   `yaml:"text" json:"text"`
+	Priority string   `yaml:"priority" json:"priority"`
+	Status   string   `yaml:"status" json:"status"`
+	Paths    []string `yaml:"paths" json:"paths"`
+	Tags     []string `yaml:"tags" json:"tags"`
+	Notes    string   `yaml:"notes" json:"notes"`
+	Due      string   `yaml:"due" json:"due"`
+}
+
+const addTemplateYAMLHeader = `# New todo. Save and quit to create it; leave it unchanged or empty to abort.
+#
+# priority: high, medium, low (or h, m, l)
+# status:   open, done, blocked, waiting, tech-debt, or a custom status
+# due:      YYYY-MM-DD, YYYY-MM-DDTHH:MM, today, tomorrow, +2d
+`
+
+// renderAddTemplate writes tmpl in format ("yaml" or "json").
+func renderAddTemplate(tmpl addTemplate, format string) ([]byte, error) {
+	if tmpl.Paths == nil {
+		tmpl.Paths = []string{}
+	}
+	if tmpl.Tags == nil {
+		tmpl.Tags = []string{}
+	}
+
+	switch format {
+	case "yaml":
+		body, err := yaml.Marshal(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(addTemplateYAMLHeader), body...), nil
+	case "json":
+		return json.MarshalIndent(tmpl, "", "  ")
+	}
+	return nil, fmt.Errorf("invalid format: %s. Use: yaml, json", format)
+}
+
+// parseAddTemplate reads an edited template back, rejecting unknown fields so
+// typos don't silently drop data.
+func parseAddTemplate(data []byte, format string) (addTemplate, error) {
+	var tmpl addTemplate
+	switch format {
+	case "yaml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&tmpl); err != nil {
+			return tmpl, fmt.Errorf("invalid YAML: %w", err)
+		}
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&tmpl); err != nil {
+			return tmpl, fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		return tmpl, fmt.Errorf("invalid format: %s. Use: yaml, json", format)
+	}
+
+	tmpl.Text = strings.TrimSpace(tmpl.Text)
+	if tmpl.Text == "" {
+		return tmpl, fmt.Errorf("todo text cannot be empty")
+	}
+	if strings.TrimSpace(tmpl.Priority) == "" {
+		tmpl.Priority = string(types.PriorityMedium)
+	}
+	if _, err := types.ParsePriority(tmpl.Priority); err != nil {
+		return tmpl, err
+	}
+	if tmpl.Status = strings.ToLower(strings.TrimSpace(tmpl.Status)); tmpl.Status == "" {
+		tmpl.Status = string(types.StatusOpen)
+	}
+	if !types.Status(tmpl.Status).IsValid() {
+		return tmpl, &types.InvalidStatusError{Status: tmpl.Status}
+	}
+	tmpl.Due = strings.TrimSpace(tmpl.Due)
+	return tmpl, nil
+}
+
+// isBlankTemplate reports whether data holds nothing but whitespace and
+// YAML comments.
+func isBlankTemplate(data []byte) bool {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// editAddTemplate opens tmpl in the user's editor and returns the edited
+// result. It returns ok=false when the file comes back unchanged or empty.
+func editAddTemplate(projectRoot string, tmpl addTemplate, format string) (addTemplate, bool, error) {
+	initial, err := renderAddTemplate(tmpl, format)
+	if err != nil {
+		return tmpl, false, err
+	}
+
+	f, err := os.CreateTemp("", "todo-*."+format)
+	if err != nil {
+		return tmpl, false, fmt.Errorf("failed to create template file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.Write(initial); err != nil {
+		f.Close()
+		return tmpl, false, fmt.Errorf("failed to write template file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return tmpl, false, fmt.Errorf("failed to write template file: %w", err)
+	}
+
+	if err := launchEditor(resolveEditor(projectRoot), path, 0); err != nil {
+		return tmpl, false, err
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return tmpl, false, fmt.Errorf("failed to read template file: %w", err)
+	}
+	if bytes.Equal(edited, initial) || isBlankTemplate(edited) {
+		return tmpl, false, nil
+	}
+
+	parsed, err := parseAddTemplate(edited, format)
+	if err != nil {
+		return tmpl, false, err
+	}
+	return parsed, true, nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestAddTemplateRoundTrip(t *testing.T) {
+	tmpl := addTemplate{Text: "Ship it", Priority: "h", Status: "blocked", Paths: []string{"src"}, Tags: []string{"api"}, Due: "2026-03-01"}
+	for _, format := range []string{"yaml", "json"} {
+		data, err := renderAddTemplate(tmpl, format)
+		if err != nil {
+			t.Fatalf("%s: render: %v", format, err)
+		}
+		got, err := parseAddTemplate(data, format)
+		if err != nil {
+			t.Fatalf("%s: parse: %v\n%s", format, err, data)
+		}
+		if got.Text != "Ship it" || got.Status != "blocked" || got.Paths[0] != "src" || got.Tags[0] != "api" || got.Due != "2026-03-01" {
+			t.Fatalf("%s: round trip lost data: %+v", format, got)
+		}
+	}
+	if !strings.HasPrefix(string(mustRender(t, tmpl, "yaml")), "# New todo.") {
+		t.Fatal("YAML template should start with the help comment")
+	}
+}
+
+func mustRender(t *testing.T, tmpl addTemplate, format string) []byte {
+	t.Helper()
+	data, err := renderAddTemplate(tmpl, format)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return data
+}
+
+func TestParseAddTemplateValidates(t *testing.T) {
+	tests := map[string]string{
+		"empty text":    "text: ''\n",
+		"bad priority":  "text: x\npriority: urgent\n",
+		"bad status":    "text: x\nstatus: someday\n",
+		"unknown field": "text: x\npriorty: high\n",
+	}
+	for name, data := range tests {
+		if _, err := parseAddTemplate([]byte(data), "yaml"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := parseAddTemplate([]byte(`{"text":"x","extra":1}`), "json"); err == nil {
+		t.Error("expected an error for an unknown JSON field")
+	}
+	if !isBlankTemplate([]byte("# only comments\n\n  \n")) {
+		t.Error("comment-only template should count as blank")
+	}
+}
+
+func TestAddEdit(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON, addNotes, addDue = []string{}, []string{}, false, "", ""
+	t.Cleanup(func() {
+		addEdit, addFormat, addPriority, addNotes, addDue = false, "yaml", "medium", "", ""
+		addPaths, addTags = []string{}, []string{}
+		addCmd.Flags().Lookup("edit").Changed = false
+	})
+
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\ncat > \"$1\" <<'EOF'\ntext: Plan the release\npriority: h\nstatus: waiting\npaths: [docs]\ntags: [Release]\nnotes: check the changelog\nEOF\n"
+	if err := os.WriteFile(editor, []byte(script), 0o755); err != nil {
+		t.Fatalf("write editor: %v", err)
+	}
+	t.Setenv("VISUAL", editor)
+
+	rootCmd.SetArgs([]string{"add", "--edit", "--no-git"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add --edit failed: %v", err)
+	}
+
+	todos, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(todos) != 1 {
+		t.Fatalf("expected 1 todo, got %d", len(todos))
+	}
+	got := todos[0]
+	if got.Text != "Plan the release" || got.Priority != types.PriorityHigh || got.Status != types.StatusWaiting {
+		t.Fatalf("unexpected todo: %+v", got)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "release" || got.Notes != "check the changelog" || len(got.Context.Paths) != 1 {
+		t.Fatalf("template fields not applied: %+v", got)
+	}
+
+	// An editor that leaves the template alone aborts without saving.
+	t.Setenv("VISUAL", "true")
+	rootCmd.SetArgs([]string{"add", "--edit", "--no-git", "Untouched"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unchanged template should abort cleanly: %v", err)
+	}
+	// A failing editor aborts with an error.
+	t.Setenv("VISUAL", "false")
+	rootCmd.SetArgs([]string{"add", "--edit", "--no-git", "Broken"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an error when the editor exits non-zero")
+	}
+	if todos, _ := storage.LoadTodos(dir); len(todos) != 1 {
+		t.Fatalf("aborted edits should not add todos, got %d", len(todos))
+	}
+}
@@ -35,7 +35,7 @@ func init() {
 }
 
 func runArchive(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
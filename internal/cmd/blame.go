@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	blameLimit int
+	blameJSON  bool
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <id|index>",
+	Short: "Show recent git commits touching a todo's paths",
+	Long: `For each path attached to a todo, list the most recent commits that touched
+it. Commits made after the todo was created are marked as new, so you can tell
+whether the referenced code changed since the todo was written.`,
+	Example: `  todo blame 1
+  todo blame abc123 --limit 10
+  todo blame 1 --json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runBlame,
+}
+
+func init() {
+	rootCmd.AddCommand(blameCmd)
+	blameCmd.Flags().IntVarP(&blameLimit, "limit", "n", 5, "Commits to show per path")
+	blameCmd.Flags().BoolVar(&blameJSON, "json", false, "Output as JSON")
+}
+
+// pathHistory is the git history of one todo path.
+type pathHistory struct {
+	Path    string           `json:"path"`
+	Exists  bool             `json:"exists"`
+	Tracked bool             `json:"tracked"`
+	Commits []git.PathCommit `json:"commits"`
+}
+
+// todoPathHistory looks up the recent commits for each of the todo's paths,
+// which are relative to the project root.
+func todoPathHistory(projectRoot string, todo *types.Todo, limit int) ([]pathHistory, error) {
+	histories := make([]pathHistory, 0, len(todo.Context.Paths))
+	for _, path := range todo.Context.Paths {
+		h := pathHistory{Path: path, Commits: []git.PathCommit{}}
+		if _, err := os.Stat(filepath.Join(projectRoot, path)); err == nil {
+			h.Exists = true
+		}
+		h.Tracked = git.IsTracked(projectRoot, path)
+		// Deleted paths still have history worth showing; untracked ones don't.
+		if h.Tracked || !h.Exists {
+			commits, err := git.GetPathHistory(projectRoot, path, limit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read git history for %s: %w", path, err)
+			}
+			if commits != nil {
+				h.Commits = commits
+			}
+		}
+		histories = append(histories, h)
+	}
+	return histories, nil
+}
+
+func runBlame(cmd *cobra.Command, args []string) error {
+	if blameLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	if !git.IsGitRepoAt(projectRoot) {
+		return fmt.Errorf("not a git repository: todo blame needs git history")
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	todo, _, err := storage.ResolveTodo(todos, args[0])
+	if err != nil {
+		return err
+	}
+
+	histories, err := todoPathHistory(projectRoot, todo, blameLimit)
+	if err != nil {
+		return err
+	}
+
+	if blameJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"id":      todo.ID,
+			"text":    todo.Text,
+			"created": todo.CreatedAt,
+			"paths":   histories,
+		})
+	}
+
+	fmt.Printf("\n  %s%s%s %s(%s, created %s)%s\n", terminal.Bold, todo.Text, terminal.Reset,
+		terminal.Dim, shortTodoID(todo.ID), todo.CreatedAt.Local().Format("2006-01-02"), terminal.Reset)
+	if len(histories) == 0 {
+		fmt.Println()
+		terminal.PrintInfo("This todo has no paths; add some with: todo edit <id> --add-path <path>")
+		fmt.Println()
+		return nil
+	}
+
+	for _, h := range histories {
+		fmt.Printf("\n  %s📁 %s%s\n", terminal.BrightCyan, h.Path, terminal.Reset)
+		switch {
+		case !h.Exists && len(h.Commits) == 0:
+			fmt.Printf("     %s⚠ Path does not exist and has no git history%s\n", terminal.Yellow, terminal.Reset)
+			continue
+		case !h.Exists:
+			fmt.Printf("     %s⚠ Path no longer exists; last commits before it was removed:%s\n", terminal.Yellow, terminal.Reset)
+		case !h.Tracked:
+			fmt.Printf("     %s⚠ Not tracked by git%s\n", terminal.Yellow, terminal.Reset)
+			continue
+		case len(h.Commits) == 0:
+			fmt.Printf("     %sNo commits yet%s\n", terminal.Dim, terminal.Reset)
+			continue
+		}
+		for _, c := range h.Commits {
+			marker := ""
+			if c.Date.After(todo.CreatedAt) {
+				marker = fmt.Sprintf(" %s● new%s", terminal.Green, terminal.Reset)
+			}
+			fmt.Printf("     %s%s%s  %s%s%s  %s%s\n",
+				terminal.Yellow, c.Hash, terminal.Reset,
+				terminal.Dim, c.Date.Local().Format("2006-01-02"), terminal.Reset,
+				c.Subject, marker)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bagadi-alnour/todo-cli/internal/stats"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var capacityJSON bool
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show outstanding estimated effort, overall and per branch",
+	Long: `Sum the estimates (story points) of every todo that is not done, overall
+and per branch. Set estimates with: todo add --estimate N or todo edit <id> --estimate N.`,
+	Example: `  todo capacity
+  todo capacity --json`,
+	Args: cobra.NoArgs,
+	RunE: runCapacity,
+}
+
+func init() {
+	rootCmd.AddCommand(capacityCmd)
+	capacityCmd.Flags().BoolVar(&capacityJSON, "json", false, "Output as JSON")
+}
+
+func runCapacity(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	capacity := stats.ComputeCapacity(todos)
+
+	if capacityJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(capacity)
+	}
+
+	terminal.PrintHeader("CAPACITY", "🎯")
+
+	if capacity.Todos == 0 {
+		terminal.PrintInfo("Nothing outstanding")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %sOutstanding:%s %s%d pts%s across %d todo(s)\n",
+		terminal.Bold+terminal.BrightCyan, terminal.Reset, terminal.Bold, capacity.Points, terminal.Reset, capacity.Todos)
+	if capacity.Unestimated > 0 {
+		fmt.Printf("  %s%d todo(s) have no estimate%s\n", terminal.Yellow, capacity.Unestimated, terminal.Reset)
+	}
+	fmt.Println()
+
+	branches := make([]string, 0, len(capacity.ByBranch))
+	for branch := range capacity.ByBranch {
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		pi, pj := capacity.ByBranch[branches[i]], capacity.ByBranch[branches[j]]
+		if pi != pj {
+			return pi > pj
+		}
+		return branches[i] < branches[j]
+	})
+
+	fmt.Printf("  %sBy branch%s\n", terminal.Bold+terminal.BrightCyan, terminal.Reset)
+	for _, branch := range branches {
+		label := branch
+		if label == "" {
+			label = "(no branch)"
+		}
+		fmt.Printf("    %s🌿 %-24s%s %s%d pts%s\n", terminal.Green, label, terminal.Reset, terminal.Bold, capacity.ByBranch[branch], terminal.Reset)
+	}
+	fmt.Println()
+	return nil
+}
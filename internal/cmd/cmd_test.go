@@ -4,10 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
 )
 
@@ -216,6 +222,8 @@ func TestDoctorJSON(t *testing.T) {
 		t.Fatalf("save: %v", err)
 	}
 
+	t.Cleanup(func() { doctorJSON = false })
+
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
 	rootCmd.SetErr(buf)
@@ -245,9 +253,9 @@ func TestFindTodoByIDOrIndex_EdgeCases(t *testing.T) {
 		t.Fatal("partial ID match for 'abcd1234' failed")
 	}
 
-	// Partial ID match (shared prefix should match the first one)
-	if todo, _ := storage.FindTodoByIDOrIndex(todos, "abcd"); todo == nil || todo.Text != "first" {
-		t.Fatal("shared prefix match for 'abcd' should return first match")
+	// Shared prefix is ambiguous and must not pick either todo
+	if todo, _ := storage.FindTodoByIDOrIndex(todos, "abcd"); todo != nil {
+		t.Fatalf("shared prefix 'abcd' should not match, got %q", todo.Text)
 	}
 
 	// Index takes priority over ID
@@ -287,6 +295,32 @@ func TestInitCommand(t *testing.T) {
 	}
 }
 
+func TestInitNestedProjectRequiresForce(t *testing.T) {
+	parent := setupTestProject(t)
+	child := filepath.Join(parent, "sub")
+	if err := os.MkdirAll(child, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	chdir(t, child)
+	t.Cleanup(func() { forceInit = false })
+
+	rootCmd.SetArgs([]string{"init"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected nested init without --force to fail")
+	}
+	if _, err := os.Stat(filepath.Join(child, ".todos")); !os.IsNotExist(err) {
+		t.Fatal("nested .todos should not be created without --force")
+	}
+
+	rootCmd.SetArgs([]string{"init", "--force"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("nested init with --force failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(child, ".todos")); err != nil {
+		t.Fatalf("expected nested .todos with --force: %v", err)
+	}
+}
+
 func TestShowCommandJSON(t *testing.T) {
 	dir := setupTestProject(t)
 	chdir(t, dir)
@@ -342,6 +376,40 @@ func TestAddWithPathAndTag(t *testing.T) {
 	}
 }
 
+func TestAddWithLocation(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { addAt = nil })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"add", "Check token expiry", "--at", "src/auth.go:42", "--json", "--no-git"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	var todo types.Todo
+	if err := json.Unmarshal(buf.Bytes(), &todo); err != nil {
+		t.Fatalf("parse JSON: %v", err)
+	}
+	if len(todo.Context.Locations) != 1 {
+		t.Fatalf("expected 1 location, got %v", todo.Context.Locations)
+	}
+	if loc := todo.Context.Locations[0]; loc.Path != "src/auth.go" || loc.Line != 42 {
+		t.Fatalf("expected src/auth.go:42, got %+v", loc)
+	}
+	found := false
+	for _, p := range todo.Context.Paths {
+		if p == "src/auth.go" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected location path mirrored into paths, got %v", todo.Context.Paths)
+	}
+}
+
 func TestNextCommandJSON(t *testing.T) {
 	dir := setupTestProject(t)
 	chdir(t, dir)
@@ -377,6 +445,57 @@ func TestNextCommandJSON(t *testing.T) {
 	}
 }
 
+func TestNextScopesToBranch(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		nextJSON, nextAny = false, false
+		rootCmd.SetOut(nil)
+	})
+
+	cfg := types.DefaultConfig()
+	cfg.DefaultBranch = "feature"
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	todos := []types.Todo{
+		*types.NewTodo("other1", "urgent elsewhere"),
+		*types.NewTodo("feat1", "feature work"),
+	}
+	todos[0].Priority = types.PriorityHigh
+	todos[0].Context.Branch = "main"
+	todos[1].Priority = types.PriorityLow
+	todos[1].Context.Branch = "feature"
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	pick := func(args ...string) string {
+		t.Helper()
+		nextAny = false
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"next", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("next %v failed: %v", args, err)
+		}
+		var result struct {
+			Todo types.Todo `json:"todo"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+		}
+		return result.Todo.ID
+	}
+
+	if got := pick(); got != "feat1" {
+		t.Fatalf("expected branch-scoped pick feat1, got %q", got)
+	}
+	if got := pick("--any"); got != "other1" {
+		t.Fatalf("expected --any to pick other1, got %q", got)
+	}
+}
+
 func TestArchiveCommand(t *testing.T) {
 	dir := setupTestProject(t)
 	chdir(t, dir)
@@ -497,6 +616,9 @@ func TestImportCommand(t *testing.T) {
 	if len(loaded) != 1 || loaded[0].Text != "imported task" {
 		t.Fatalf("expected imported task, got %+v", loaded)
 	}
+	if loaded[0].Meta.Source != types.SourceImport {
+		t.Fatalf("expected source %q, got %q", types.SourceImport, loaded[0].Meta.Source)
+	}
 }
 
 func TestStatsCommandJSON(t *testing.T) {
@@ -620,3 +742,1862 @@ func TestInvalidProjectError(t *testing.T) {
 		t.Fatal("expected error for missing project")
 	}
 }
+
+func TestTagsCommandJSON(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	todos := []types.Todo{
+		*types.NewTodo("t1", "one"),
+		*types.NewTodo("t2", "two"),
+		*types.NewTodo("t3", "three"),
+	}
+	todos[0].Tags = []string{"api", "bug"}
+	todos[1].Tags = []string{"api", "bugs"}
+	todos[2].Tags = []string{"api"}
+	todos[2].MarkDone()
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"tags", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("tags failed: %v", err)
+	}
+
+	var result struct {
+		Tags    []tagCount  `json:"tags"`
+		Count   int         `json:"count"`
+		Similar [][2]string `json:"similar"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	if result.Count != 3 {
+		t.Fatalf("expected 3 tags, got %d", result.Count)
+	}
+	if first := result.Tags[0]; first.Tag != "api" || first.Total != 3 || first.Open != 2 {
+		t.Fatalf("expected api first with 2 open / 3 total, got %+v", first)
+	}
+	if len(result.Similar) != 1 || result.Similar[0] != [2]string{"bug", "bugs"} {
+		t.Fatalf("expected bug/bugs similarity, got %v", result.Similar)
+	}
+}
+
+func TestOpenPrint(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { openPrint = false; openPathIndex = 0 })
+
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	todo := types.NewTodo("o1", "two files")
+	todo.SetPaths([]string{"src/a.go"})
+	todo.AddLocation(types.Location{Path: "src/b.go", Line: 12})
+	if err := storage.SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	root, err := storage.FindProjectRoot(dir)
+	if err != nil {
+		t.Fatalf("find root: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"open", "1", "--print", "--path-index", "2"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if got, want := strings.TrimSpace(buf.String()), filepath.Join(root, "src", "b.go"); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	// Without a TTY, several paths and no --path-index is an error.
+	openPathIndex = 0
+	rootCmd.SetArgs([]string{"open", "1", "--print"})
+	openCmd.Flags().Lookup("path-index").Changed = false
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected error when several paths and no --path-index")
+	}
+}
+
+func TestRenameAndDeleteTag(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { renameTagDryRun = false })
+
+	todos := []types.Todo{
+		*types.NewTodo("r1", "one"),
+		*types.NewTodo("r2", "two"),
+		*types.NewTodo("r3", "three"),
+	}
+	todos[0].Tags = []string{"ui"}
+	todos[1].Tags = []string{"frontend", "ui"}
+	todos[2].Tags = []string{"backend"}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"rename-tag", "UI", "frontend", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("rename-tag dry run failed: %v", err)
+	}
+	loaded, _ := storage.LoadTodos(dir)
+	if got, _ := storage.FindTodoByID(loaded, "r1"); got.Tags[0] != "ui" {
+		t.Fatalf("dry run should not change tags, got %v", got.Tags)
+	}
+
+	renameTagDryRun = false
+	rootCmd.SetArgs([]string{"rename-tag", "ui", "frontend"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("rename-tag failed: %v", err)
+	}
+	loaded, _ = storage.LoadTodos(dir)
+	for _, id := range []string{"r1", "r2"} {
+		got, _ := storage.FindTodoByID(loaded, id)
+		if len(got.Tags) != 1 || got.Tags[0] != "frontend" {
+			t.Fatalf("todo %s: expected [frontend], got %v", id, got.Tags)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"delete-tag", "backend"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("delete-tag failed: %v", err)
+	}
+	loaded, _ = storage.LoadTodos(dir)
+	if got, _ := storage.FindTodoByID(loaded, "r3"); len(got.Tags) != 0 {
+		t.Fatalf("expected backend tag removed, got %v", got.Tags)
+	}
+}
+
+func TestGroupLogByDay(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	mk := func(id string, at time.Time) types.Todo {
+		todo := types.NewTodo(id, id)
+		todo.Status = types.StatusDone
+		todo.UpdatedAt = at
+		return *todo
+	}
+	todos := []types.Todo{
+		mk("old", now.AddDate(0, 0, -3)),
+		mk("today-early", now.Add(-5*time.Hour)),
+		mk("yesterday", now.AddDate(0, 0, -1)),
+		mk("today-late", now.Add(-time.Hour)),
+	}
+
+	days := groupLogByDay(todos, now)
+	if len(days) != 3 {
+		t.Fatalf("expected 3 day groups, got %d", len(days))
+	}
+	if days[0].Label != "Today" || len(days[0].Todos) != 2 || days[0].Todos[0].ID != "today-late" {
+		t.Fatalf("unexpected today group: %+v", days[0])
+	}
+	if days[1].Label != "Yesterday" {
+		t.Fatalf("expected Yesterday, got %q", days[1].Label)
+	}
+	if days[2].Label != "Saturday, Mar 7" {
+		t.Fatalf("expected dated header, got %q", days[2].Label)
+	}
+
+	since, err := parsePastDateInput("7d", now, false)
+	if err != nil || !since.Equal(now.AddDate(0, 0, -7)) {
+		t.Fatalf("parsePastDateInput(7d) = %v, %v", since, err)
+	}
+	if _, err := parsePastDateInput("soon", now, false); err == nil {
+		t.Fatal("expected error for invalid --since")
+	}
+}
+
+func TestLogUsesCompletedAt(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	completed := now.AddDate(0, 0, -3)
+	edited := *types.NewTodo("edited", "done three days ago, edited today")
+	edited.Status = types.StatusDone
+	edited.CompletedAt = &completed
+	edited.UpdatedAt = now.Add(-time.Hour)
+	legacy := *types.NewTodo("legacy", "done yesterday, no CompletedAt")
+	legacy.Status = types.StatusDone
+	legacy.UpdatedAt = now.AddDate(0, 0, -1)
+
+	days := groupLogByDay([]types.Todo{edited, legacy}, now)
+	if len(days) != 2 {
+		t.Fatalf("expected 2 day groups, got %+v", days)
+	}
+	if days[0].Label != "Yesterday" || days[0].Todos[0].ID != "legacy" {
+		t.Fatalf("expected legacy todo under Yesterday first, got %+v", days[0])
+	}
+	if days[1].Label != "Saturday, Mar 7" || days[1].Todos[0].ID != "edited" {
+		t.Fatalf("expected edited todo under its completion day, got %+v", days[1])
+	}
+
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	recent := time.Now().Add(-time.Hour)
+	old := time.Now().AddDate(0, 0, -3)
+	edited.CompletedAt = &old
+	edited.UpdatedAt = recent
+	if err := storage.SaveTodos(dir, []types.Todo{edited}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	t.Cleanup(func() {
+		logSince = ""
+		logJSON = false
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs([]string{"log", "--since", "1d", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("log failed: %v", err)
+	}
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("decode: %v\n%s", err, buf.String())
+	}
+	if out.Count != 0 {
+		t.Fatalf("expected todo completed 3 days ago to be outside --since 1d, got %d", out.Count)
+	}
+}
+
+func TestEditAddAndRemovePaths(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	todo := types.NewTodo("p1", "paths")
+	todo.Context.Paths = []string{"a.go", "b.go", "c.go"}
+	if err := storage.SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"edit", "p1", "--add-path", "d.go,a.go", "--remove-path", "b.go"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	t.Cleanup(func() {
+		editAddPaths = nil
+		editRemovePaths = nil
+		editCmd.Flags().Lookup("add-path").Changed = false
+		editCmd.Flags().Lookup("remove-path").Changed = false
+	})
+
+	loaded, _ := storage.LoadTodos(dir)
+	got, _ := storage.FindTodoByID(loaded, "p1")
+	want := []string{"a.go", "c.go", "d.go"}
+	if strings.Join(got.Context.Paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("paths = %v, want %v", got.Context.Paths, want)
+	}
+}
+
+func TestWhichJSON(t *testing.T) {
+	dir := setupTestProject(t)
+	sub := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	chdir(t, sub)
+	t.Cleanup(func() { whichJSON = false })
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"which", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("which failed: %v", err)
+	}
+
+	var info whichInfo
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil {
+		t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	root, _ := storage.CanonicalPath(dir)
+	if info.ProjectRoot != root {
+		t.Fatalf("projectRoot = %q, want %q", info.ProjectRoot, root)
+	}
+	if info.ConfigFile != storage.GetConfigPath(root) {
+		t.Fatalf("configFile = %q", info.ConfigFile)
+	}
+	if info.UserFile != filepath.Join(root, storage.TodosDir, storage.UsersDir, "test-user.json") {
+		t.Fatalf("userFile = %q", info.UserFile)
+	}
+}
+
+func TestDoneRecurringSpawnsNextOccurrence(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	due := time.Date(2026, 3, 2, 17, 0, 0, 0, time.UTC)
+	todo := types.NewTodo("rc1", "water plants")
+	todo.Recur = types.RecurWeekly
+	todo.DueAt = &due
+	todo.Tags = []string{"home"}
+	if err := storage.SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"status", "rc1", "done"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("status done failed: %v", err)
+	}
+
+	loaded, _ := storage.LoadTodos(dir)
+	if len(loaded) != 2 {
+		t.Fatalf("expected original plus next occurrence, got %d todos", len(loaded))
+	}
+	var next *types.Todo
+	for i := range loaded {
+		if loaded[i].ID != "rc1" {
+			next = &loaded[i]
+		}
+	}
+	if next.Status != types.StatusOpen || next.Recur != types.RecurWeekly || next.Text != "water plants" {
+		t.Fatalf("unexpected next occurrence: %+v", next)
+	}
+	if next.DueAt == nil || !next.DueAt.Equal(due.AddDate(0, 0, 7)) {
+		t.Fatalf("expected due advanced one week, got %v", next.DueAt)
+	}
+}
+
+func TestConfigEditorAndList(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		configEditor = ""
+		configList = false
+		configCmd.Flags().Lookup("editor").Changed = false
+	})
+
+	rootCmd.SetArgs([]string{"config", "--editor", "definitely-not-an-editor -w"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config --editor failed: %v", err)
+	}
+	configCmd.Flags().Lookup("editor").Changed = false
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"config", "--list"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config --list failed: %v", err)
+	}
+	var cfg types.Config
+	if err := json.Unmarshal(buf.Bytes(), &cfg); err != nil {
+		t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	if cfg.Editor != "definitely-not-an-editor -w" || !cfg.AutoGit {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if got := resolveEditor(dir); got != "definitely-not-an-editor -w" {
+		t.Fatalf("resolveEditor = %q, want config editor", got)
+	}
+}
+
+func TestStatusChangesRecordHistory(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { historyJSON = false })
+
+	if err := storage.SaveTodos(dir, []types.Todo{*types.NewTodo("hist1", "ship it")}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"status", "hist1", "blocked"},
+		{"status", "hist1", "blocked"},
+		{"done", "hist1"},
+	} {
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs([]string{"history", "hist1", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+
+	var payload struct {
+		History []types.StatusChange `json:"history"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, out.String())
+	}
+	want := []types.StatusChange{
+		{From: types.StatusOpen, To: types.StatusBlocked},
+		{From: types.StatusBlocked, To: types.StatusDone},
+	}
+	if len(payload.History) != len(want) {
+		t.Fatalf("expected %d changes (no-op skipped), got %+v", len(want), payload.History)
+	}
+	for i, w := range want {
+		if payload.History[i].From != w.From || payload.History[i].To != w.To || payload.History[i].At.IsZero() {
+			t.Fatalf("change %d = %+v, want %s → %s", i, payload.History[i], w.From, w.To)
+		}
+	}
+}
+
+func TestStatusHistoryIsBounded(t *testing.T) {
+	todo := types.NewTodo("h", "flip")
+	for i := 0; i < types.MaxStatusHistory+5; i++ {
+		todo.Toggle()
+	}
+	if len(todo.History) != types.MaxStatusHistory {
+		t.Fatalf("expected history capped at %d, got %d", types.MaxStatusHistory, len(todo.History))
+	}
+	if last := todo.History[len(todo.History)-1]; last.To != todo.Status {
+		t.Fatalf("last change should match current status, got %+v", last)
+	}
+}
+
+func TestAddFromStdin(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON = []string{}, []string{}, false
+	t.Cleanup(func() {
+		addFromStdin = false
+		addPriority = "medium"
+		addPaths = []string{}
+		addCmd.Flags().Lookup("priority").Changed = false
+		addCmd.Flags().Lookup("path").Changed = false
+		rootCmd.SetIn(nil)
+	})
+
+	rootCmd.SetIn(strings.NewReader("# sprint backlog\nwrite docs\n\n  fix login  \n# done later\nship it\n"))
+	rootCmd.SetArgs([]string{"add", "--from-stdin", "--priority", "high", "--path", "src", "--no-git"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add --from-stdin failed: %v", err)
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 3 {
+		t.Fatalf("expected 3 todos, got %d", len(loaded))
+	}
+	want := map[string]bool{"write docs": true, "fix login": true, "ship it": true}
+	for _, todo := range loaded {
+		if !want[todo.Text] {
+			t.Errorf("unexpected todo %q", todo.Text)
+		}
+		if todo.Priority != types.PriorityHigh || len(todo.Context.Paths) != 1 || todo.Context.Paths[0] != "src" {
+			t.Errorf("shared flags not applied to %q: %+v", todo.Text, todo)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"add", "--from-stdin", "extra text"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--from-stdin") {
+		t.Fatalf("expected error combining --from-stdin with text, got %v", err)
+	}
+}
+
+func TestAddTextFromStdin(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON = []string{}, []string{}, false
+	t.Cleanup(func() {
+		addPriority = "medium"
+		addPaths = []string{}
+		addCmd.Flags().Lookup("priority").Changed = false
+		addCmd.Flags().Lookup("path").Changed = false
+		rootCmd.SetIn(nil)
+	})
+
+	rootCmd.SetIn(strings.NewReader("Fix flaky login test in auth.go\n"))
+	rootCmd.SetArgs([]string{"add", "-", "--priority", "high", "--path", "src", "--no-git"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add - failed: %v", err)
+	}
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Text != "Fix flaky login test in auth.go" {
+		t.Fatalf("expected one todo with the piped text, got %+v", loaded)
+	}
+	if loaded[0].Priority != types.PriorityHigh || len(loaded[0].Context.Paths) != 1 || loaded[0].Context.Paths[0] != "src" {
+		t.Fatalf("flags not applied: %+v", loaded[0])
+	}
+
+	for _, tc := range []struct {
+		stdin string
+		args  []string
+		want  string
+	}{
+		{"text", []string{"add", "-", "more text"}, "cannot be combined"},
+		{"one\ntwo\n", []string{"add", "-"}, "--from-stdin"},
+		{"  \n", []string{"add", "-"}, "no todo text"},
+	} {
+		rootCmd.SetIn(strings.NewReader(tc.stdin))
+		rootCmd.SetArgs(tc.args)
+		if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v with stdin %q: expected an error mentioning %q, got %v", tc.args, tc.stdin, tc.want, err)
+		}
+	}
+}
+
+func TestStatusAcceptsCustomStatus(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { types.SetCustomStatuses(nil) })
+
+	cfg := types.DefaultConfig()
+	cfg.CustomStatuses = []types.CustomStatus{{Name: "in-review", Icon: "👀", Color: "cyan"}}
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	if err := storage.SaveTodos(dir, []types.Todo{*types.NewTodo("cs1", "review me")}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"status", "cs1", "in-review"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("status in-review failed: %v", err)
+	}
+	loaded, _ := storage.LoadTodos(dir)
+	if len(loaded) != 1 || loaded[0].Status != "in-review" {
+		t.Fatalf("expected custom status saved, got %+v", loaded)
+	}
+
+	rootCmd.SetArgs([]string{"status", "cs1", "in-qa"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "in-review") {
+		t.Fatalf("expected invalid status error listing custom statuses, got %v", err)
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		statusForce, doneForce, editForce = false, false, false
+	})
+
+	cfg := types.DefaultConfig()
+	cfg.StatusTransitions = map[types.Status][]types.Status{
+		types.StatusDone: {types.StatusOpen},
+	}
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	done := *types.NewTodo("st1", "shipped")
+	done.MarkDone()
+	if err := storage.SaveTodos(dir, []types.Todo{done, *types.NewTodo("st2", "open one")}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	statusOf := func(id string) types.Status {
+		t.Helper()
+		loaded, _ := storage.LoadTodos(dir)
+		todo, _ := storage.FindTodoByID(loaded, id)
+		return todo.Status
+	}
+
+	// Unlisted statuses move freely; a refused target stops the whole batch.
+	rootCmd.SetArgs([]string{"status", "st2", "st1", "tech-debt"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "done → tech-debt") || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected refused transition, got %v", err)
+	}
+	if statusOf("st1") != types.StatusDone || statusOf("st2") != types.StatusOpen {
+		t.Fatal("refused batch should change nothing")
+	}
+
+	rootCmd.SetArgs([]string{"edit", "st1", "--status", "blocked"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected edit --status to refuse done → blocked")
+	}
+
+	rootCmd.SetArgs([]string{"status", "st1", "open"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("allowed transition failed: %v", err)
+	}
+	rootCmd.SetArgs([]string{"status", "st1", "done"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unrestricted transition failed: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"status", "st1", "tech-debt", "--force"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("status --force failed: %v", err)
+	}
+	if got := statusOf("st1"); got != types.StatusTechDebt {
+		t.Fatalf("expected tech-debt after --force, got %s", got)
+	}
+}
+
+func TestStatusMultipleTargets(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	blocked := *types.NewTodo("mt2", "already blocked")
+	blocked.SetStatus(types.StatusBlocked)
+	blockedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	blocked.UpdatedAt = blockedAt
+	todos := []types.Todo{*types.NewTodo("mt1", "first"), blocked, *types.NewTodo("mt3", "third")}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// Indices and IDs mix; the unknown target is skipped with a warning.
+	rootCmd.SetArgs([]string{"status", "1", "mt2", "mt3", "nope", "blocked"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("status with several targets failed: %v", err)
+	}
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for _, todo := range loaded {
+		if todo.Status != types.StatusBlocked {
+			t.Fatalf("expected every todo blocked, got %s for %s", todo.Status, todo.ID)
+		}
+		if todo.ID == "mt2" && (!todo.UpdatedAt.Equal(blockedAt) || len(todo.History) != len(blocked.History)) {
+			t.Fatalf("unchanged todo was touched: %+v", todo)
+		}
+	}
+
+	if got, want := statusSummary(types.StatusBlocked, 2, 1, 1), "Set 2 of 4 todo(s) to blocked (1 already blocked, 1 not found)"; got != want {
+		t.Fatalf("statusSummary = %q, want %q", got, want)
+	}
+	if got, want := statusSummary(types.StatusDone, 3, 0, 0), "Set 3 of 3 todo(s) to done"; got != want {
+		t.Fatalf("statusSummary = %q, want %q", got, want)
+	}
+
+	rootCmd.SetArgs([]string{"status", "mt1", "mt3", "finished"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "finished") {
+		t.Fatalf("expected an invalid status error, got %v", err)
+	}
+}
+
+func TestPromptCommand(t *testing.T) {
+	t.Cleanup(func() {
+		noColor = false
+		rootCmd.PersistentFlags().Lookup("no-color").Changed = false
+		rootCmd.SetOut(nil)
+	})
+
+	run := func() string {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs([]string{"prompt", "--no-color"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("prompt failed: %v", err)
+		}
+		return buf.String()
+	}
+
+	chdir(t, t.TempDir())
+	if out := run(); out != "" {
+		t.Fatalf("expected no output outside a project, got %q", out)
+	}
+
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	if out := run(); out != "" {
+		t.Fatalf("expected no output with no todos, got %q", out)
+	}
+
+	todos := []types.Todo{
+		*types.NewTodo("p1", "one"),
+		*types.NewTodo("p2", "two"),
+		*types.NewTodo("p3", "stuck"),
+		*types.NewTodo("p4", "finished"),
+	}
+	todos[2].Status = types.StatusBlocked
+	todos[3].MarkDone()
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if out := run(); out != "●2 ✗1\n" {
+		t.Fatalf("unexpected prompt output %q", out)
+	}
+
+	// prompt leaves config.json alone, so its custom statuses stay unregistered.
+	t.Cleanup(func() { types.SetCustomStatuses(nil) })
+	cfg := types.DefaultConfig()
+	cfg.CustomStatuses = []types.CustomStatus{{Name: "in-review", Color: "cyan"}}
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	run()
+	if got := types.CustomStatuses(); len(got) != 0 {
+		t.Fatalf("expected prompt to skip config.json, got custom statuses %+v", got)
+	}
+}
+
+func TestSaveListChangeKeepsHiddenTodos(t *testing.T) {
+	dir := setupTestProject(t)
+	todos := []types.Todo{
+		*types.NewTodo("a1", "shown"),
+		*types.NewTodo("b2", "hidden by the list filter"),
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	err := saveListChange(dir, "a1", func(all []types.Todo, i int) ([]types.Todo, error) {
+		return storage.DeleteTodo(all, i), nil
+	})
+	if err != nil {
+		t.Fatalf("saveListChange: %v", err)
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "b2" {
+		t.Fatalf("expected only b2 to remain, got %+v", loaded)
+	}
+
+	if err := saveListChange(dir, "missing", func(all []types.Todo, i int) ([]types.Todo, error) {
+		return all, nil
+	}); err == nil {
+		t.Fatal("expected an error for a todo that no longer exists")
+	}
+}
+
+func TestAddListTodoKeepsHiddenTodos(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	if err := storage.SaveTodos(dir, []types.Todo{*types.NewTodo("b2", "hidden by the list filter")}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	todo, err := addListTodo(dir, "added from the list")
+	if err != nil {
+		t.Fatalf("addListTodo: %v", err)
+	}
+	if todo.CreatedBy != "test-user" || todo.Status != types.StatusOpen {
+		t.Fatalf("unexpected new todo: %+v", todo)
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].ID != todo.ID || loaded[1].Text != "added from the list" {
+		t.Fatalf("expected b2 and the new todo, got %+v", loaded)
+	}
+}
+
+func TestAddBeforeAfter(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON = []string{}, []string{}, false
+	t.Cleanup(func() {
+		addBefore, addAfter = "", ""
+		addCmd.Flags().Lookup("before").Changed = false
+		addCmd.Flags().Lookup("after").Changed = false
+	})
+
+	run := func(args ...string) error {
+		addBefore, addAfter = "", ""
+		rootCmd.SetArgs(append([]string{"add", "--no-git"}, args...))
+		return rootCmd.Execute()
+	}
+	texts := func() []string {
+		todos, err := storage.LoadTodos(dir)
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		out := make([]string, len(todos))
+		for i, todo := range todos {
+			out[i] = todo.Text
+		}
+		return out
+	}
+
+	for _, text := range []string{"one", "three"} {
+		if err := run(text); err != nil {
+			t.Fatalf("add %s: %v", text, err)
+		}
+	}
+	if err := run("two", "--after", "1"); err != nil {
+		t.Fatalf("add --after: %v", err)
+	}
+	if err := run("zero", "--before", "1"); err != nil {
+		t.Fatalf("add --before: %v", err)
+	}
+	if err := run("four", "--after", "4"); err != nil {
+		t.Fatalf("add --after last: %v", err)
+	}
+	want := []string{"zero", "one", "two", "three", "four"}
+	if got := texts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("order = %v; want %v", got, want)
+	}
+
+	if err := run("x", "--before", "1", "--after", "2"); err == nil {
+		t.Fatal("expected an error when combining --before and --after")
+	}
+	if err := run("x", "--after", "99"); err == nil {
+		t.Fatal("expected an error for an unknown reference")
+	}
+	if got := texts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("failed adds changed the todos: %v", got)
+	}
+}
+
+func TestSaveLastSelected(t *testing.T) {
+	dir := setupTestProject(t)
+
+	if err := saveLastSelected(dir, "abc123"); err != nil {
+		t.Fatalf("saveLastSelected: %v", err)
+	}
+	config, err := storage.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if config.LastSelected != "abc123" {
+		t.Fatalf("expected lastSelected abc123, got %q", config.LastSelected)
+	}
+
+	problems, err := storage.ValidateConfig(dir)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("lastSelected should be a valid config key, got %v (%v)", problems, err)
+	}
+
+	if err := saveLastSelected(dir, ""); err != nil {
+		t.Fatalf("clear lastSelected: %v", err)
+	}
+	data, err := os.ReadFile(storage.GetConfigPath(dir))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(data), "lastSelected") {
+		t.Fatalf("empty lastSelected should be omitted, got %s", data)
+	}
+}
+
+func TestRenameBranch(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { renameBranchDryRun, renameBranchCurrent = false, false })
+
+	todos := []types.Todo{
+		*types.NewTodo("b1", "one"),
+		*types.NewTodo("b2", "two"),
+		*types.NewTodo("b3", "three"),
+	}
+	todos[0].Context.Branch = "feat-x"
+	todos[1].Context.Branch = "main"
+	todos[2].Context.Branch = "feat-x"
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"rename-branch", "feat-x", "feature/x"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("rename-branch failed: %v", err)
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := map[string]string{"b1": "feature/x", "b2": "main", "b3": "feature/x"}
+	for id, branch := range want {
+		got, _ := storage.FindTodoByID(loaded, id)
+		if got.Context.Branch != branch {
+			t.Fatalf("todo %s: expected branch %q, got %q", id, branch, got.Context.Branch)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"rename-branch", "main", "main"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an error when old and new branch match")
+	}
+}
+
+func TestFocusPathScope(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		focusJSON, focusAll, focusPath = false, false, ""
+		rootCmd.SetOut(nil)
+	})
+
+	cfg := types.DefaultConfig()
+	cfg.DefaultBranch = "feature"
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	todos := []types.Todo{
+		*types.NewTodo("auth1", "feature auth"),
+		*types.NewTodo("ui1", "feature ui"),
+		*types.NewTodo("auth2", "main auth"),
+	}
+	todos[0].Context.Branch, todos[0].Context.Paths = "feature", []string{"src/auth/login.go"}
+	todos[1].Context.Branch, todos[1].Context.Paths = "feature", []string{"src/ui"}
+	todos[2].Context.Branch, todos[2].Context.Paths = "main", []string{"src/auth"}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	focused := func(args ...string) []string {
+		t.Helper()
+		focusAll, focusPath = false, ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"focus", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("focus %v failed: %v", args, err)
+		}
+		var result struct {
+			Todos []types.Todo `json:"todos"`
+			Path  string       `json:"path"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+		}
+		ids := make([]string, len(result.Todos))
+		for i, todo := range result.Todos {
+			ids[i] = todo.ID
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	if got := focused("--path", "src/auth"); !reflect.DeepEqual(got, []string{"auth1"}) {
+		t.Fatalf("branch + path scope = %v; want [auth1]", got)
+	}
+	if got := focused("--path", "src/auth", "--all"); !reflect.DeepEqual(got, []string{"auth1", "auth2"}) {
+		t.Fatalf("--all keeps the path scope, got %v", got)
+	}
+}
+
+func TestFocusScopeConfig(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		focusJSON, focusAll, focusBranch = false, false, false
+		configBranchScope = ""
+		configCmd.Flags().Lookup("auto-branch-scope").Changed = false
+		rootCmd.SetOut(nil)
+	})
+
+	cfg := types.DefaultConfig()
+	cfg.DefaultBranch = "feature"
+	if err := storage.SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	todos := []types.Todo{
+		*types.NewTodo("feat1", "on feature"),
+		*types.NewTodo("main1", "on main"),
+	}
+	todos[0].Context.Branch = "feature"
+	todos[1].Context.Branch = "main"
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	focused := func(args ...string) []string {
+		t.Helper()
+		focusAll, focusBranch = false, false
+		focusCmd.Flags().Lookup("all").Changed = false
+		focusCmd.Flags().Lookup("branch").Changed = false
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"focus", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("focus %v failed: %v", args, err)
+		}
+		var result struct {
+			Todos []types.Todo `json:"todos"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+		}
+		ids := make([]string, len(result.Todos))
+		for i, todo := range result.Todos {
+			ids[i] = todo.ID
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	if got := focused(); !reflect.DeepEqual(got, []string{"feat1"}) {
+		t.Fatalf("default scope = %v; want [feat1]", got)
+	}
+
+	rootCmd.SetArgs([]string{"config", "--auto-branch-scope", "false"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config --auto-branch-scope: %v", err)
+	}
+	loaded, err := storage.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if loaded.FocusScope != "all" {
+		t.Fatalf("focusScope = %q; want all", loaded.FocusScope)
+	}
+
+	if got := focused(); !reflect.DeepEqual(got, []string{"feat1", "main1"}) {
+		t.Fatalf("focusScope all = %v; want both todos", got)
+	}
+	if got := focused("--branch"); !reflect.DeepEqual(got, []string{"feat1"}) {
+		t.Fatalf("--branch should override focusScope, got %v", got)
+	}
+}
+
+func TestEstimateAndCapacity(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON = []string{}, []string{}, false
+	t.Cleanup(func() {
+		addEstimate, editEstimate, capacityJSON = 0, 0, false
+		addCmd.Flags().Lookup("estimate").Changed = false
+		editCmd.Flags().Lookup("estimate").Changed = false
+		rootCmd.SetOut(nil)
+	})
+
+	run := func(args ...string) error {
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+	if err := run("add", "--no-git", "big task", "--estimate", "5"); err != nil {
+		t.Fatalf("add --estimate: %v", err)
+	}
+	addEstimate = 0
+	if err := run("add", "--no-git", "small task"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := run("edit", "2", "--estimate", "2"); err != nil {
+		t.Fatalf("edit --estimate: %v", err)
+	}
+	if err := run("edit", "2", "--estimate", "-1"); err == nil {
+		t.Fatal("expected an error for a negative estimate")
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded[0].Estimate != 5 || loaded[1].Estimate != 2 {
+		t.Fatalf("unexpected estimates: %d, %d", loaded[0].Estimate, loaded[1].Estimate)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	if err := run("capacity", "--json"); err != nil {
+		t.Fatalf("capacity: %v", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	if points := int(result["points"].(float64)); points != 7 {
+		t.Fatalf("expected 7 outstanding points, got %d", points)
+	}
+	if byBranch := result["byBranch"].(map[string]any); int(byBranch[""].(float64)) != 7 {
+		t.Fatalf("expected 7 points without a branch, got %v", byBranch)
+	}
+}
+
+func TestCheckAddDuplicates(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("d1", "Fix login bug"),
+		*types.NewTodo("d2", "Write docs"),
+	}
+	todos[1].MarkDone()
+
+	warnings, err := checkAddDuplicates(todos, []string{"  fix LOGIN   bug "}, false, false)
+	if err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], "#1") {
+		t.Fatalf("expected one warning naming #1, got %v (err %v)", warnings, err)
+	}
+	if _, err := checkAddDuplicates(todos, []string{"Fix login bug"}, false, true); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected an interactive duplicate to require --force, got %v", err)
+	}
+	if warnings, err := checkAddDuplicates(todos, []string{"Fix login bug"}, true, true); err != nil || len(warnings) != 0 {
+		t.Fatalf("expected --force to proceed quietly, got %v (err %v)", warnings, err)
+	}
+	if warnings, err := checkAddDuplicates(todos, []string{"Write docs", "Fix login bugs"}, false, true); err != nil || len(warnings) != 0 {
+		t.Fatalf("done todos and similar texts should not match, got %v (err %v)", warnings, err)
+	}
+}
+
+func TestOrderCommand(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		orderJSON = false
+		rootCmd.SetOut(nil)
+	})
+
+	todos := []types.Todo{
+		*types.NewTodo("o1", "one"),
+		*types.NewTodo("o2", "two"),
+		*types.NewTodo("o3", "three"),
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"order", "3", "o1", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("order failed: %v", err)
+	}
+	var result struct {
+		Order []string `json:"order"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("parse JSON: %v\noutput: %s", err, buf.String())
+	}
+	if want := []string{"o3", "o1", "o2"}; !reflect.DeepEqual(result.Order, want) {
+		t.Fatalf("order = %v; want %v", result.Order, want)
+	}
+
+	for _, args := range [][]string{{"order", "1", "1"}, {"order", "nope"}} {
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err == nil {
+			t.Fatalf("expected %v to fail", args)
+		}
+	}
+}
+
+func TestStartStopTimer(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	todos := []types.Todo{
+		*types.NewTodo("t1", "first"),
+		*types.NewTodo("t2", "second"),
+		*types.NewTodo("t3", "yours, timed by a teammate"),
+	}
+	for i := range todos {
+		todos[i].CreatedBy = "test-user"
+	}
+	todos[2].StartTimer(time.Now(), "ada-lovelace")
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	run := func(args ...string) error {
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+	running := func(id string) bool {
+		t.Helper()
+		loaded, err := storage.LoadTodos(dir)
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		todo, _ := storage.FindTodoByID(loaded, id)
+		return todo != nil && todo.StartedAt != nil
+	}
+
+	if err := run("start", "t1"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if !running("t1") {
+		t.Fatal("expected a running timer on the first todo")
+	}
+
+	// Starting another todo stops the first, but not a teammate's.
+	if err := run("start", "t2"); err != nil {
+		t.Fatalf("start second: %v", err)
+	}
+	if running("t1") || !running("t2") {
+		t.Fatal("expected only the second of your timers running")
+	}
+	if !running("t3") {
+		t.Fatal("starting a timer should leave one a teammate started running")
+	}
+
+	if err := run("stop"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if running("t2") {
+		t.Fatal("expected the timer to be stopped")
+	}
+	if !running("t3") {
+		t.Fatal("stop should leave a timer a teammate started running")
+	}
+	if err := run("stop"); err != nil {
+		t.Fatalf("stop without a timer of yours should only warn: %v", err)
+	}
+
+	if err := run("done", "t1"); err != nil {
+		t.Fatalf("done: %v", err)
+	}
+	if err := run("start", "t1"); err == nil {
+		t.Fatal("expected starting a done todo to fail")
+	}
+}
+
+func TestConfigSetGetUnset(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		configSet, configUnset, configGet = []string{}, []string{}, ""
+		configCmd.Flags().Lookup("get").Changed = false
+		rootCmd.SetOut(nil)
+	})
+
+	run := func(args ...string) (string, error) {
+		configSet, configUnset = []string{}, []string{}
+		configCmd.Flags().Lookup("get").Changed = false
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"config"}, args...))
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("--set", "default_branch=develop", "--set", "autoGit=false"); err != nil {
+		t.Fatalf("config --set: %v", err)
+	}
+	if out, err := run("--get", "defaultBranch"); err != nil || out != "develop\n" {
+		t.Fatalf("config --get defaultBranch = %q, %v; want develop", out, err)
+	}
+	if out, err := run("--get", "auto-git"); err != nil || out != "false\n" {
+		t.Fatalf("config --get auto-git = %q, %v; want false", out, err)
+	}
+
+	if _, err := run("--unset", "autoGit", "--unset", "defaultBranch"); err != nil {
+		t.Fatalf("config --unset: %v", err)
+	}
+	cfg, err := storage.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.AutoGit || cfg.DefaultBranch != "" {
+		t.Fatalf("expected defaults after --unset, got %+v", cfg)
+	}
+
+	for _, args := range [][]string{
+		{"--set", "stale_days=14"},
+		{"--get", "stale_days"},
+		{"--unset", "nope"},
+		{"--set", "autoGit"},
+		{"--set", "autoGit=sometimes"},
+	} {
+		if _, err := run(args...); err == nil {
+			t.Fatalf("expected config %v to fail", args)
+		}
+	}
+}
+
+func TestDoneAllInPath(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		doneAllInPath, doneYes = "", false
+		doneCmd.Flags().Lookup("all-in-path").Changed = false
+		doneCmd.Flags().Lookup("yes").Changed = false
+	})
+
+	todos := []types.Todo{
+		*types.NewTodo("p1", "billing one"),
+		*types.NewTodo("p2", "billing two"),
+		*types.NewTodo("p3", "auth"),
+		*types.NewTodo("p4", "billing blocked"),
+		*types.NewTodo("p5", "sibling directory"),
+	}
+	todos[0].SetPaths([]string{"src/billing/a.go"})
+	todos[1].SetPaths([]string{"src/billing"})
+	todos[2].SetPaths([]string{"src/auth"})
+	todos[3].SetPaths([]string{"src/billing/b.go"})
+	todos[3].Status = types.StatusBlocked
+	todos[4].SetPaths([]string{"src/billingfoo/a.go"})
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// Without --yes the prompt can't be answered in tests, so nothing changes.
+	rootCmd.SetArgs([]string{"done", "--all-in-path", "src/billing"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an unconfirmed --all-in-path to fail")
+	}
+	rootCmd.SetArgs([]string{"done", "--all-in-path", "src/billing", "1"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected --all-in-path with arguments to fail")
+	}
+	rootCmd.SetArgs([]string{"done", "--all-in-path", "./", "--yes"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an empty path scope to fail")
+	}
+
+	rootCmd.SetArgs([]string{"done", "--all-in-path", "./src/billing", "--yes"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("done --all-in-path: %v", err)
+	}
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := map[string]types.Status{"p1": types.StatusDone, "p2": types.StatusDone, "p3": types.StatusOpen, "p4": types.StatusBlocked, "p5": types.StatusOpen}
+	for _, todo := range loaded {
+		if todo.Status != want[todo.ID] {
+			t.Fatalf("%s: status %s, want %s", todo.ID, todo.Status, want[todo.ID])
+		}
+	}
+}
+
+func TestConfigTheme(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		configTheme = ""
+		configCmd.Flags().Lookup("theme").Changed = false
+		_ = terminal.SetTheme("")
+	})
+
+	rootCmd.SetArgs([]string{"config", "--theme", "Mono"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config --theme: %v", err)
+	}
+	cfg, err := storage.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Theme != "mono" {
+		t.Fatalf("expected theme mono, got %q", cfg.Theme)
+	}
+
+	rootCmd.SetArgs([]string{"config", "--theme", "neon"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an unknown theme to fail")
+	}
+}
+
+func TestQuietAdd(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addJSON = []string{}, []string{}, false
+	t.Cleanup(func() {
+		quiet, addJSON = false, false
+		terminal.SetQuiet(false)
+		rootCmd.SetOut(nil)
+	})
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"add", "quiet one", "--no-git", "-q"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add -q: %v", err)
+	}
+	todos, err := storage.LoadTodos(dir)
+	if err != nil || len(todos) != 1 {
+		t.Fatalf("load: %v, %d todos", err, len(todos))
+	}
+	if got := buf.String(); got != todos[0].ID+"\n" {
+		t.Fatalf("add -q printed %q, want only the ID", got)
+	}
+
+	// --json still wins for structured output.
+	buf.Reset()
+	rootCmd.SetArgs([]string{"add", "quiet two", "--no-git", "-q", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add -q --json: %v", err)
+	}
+	var todo types.Todo
+	if err := json.Unmarshal(buf.Bytes(), &todo); err != nil || todo.Text != "quiet two" {
+		t.Fatalf("expected JSON output, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestListLimitOpenOnly(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	listStatus, listPath, listPriority, listTags = "", "", "", []string{}
+	t.Cleanup(func() {
+		listLimit, listOpenOnly, listJSON, listStatus = 0, false, false, ""
+		rootCmd.SetOut(nil)
+	})
+
+	todos := []types.Todo{
+		*types.NewTodo("id1", "low open"),
+		*types.NewTodo("id2", "high done"),
+		*types.NewTodo("id3", "medium open"),
+		*types.NewTodo("id4", "high open"),
+	}
+	todos[0].Priority = types.PriorityLow
+	todos[1].Priority = types.PriorityHigh
+	todos[1].MarkDone()
+	todos[3].Priority = types.PriorityHigh
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	run := func(args ...string) (texts []string, total int) {
+		t.Helper()
+		listLimit, listOpenOnly, listStatus = 0, false, ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"list", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("list %v: %v", args, err)
+		}
+		var result struct {
+			Todos []types.Todo `json:"todos"`
+			Total int          `json:"total"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, buf.String())
+		}
+		for _, todo := range result.Todos {
+			texts = append(texts, todo.Text)
+		}
+		return texts, result.Total
+	}
+
+	// The limit applies after filtering and priority sorting.
+	texts, total := run("--open-only", "--limit", "2")
+	if !reflect.DeepEqual(texts, []string{"high open", "medium open"}) || total != 3 {
+		t.Fatalf("--open-only --limit 2 = %v (total %d)", texts, total)
+	}
+	if texts, _ := run("--limit", "1"); !reflect.DeepEqual(texts, []string{"high done"}) {
+		t.Fatalf("--limit 1 = %v", texts)
+	}
+	if texts, _ := run("--open-only", "--limit", "10"); len(texts) != 3 {
+		t.Fatalf("a limit above the count should keep every match, got %v", texts)
+	}
+
+	rootCmd.SetArgs([]string{"list", "--json", "--open-only", "--status", "done"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected --open-only with --status done to fail")
+	}
+}
+
+func TestProjectFlag(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, t.TempDir())
+	t.Cleanup(func() {
+		projectDir, listJSON = "", false
+		rootCmd.SetOut(nil)
+	})
+
+	todo := types.NewTodo("id1", "from elsewhere")
+	todo.CreatedBy = "test-user"
+	if err := storage.SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	sub := filepath.Join(dir, "src", "pkg")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	for _, project := range []string{dir, sub} {
+		listJSON = false
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs([]string{"--project", project, "list", "--json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("--project %s list: %v", project, err)
+		}
+		var result struct {
+			Todos []types.Todo `json:"todos"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, buf.String())
+		}
+		if len(result.Todos) != 1 || result.Todos[0].Text != "from elsewhere" {
+			t.Fatalf("--project %s listed %+v", project, result.Todos)
+		}
+	}
+
+	rootCmd.SetArgs([]string{"--project", t.TempDir(), "list", "--json"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "no todo project") {
+		t.Fatalf("expected a no-project error, got %v", err)
+	}
+	rootCmd.SetArgs([]string{"--project", filepath.Join(dir, "missing"), "list", "--json"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an error for a missing --project directory")
+	}
+}
+
+func TestProjectFlagGitContext(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := setupTestProject(t)
+	other := t.TempDir()
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	addNoGit = false
+	t.Cleanup(func() { projectDir = "" })
+	git := func(repo string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	for repo, branch := range map[string]string{dir: "project-branch", other: "cwd-branch"} {
+		git(repo, "init", "-q", "-b", branch)
+		git(repo, "commit", "-q", "--allow-empty", "-m", "init")
+	}
+	chdir(t, other)
+
+	rootCmd.SetArgs([]string{"--project", dir, "add", "from elsewhere"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("--project add: %v", err)
+	}
+	todos, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Context.Branch != "project-branch" {
+		t.Fatalf("expected the project's branch to be recorded, got %+v", todos)
+	}
+}
+
+func TestListCount(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	listStatus, listPath, listPriority, listTags = "", "", "", []string{}
+	t.Cleanup(func() {
+		listCount, listOpenOnly, listJSON, listStatus, listPriority = false, false, false, "", ""
+		rootCmd.SetOut(nil)
+	})
+
+	todos := []types.Todo{
+		*types.NewTodo("id1", "high open"),
+		*types.NewTodo("id2", "high done"),
+		*types.NewTodo("id3", "low open"),
+	}
+	todos[0].Priority = types.PriorityHigh
+	todos[1].Priority = types.PriorityHigh
+	todos[1].MarkDone()
+	todos[2].Priority = types.PriorityLow
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	count := func(args ...string) string {
+		t.Helper()
+		listCount, listOpenOnly, listJSON, listStatus, listPriority = false, false, false, "", ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"list", "--count"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("list --count %v: %v", args, err)
+		}
+		return buf.String()
+	}
+
+	if got := count(); got != "3\n" {
+		t.Fatalf("--count = %q; want 3", got)
+	}
+	if got := count("--open-only"); got != "2\n" {
+		t.Fatalf("--open-only --count = %q; want 2", got)
+	}
+	if got := count("--priority", "high", "--status", "open"); got != "1\n" {
+		t.Fatalf("--priority high --status open --count = %q; want 1", got)
+	}
+	if got := count("--status", "blocked"); got != "0\n" {
+		t.Fatalf("no matches should print 0, got %q", got)
+	}
+
+	rootCmd.SetArgs([]string{"list", "--count", "--json"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected --count with --json to fail")
+	}
+}
+
+func TestListAndFocusMine(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	listStatus, listPath, listPriority, listTags = "", "", "", []string{}
+	resetFocusFlags := func() {
+		focusMine, focusJSON, focusAll, focusBranch = false, false, false, false
+		focusCmd.Flags().Lookup("all").Changed = false
+		focusCmd.Flags().Lookup("branch").Changed = false
+	}
+	resetFocusFlags()
+	t.Cleanup(func() {
+		listCount, listMine = false, false
+		resetFocusFlags()
+		rootCmd.SetOut(nil)
+	})
+
+	mine := types.NewTodo("m1", "mine")
+	mine.Author, mine.CreatedBy = "Test User", "test-user"
+	theirs := types.NewTodo("m2", "theirs")
+	theirs.Author, theirs.CreatedBy = "Someone Else", "someone-else"
+	legacy := types.NewTodo("m3", "mine, before authors were recorded")
+	legacy.CreatedBy = "test-user"
+	if err := storage.SaveTodos(dir, []types.Todo{*mine, *theirs, *legacy}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"list", "--mine", "--count"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("list --mine --count: %v", err)
+	}
+	if buf.String() != "2\n" {
+		t.Fatalf("list --mine --count = %q; want 2", buf.String())
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"focus", "--all", "--mine", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("focus --mine: %v", err)
+	}
+	var focus struct {
+		Todos []types.Todo `json:"todos"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &focus); err != nil {
+		t.Fatalf("parse focus output: %v\n%s", err, buf.String())
+	}
+	for _, todo := range focus.Todos {
+		if todo.ID == "m2" {
+			t.Fatalf("focus --mine included someone else's todo: %+v", focus.Todos)
+		}
+	}
+	if len(focus.Todos) != 2 {
+		t.Fatalf("expected 2 focused todos, got %+v", focus.Todos)
+	}
+}
+
+func TestListMineFallsBackWithoutAuthors(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	listStatus, listPath, listPriority, listTags = "", "", "", []string{}
+	t.Cleanup(func() {
+		listCount, listMine = false, false
+		rootCmd.SetOut(nil)
+	})
+
+	// Neither todo has an author, and both live in legacy.json.
+	if err := storage.SaveTodos(dir, []types.Todo{*types.NewTodo("l1", "one"), *types.NewTodo("l2", "two")}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"list", "--mine", "--count"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("list --mine --count: %v", err)
+	}
+	if buf.String() != "2\n" {
+		t.Fatalf("list --mine --count = %q; want every todo", buf.String())
+	}
+}
+
+func TestListSinceUntil(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	listPath, listPriority, listTags = "", "", []string{}
+	t.Cleanup(func() {
+		listSince, listUntil, listBy, listJSON, listStatus = "", "", "created", false, ""
+		rootCmd.SetOut(nil)
+	})
+
+	now := time.Now()
+	todos := []types.Todo{
+		*types.NewTodo("id1", "last month"),
+		*types.NewTodo("id2", "this week"),
+		*types.NewTodo("id3", "this week, done"),
+	}
+	todos[0].CreatedAt = now.AddDate(0, -1, 0)
+	todos[1].CreatedAt = now.AddDate(0, 0, -2)
+	todos[2].CreatedAt = now.AddDate(0, 0, -3)
+	todos[2].MarkDone()
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	run := func(args ...string) []string {
+		t.Helper()
+		listSince, listUntil, listBy, listStatus = "", "", "created", ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"list", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("list %v: %v", args, err)
+		}
+		var result struct {
+			Todos []types.Todo `json:"todos"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, buf.String())
+		}
+		var ids []string
+		for _, todo := range result.Todos {
+			ids = append(ids, todo.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	if ids := run("--since", "7d"); !reflect.DeepEqual(ids, []string{"id2", "id3"}) {
+		t.Fatalf("--since 7d = %v", ids)
+	}
+	if ids := run("--since", "7d", "--status", "open"); !reflect.DeepEqual(ids, []string{"id2"}) {
+		t.Fatalf("--since 7d --status open = %v", ids)
+	}
+	if ids := run("--until", "7d"); !reflect.DeepEqual(ids, []string{"id1"}) {
+		t.Fatalf("--until 7d = %v", ids)
+	}
+	// Every todo was just saved, so all were updated within the last day.
+	if ids := run("--since", "1d", "--by", "updated"); len(ids) != 3 {
+		t.Fatalf("--since 1d --by updated = %v", ids)
+	}
+
+	listSince, listBy = "", "created"
+	rootCmd.SetArgs([]string{"list", "--json", "--since", "1d", "--by", "due"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected --by due to fail")
+	}
+}
+
+func TestTouchResetsStaleness(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+
+	old := time.Now().AddDate(0, -2, 0)
+	todos := []types.Todo{
+		*types.NewTodo("aaa111", "old but relevant"),
+		*types.NewTodo("bbb222", "also old"),
+		*types.NewTodo("ccc333", "left alone"),
+	}
+	for i := range todos {
+		todos[i].CreatedAt, todos[i].UpdatedAt = old, old
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if stale := checkStaleTodos(todos); len(stale) != 3 {
+		t.Fatalf("expected 3 stale todos before touching, got %d", len(stale))
+	}
+
+	rootCmd.SetArgs([]string{"touch", "1", "bbb222", "missing"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for _, todo := range loaded[:2] {
+		if todo.LastReviewed == nil || !todo.UpdatedAt.After(old) || todo.Text == "" {
+			t.Fatalf("expected %s to be touched, got %+v", todo.ID, todo)
+		}
+	}
+	if loaded[2].LastReviewed != nil {
+		t.Fatalf("expected ccc333 untouched, got %+v", loaded[2])
+	}
+	if stale := checkStaleTodos(loaded); len(stale) != 1 || stale[0].ID != "ccc333" {
+		t.Fatalf("expected only ccc333 to stay stale, got %+v", stale)
+	}
+}
+
+func TestListDatesSuffix(t *testing.T) {
+	t.Cleanup(func() {
+		listShowDates, listDateFormat, verbose = false, "relative", false
+	})
+
+	todo := types.NewTodo("d1", "dated")
+	todo.CreatedAt = time.Now().Add(-3 * 24 * time.Hour)
+	todo.UpdatedAt = time.Now().Add(-2 * time.Hour)
+
+	if got := listDatesSuffix(*todo); got != "" {
+		t.Fatalf("expected no dates without --show-dates, got %q", got)
+	}
+
+	listShowDates = true
+	got := listDatesSuffix(*todo)
+	if !strings.Contains(got, "created 3 days ago") || strings.Contains(got, "updated") {
+		t.Fatalf("unexpected relative suffix %q", got)
+	}
+
+	verbose = true
+	listDateFormat = "absolute"
+	got = listDatesSuffix(*todo)
+	want := "created " + todo.CreatedAt.Local().Format("2006-01-02") + ", updated " + todo.UpdatedAt.Local().Format("2006-01-02")
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected %q in %q", want, got)
+	}
+}
+
+func TestCommitFlag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "Test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "Test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	t.Cleanup(func() {
+		doneCommit, deleteCommit = false, false
+		doneCmd.Flags().Lookup("commit").Changed = false
+	})
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	todos := []types.Todo{*types.NewTodo("c1", "Ship the release"), *types.NewTodo("c2", "Write notes")}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"done", "c1", "--commit"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("done --commit failed: %v", err)
+	}
+	if got := git("log", "-1", "--format=%s"); got != "todo: done Ship the release" {
+		t.Fatalf("unexpected commit subject %q", got)
+	}
+
+	// Without --commit or autoCommit nothing is committed.
+	rootCmd.SetArgs([]string{"delete", "c2"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if got := git("rev-list", "--count", "HEAD"); got != "2" {
+		t.Fatalf("expected 2 commits, got %s", got)
+	}
+
+	config, err := storage.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	config.AutoCommit = true
+	config.CommitMessage = "todos: {{.Action}} ({{.Count}})"
+	if err := storage.SaveConfig(dir, config); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	if err := storage.SaveTodos(dir, []types.Todo{*types.NewTodo("c3", "Tag it")}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	rootCmd.SetArgs([]string{"delete", "c3"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if got := git("log", "-1", "--format=%s"); got != "todos: delete (1)" {
+		t.Fatalf("expected autoCommit to commit, got subject %q", got)
+	}
+}
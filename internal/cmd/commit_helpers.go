@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// commitTodoChange commits the per-user todo files after action (add, done,
+// or delete) changed the todos with the given texts, when the command's
+// --commit flag or the autoCommit setting asks for it; an explicit
+// --commit=false beats autoCommit. It returns the commit message, or "" when
+// nothing was committed (not a git repository, or no changes). Failures only
+// warn on stderr, since the todos are already saved.
+func commitTodoChange(cmd *cobra.Command, projectRoot, action string, texts []string, commitFlag bool) string {
+	config, err := storage.LoadConfig(projectRoot)
+	if err != nil {
+		warnNotCommitted(err)
+		return ""
+	}
+	enabled := config.AutoCommit
+	if cmd.Flags().Changed("commit") {
+		enabled = commitFlag
+	}
+	if !enabled || len(texts) == 0 {
+		return ""
+	}
+
+	message, err := types.RenderCommitMessage(config.CommitMessage, types.NewCommitMessageData(action, texts))
+	if err != nil {
+		warnNotCommitted(fmt.Errorf("commitMessage: %w", err))
+		return ""
+	}
+	usersDir, err := filepath.Rel(projectRoot, storage.GetUsersDir(projectRoot))
+	if err != nil {
+		warnNotCommitted(err)
+		return ""
+	}
+	committed, err := git.CommitFile(projectRoot, usersDir, message)
+	if err != nil {
+		warnNotCommitted(err)
+		return ""
+	}
+	if !committed {
+		Verbosef("nothing to commit under %s", usersDir)
+		return ""
+	}
+	return message
+}
+
+func warnNotCommitted(err error) {
+	fmt.Fprintf(os.Stderr, "%s⚠ Todos saved but not committed: %v%s\n", terminal.BrightYellow, err, terminal.Reset)
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os/exec"
 	"strconv"
+	"strings"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
@@ -14,6 +17,16 @@ var (
 	configAutoGit       string
 	configDefaultBranch string
 	configReset         bool
+	configValidate      bool
+	configFix           bool
+	configEditor        string
+	configList          bool
+	configTheme         string
+	configLocale        string
+	configBranchScope   string
+	configSet           []string
+	configGet           string
+	configUnset         []string
 )
 
 var configCmd = &cobra.Command{
@@ -22,8 +35,38 @@ var configCmd = &cobra.Command{
 	Long: `View or update the todo project's configuration.
 
 When no flags are provided, the current configuration is shown.
-Use --auto-git and --default-branch to update values, or --reset to
-restore defaults.`,
+Use --auto-git, --default-branch, --editor, --theme, --locale, and
+--auto-branch-scope to update values, or --reset to restore defaults.
+--list prints the whole config as JSON.
+
+--locale sets the language tag the web UI (todo ui) formats dates with,
+such as de-DE or en-GB; the default is en-US. The page picks it up on its
+next load.
+
+--auto-branch-scope false makes todo focus show every open todo by default
+(focusScope "all"); true restores scoping to the current branch. focus --all
+and --branch still override it for one run.
+
+--set key=value, --get key, and --unset key address any setting by its
+config.json name (autoGit, defaultBranch, editor, theme, locale, focusScope,
+lastSelected, customStatuses); snake_case spellings like default_branch work
+too.
+
+--validate checks config.json for unknown keys and invalid values and
+exits non-zero when it finds any. --fix drops unknown keys and resets
+invalid values to their defaults.`,
+	Example: `  todo config
+  todo config --auto-git false
+  todo config --editor nvim
+  todo config --theme light  # default, light, or mono
+  todo config --locale de-DE  # Dates in the web UI
+  todo config --auto-branch-scope false  # focus shows all branches by default
+  todo config --list       # Full config as JSON
+  todo config --set default_branch=main --set autoGit=false
+  todo config --get editor
+  todo config --unset editor
+  todo config --validate   # Exit 1 if config.json has problems (CI)
+  todo config --fix        # Repair invalid fields`,
 	RunE: runConfig,
 }
 
@@ -33,16 +76,33 @@ func init() {
 	configCmd.Flags().StringVar(&configAutoGit, "auto-git", "", "Enable/disable automatic git context capture (true/false)")
 	configCmd.Flags().StringVar(&configDefaultBranch, "default-branch", "", "Set the default branch used when git context is unavailable")
 	configCmd.Flags().BoolVar(&configReset, "reset", false, "Reset configuration to defaults")
+	configCmd.Flags().BoolVar(&configValidate, "validate", false, "Check config.json for invalid values and unknown keys")
+	configCmd.Flags().StringVar(&configEditor, "editor", "", "Editor command for 'todo open' (overrides $VISUAL/$EDITOR; empty to unset)")
+	configCmd.Flags().StringVar(&configTheme, "theme", "", "Color theme: default, light (for light backgrounds), mono (no colors); --no-color still wins")
+	configCmd.Flags().StringVar(&configLocale, "locale", "", "Language tag for dates in the web UI, e.g. en-GB (default en-US)")
+	configCmd.Flags().StringVar(&configBranchScope, "auto-branch-scope", "", "Scope todo focus to the current branch by default (true/false)")
+	configCmd.Flags().BoolVar(&configList, "list", false, "Print the full configuration as JSON")
+	configCmd.Flags().StringArrayVar(&configSet, "set", []string{}, "Set a config key: key=value (can be used multiple times)")
+	configCmd.Flags().StringVar(&configGet, "get", "", "Print the value of a config key")
+	configCmd.Flags().StringArrayVar(&configUnset, "unset", []string{}, "Restore a config key to its default (can be used multiple times)")
+	configCmd.Flags().BoolVar(&configFix, "fix", false, "Reset invalid config values to defaults and drop unknown keys")
+
+	_ = configCmd.RegisterFlagCompletionFunc("theme", cobra.FixedCompletions(types.Themes, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runConfig(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
+	if configValidate || configFix {
+		return runConfigValidate(projectRoot)
+	}
+
+	// --reset must work even when the current config no longer loads.
 	cfg, err := storage.LoadConfig(projectRoot)
-	if err != nil {
+	if err != nil && !configReset {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
@@ -66,12 +126,90 @@ func runConfig(cmd *cobra.Command, args []string) error {
 		modified = true
 	}
 
+	if cmd.Flags().Changed("editor") {
+		cfg.Editor = strings.TrimSpace(configEditor)
+		modified = true
+		warnMissingEditor(cfg.Editor)
+	}
+
+	if cmd.Flags().Changed("theme") {
+		field, _ := storage.LookupConfigField("theme")
+		if err := field.Set(cfg, configTheme); err != nil {
+			return fmt.Errorf("invalid value for --theme: %s (%w)", configTheme, err)
+		}
+		modified = true
+	}
+
+	if cmd.Flags().Changed("locale") {
+		field, _ := storage.LookupConfigField("locale")
+		if err := field.Set(cfg, configLocale); err != nil {
+			return fmt.Errorf("invalid value for --locale: %s (%w)", configLocale, err)
+		}
+		modified = true
+	}
+
+	if cmd.Flags().Changed("auto-branch-scope") {
+		value, err := strconv.ParseBool(configBranchScope)
+		if err != nil {
+			return fmt.Errorf("invalid value for --auto-branch-scope: %s (use true/false)", configBranchScope)
+		}
+		cfg.FocusScope = ""
+		if !value {
+			cfg.FocusScope = "all"
+		}
+		modified = true
+	}
+
+	for _, key := range configUnset {
+		field, err := storage.LookupConfigField(key)
+		if err != nil {
+			return err
+		}
+		field.Unset(cfg)
+		modified = true
+	}
+	for _, assignment := range configSet {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return fmt.Errorf("invalid --set value: %q (use key=value)", assignment)
+		}
+		field, err := storage.LookupConfigField(key)
+		if err != nil {
+			return err
+		}
+		if err := field.Set(cfg, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", field.Key, err)
+		}
+		if field.Key == "editor" {
+			warnMissingEditor(cfg.Editor)
+		}
+		modified = true
+	}
+
 	if modified {
 		if err := storage.SaveConfig(projectRoot, cfg); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
-		terminal.PrintSuccess("Configuration updated")
-		fmt.Println()
+		if !configList && !cmd.Flags().Changed("get") {
+			terminal.PrintSuccess("Configuration updated")
+			fmt.Println()
+		}
+	}
+
+	// --get prints the bare value so scripts can use it directly.
+	if cmd.Flags().Changed("get") {
+		field, err := storage.LookupConfigField(configGet)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), field.Get(cfg))
+		return nil
+	}
+
+	if configList {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(cfg)
 	}
 
 	fmt.Printf("  %sConfig:%s\n", terminal.Dim, terminal.Reset)
@@ -80,7 +218,79 @@ func runConfig(cmd *cobra.Command, args []string) error {
 	if defaultBranch == "" {
 		defaultBranch = "(not set)"
 	}
-	fmt.Printf("    %sdefaultBranch:%s %s\n\n", terminal.BrightCyan, terminal.Reset, defaultBranch)
+	fmt.Printf("    %sdefaultBranch:%s %s\n", terminal.BrightCyan, terminal.Reset, defaultBranch)
+	editor := cfg.Editor
+	if editor == "" {
+		editor = "(not set, using $VISUAL/$EDITOR)"
+	}
+	fmt.Printf("    %seditor:%s        %s\n", terminal.BrightCyan, terminal.Reset, editor)
+	theme := cfg.Theme
+	if theme == "" {
+		theme = "default"
+	}
+	fmt.Printf("    %stheme:%s         %s\n", terminal.BrightCyan, terminal.Reset, theme)
+	locale := cfg.Locale
+	if locale == "" {
+		locale = types.DefaultLocale
+	}
+	fmt.Printf("    %slocale:%s        %s\n", terminal.BrightCyan, terminal.Reset, locale)
+	focusScope := cfg.FocusScope
+	if focusScope == "" {
+		focusScope = "branch"
+	}
+	fmt.Printf("    %sfocusScope:%s    %s\n\n", terminal.BrightCyan, terminal.Reset, focusScope)
 
 	return nil
 }
+
+// warnMissingEditor warns when the editor command isn't on PATH.
+func warnMissingEditor(editor string) {
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			terminal.PrintWarning(fmt.Sprintf("Editor %q not found on PATH", fields[0]))
+		}
+	}
+}
+
+func runConfigValidate(projectRoot string) error {
+	if configFix {
+		fixed, err := storage.FixConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to fix config: %w", err)
+		}
+		if len(fixed) == 0 {
+			terminal.PrintSuccess("Config is valid")
+			fmt.Println()
+			return nil
+		}
+		terminal.PrintSuccess(fmt.Sprintf("Fixed %d config problem(s)", len(fixed)))
+		printConfigProblems(fixed)
+		return nil
+	}
+
+	problems, err := storage.ValidateConfig(projectRoot)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		terminal.PrintSuccess("Config is valid")
+		fmt.Println()
+		return nil
+	}
+
+	terminal.PrintWarning(fmt.Sprintf("Found %d config problem(s)", len(problems)))
+	printConfigProblems(problems)
+	fmt.Printf("  %sRun 'todo config --fix' to reset invalid values%s\n\n", terminal.Dim, terminal.Reset)
+	return fmt.Errorf("invalid config: %s", storage.GetConfigPath(projectRoot))
+}
+
+func printConfigProblems(problems []storage.ConfigProblem) {
+	for _, p := range problems {
+		key := p.Key
+		if key == "" {
+			key = "(file)"
+		}
+		fmt.Printf("    %s%s:%s %s\n", terminal.BrightCyan, key, terminal.Reset, p.Message)
+	}
+	fmt.Println()
+}
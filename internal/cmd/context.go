@@ -31,7 +31,7 @@ func init() {
 }
 
 func runContext(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -42,8 +42,8 @@ func runContext(cmd *cobra.Command, args []string) error {
 	}
 
 	branch := ""
-	if git.IsGitRepo() {
-		b, _, err := git.GetGitContext()
+	if git.IsGitRepoAt(projectRoot) {
+		b, _, err := git.GetGitContext(projectRoot)
 		if err == nil {
 			branch = b
 		}
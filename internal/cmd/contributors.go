@@ -6,7 +6,6 @@ import (
 
 	"github.com/bagadi-alnour/todo-cli/internal/contributors"
 	"github.com/bagadi-alnour/todo-cli/internal/git"
-	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
 	"github.com/spf13/cobra"
 )
@@ -35,14 +34,14 @@ func init() {
 }
 
 func runContributors(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
 	var f *contributors.File
 	if contributorsRefresh {
-		if !git.IsGitRepo() {
+		if !git.IsGitRepoAt(projectRoot) {
 			return fmt.Errorf("not a git repository")
 		}
 		f, err = contributors.RefreshFromGit(projectRoot)
@@ -63,7 +62,7 @@ func runContributors(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(f.Contributors) == 0 {
-		if !git.IsGitRepo() {
+		if !git.IsGitRepoAt(projectRoot) {
 			terminal.PrintInfo("No git repository — contributors are sourced from git history")
 		} else {
 			terminal.PrintInfo("No contributors found. Try: todo contributors --refresh")
@@ -9,21 +9,25 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var deleteCommit bool
+
 var deleteCmd = &cobra.Command{
-	Use:     "delete <id|index> [id|index...]",
-	Aliases: []string{"del", "rm"},
-	Short:   "Delete one or more todos",
-	Long:    "Remove todos by list index or ID. Multiple arguments are supported.",
-	Args:    cobra.MinimumNArgs(1),
-	RunE:    runDelete,
+	Use:               "delete <id|index> [id|index...]",
+	Aliases:           []string{"del", "rm"},
+	Short:             "Delete one or more todos",
+	Long:              "Remove todos by list index or ID. Multiple arguments are supported.",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeTodoArgs,
+	RunE:              runDelete,
 }
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.Flags().BoolVar(&deleteCommit, "commit", false, "Commit the todo files afterwards (default from autoCommit in config.json)")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -36,9 +40,9 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 		var toDelete []int
 		for _, idOrIndex := range args {
-			target, idx := storage.FindTodoByIDOrIndex(todos, idOrIndex)
-			if target == nil {
-				terminal.PrintWarning(fmt.Sprintf("Not found: %s", idOrIndex))
+			target, idx, err := storage.ResolveTodo(todos, idOrIndex)
+			if err != nil {
+				warnUnresolved(todos, idOrIndex, err)
 				continue
 			}
 			toDelete = append(toDelete, idx)
@@ -46,16 +50,18 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 
 		if len(toDelete) == 0 {
-			fmt.Println()
+			terminal.PrintBlank()
 			return nil
 		}
 
 		seen := make(map[int]bool, len(toDelete))
 		var unique []int
+		var deletedTexts []string
 		for _, idx := range toDelete {
 			if !seen[idx] {
 				seen[idx] = true
 				unique = append(unique, idx)
+				deletedTexts = append(deletedTexts, todos[idx].Text)
 			}
 		}
 		sort.Sort(sort.Reverse(sort.IntSlice(unique)))
@@ -66,8 +72,11 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		if err := storage.SaveTodos(projectRoot, todos); err != nil {
 			return fmt.Errorf("failed to save todos: %w", err)
 		}
+		if message := commitTodoChange(cmd, projectRoot, "delete", deletedTexts, deleteCommit); message != "" {
+			terminal.PrintDim("Committed: " + message)
+		}
 
-		fmt.Println()
+		terminal.PrintBlank()
 		return nil
 	})
 }
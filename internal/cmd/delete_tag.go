@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var deleteTagDryRun bool
+
+var deleteTagCmd = &cobra.Command{
+	Use:   "delete-tag <name>",
+	Short: "Remove a tag from all todos",
+	Long:  `Remove a tag from every todo that has it. The todos themselves are kept.`,
+	Example: `  todo delete-tag wip
+  todo delete-tag old-sprint --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeleteTag,
+}
+
+func init() {
+	rootCmd.AddCommand(deleteTagCmd)
+	deleteTagCmd.Flags().BoolVar(&deleteTagDryRun, "dry-run", false, "Show how many todos would change without saving")
+}
+
+func runDeleteTag(cmd *cobra.Command, args []string) error {
+	tag, err := singleTag(args[0])
+	if err != nil {
+		return err
+	}
+
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	return storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		changed := retag(todos, tag, "")
+		if changed == 0 {
+			terminal.PrintInfo(fmt.Sprintf("No todos tagged #%s", tag))
+			fmt.Println()
+			return nil
+		}
+
+		if deleteTagDryRun {
+			terminal.PrintInfo(fmt.Sprintf("Would remove #%s from %d todo(s) (dry run)", tag, changed))
+			fmt.Println()
+			return nil
+		}
+
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		terminal.PrintSuccess(fmt.Sprintf("Removed #%s from %d todo(s)", tag, changed))
+		fmt.Println()
+		return nil
+	})
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var diffJSON bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show uncommitted todo changes against git HEAD",
+	Long: `Compare the working todo files with the versions committed in git HEAD and
+report which todos were added, removed, or modified, by ID. Run it before
+committing to see the todo churn you're about to push.
+
+If the todo files aren't committed yet, every todo is reported as added.`,
+	Example: `  todo diff
+  todo diff --json`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Output as JSON")
+}
+
+// todoChange is a todo present on both sides whose fields differ.
+type todoChange struct {
+	ID     string   `json:"id"`
+	Text   string   `json:"text"`
+	Fields []string `json:"fields"`
+	Before string   `json:"statusBefore,omitempty"`
+	After  string   `json:"statusAfter,omitempty"`
+}
+
+// todoDiff is the difference between two sets of todos, matched by ID.
+type todoDiff struct {
+	Committed bool         `json:"committed"`
+	Added     []types.Todo `json:"added"`
+	Removed   []types.Todo `json:"removed"`
+	Modified  []todoChange `json:"modified"`
+}
+
+func (d todoDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// diffTodos compares old and new by ID. Added and modified todos keep the
+// order of new, removed ones the order of old. A change to updatedAt alone
+// doesn't count as a modification.
+func diffTodos(old, new []types.Todo) todoDiff {
+	d := todoDiff{Added: []types.Todo{}, Removed: []types.Todo{}, Modified: []todoChange{}}
+	before := make(map[string]types.Todo, len(old))
+	for _, t := range old {
+		before[t.ID] = t
+	}
+	after := make(map[string]bool, len(new))
+	for _, t := range new {
+		after[t.ID] = true
+		prev, ok := before[t.ID]
+		if !ok {
+			d.Added = append(d.Added, t)
+			continue
+		}
+		fields := changedTodoFields(prev, t)
+		if len(fields) == 0 {
+			continue
+		}
+		change := todoChange{ID: t.ID, Text: t.Text, Fields: fields}
+		if prev.Status != t.Status {
+			change.Before, change.After = string(prev.Status), string(t.Status)
+		}
+		d.Modified = append(d.Modified, change)
+	}
+	for _, t := range old {
+		if !after[t.ID] {
+			d.Removed = append(d.Removed, t)
+		}
+	}
+	return d
+}
+
+// changedTodoFields lists the JSON field names that differ between a and b,
+// sorted.
+func changedTodoFields(a, b types.Todo) []string {
+	fa, fb := todoFieldMap(a), todoFieldMap(b)
+	var fields []string
+	for key, va := range fa {
+		if vb, ok := fb[key]; !ok || !bytes.Equal(va, vb) {
+			fields = append(fields, key)
+		}
+	}
+	for key := range fb {
+		if _, ok := fa[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+	out := fields[:0]
+	for _, f := range fields {
+		if f != "updatedAt" {
+			out = append(out, f)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func todoFieldMap(t types.Todo) map[string]json.RawMessage {
+	data, _ := json.Marshal(t)
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	committed, found, err := storage.LoadCommittedTodos(projectRoot)
+	if err != nil {
+		return err
+	}
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	d := diffTodos(committed, todos)
+	d.Committed = found
+
+	if diffJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+
+	terminal.PrintHeader("TODO DIFF", "±")
+
+	if !found {
+		terminal.PrintWarning("No todo files in HEAD yet; every todo counts as added")
+		fmt.Println()
+	}
+	if d.empty() {
+		terminal.PrintInfo("No todo changes since HEAD")
+		fmt.Println()
+		return nil
+	}
+
+	for _, t := range d.Added {
+		fmt.Printf("  %s+ %s%s %s\n", terminal.Green, shortTodoID(t.ID), terminal.Reset, t.Text)
+	}
+	for _, t := range d.Removed {
+		fmt.Printf("  %s- %s%s %s\n", terminal.Red, shortTodoID(t.ID), terminal.Reset, t.Text)
+	}
+	for _, c := range d.Modified {
+		fields := make([]string, len(c.Fields))
+		for i, f := range c.Fields {
+			fields[i] = f
+			if f == "status" {
+				fields[i] = fmt.Sprintf("status %s → %s", c.Before, c.After)
+			}
+		}
+		detail := strings.Join(fields, ", ")
+		fmt.Printf("  %s~ %s%s %s %s(%s)%s\n", terminal.Yellow, shortTodoID(c.ID), terminal.Reset, c.Text,
+			terminal.Dim, detail, terminal.Reset)
+	}
+	fmt.Println()
+	fmt.Printf("  %d added, %d removed, %d modified\n", len(d.Added), len(d.Removed), len(d.Modified))
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestDiffTodos(t *testing.T) {
+	now := time.Now()
+	kept := types.Todo{ID: "keep", Text: "kept", Status: types.StatusOpen, UpdatedAt: now}
+	touched := kept
+	touched.UpdatedAt = now.Add(time.Hour)
+	changed := types.Todo{ID: "chg", Text: "changed", Status: types.StatusOpen, Priority: types.PriorityLow}
+	changedAfter := changed
+	changedAfter.Status = types.StatusDone
+	changedAfter.Priority = types.PriorityHigh
+	gone := types.Todo{ID: "gone", Text: "gone"}
+	added := types.Todo{ID: "new", Text: "new"}
+
+	d := diffTodos([]types.Todo{kept, changed, gone}, []types.Todo{touched, added, changedAfter})
+
+	if len(d.Added) != 1 || d.Added[0].ID != "new" {
+		t.Fatalf("added = %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].ID != "gone" {
+		t.Fatalf("removed = %+v", d.Removed)
+	}
+	if len(d.Modified) != 1 {
+		t.Fatalf("modified = %+v, want only chg (updatedAt alone doesn't count)", d.Modified)
+	}
+	m := d.Modified[0]
+	if m.ID != "chg" || !reflect.DeepEqual(m.Fields, []string{"priority", "status"}) ||
+		m.Before != "open" || m.After != "done" {
+		t.Fatalf("modified[0] = %+v", m)
+	}
+	if !diffTodos([]types.Todo{kept}, []types.Todo{kept}).empty() {
+		t.Fatal("identical sets should produce an empty diff")
+	}
+}
+
+func TestDiffCommand(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		diffJSON = false
+		rootCmd.SetOut(nil)
+	})
+	git := func(args ...string) {
+		t.Helper()
+		c := exec.Command("git", args...)
+		c.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	runDiffJSON := func() todoDiff {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs([]string{"diff", "--json"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		var d todoDiff
+		if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+			t.Fatalf("parse diff output: %v\n%s", err, buf.String())
+		}
+		return d
+	}
+
+	seed := []types.Todo{
+		{ID: "aaa111", Text: "stays", Status: types.StatusOpen, CreatedBy: "test-user"},
+		{ID: "bbb222", Text: "finished", Status: types.StatusOpen, CreatedBy: "test-user"},
+		{ID: "ccc333", Text: "dropped", Status: types.StatusOpen, CreatedBy: "test-user"},
+	}
+	if err := storage.SaveTodos(dir, seed); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	git("init", "-q")
+	if d := runDiffJSON(); d.Committed || len(d.Added) != 3 {
+		t.Fatalf("before the first commit: %+v", d)
+	}
+
+	git("add", ".")
+	git("commit", "-q", "-m", "todos")
+	if d := runDiffJSON(); !d.Committed || !d.empty() {
+		t.Fatalf("right after commit: %+v", d)
+	}
+
+	todos, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	todos[1].Status = types.StatusDone
+	todos = append(todos[:2], types.Todo{ID: "ddd444", Text: "fresh", Status: types.StatusOpen, CreatedBy: "test-user"})
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	d := runDiffJSON()
+	if len(d.Added) != 1 || d.Added[0].ID != "ddd444" {
+		t.Fatalf("added = %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].ID != "ccc333" {
+		t.Fatalf("removed = %+v", d.Removed)
+	}
+	if len(d.Modified) != 1 || d.Modified[0].ID != "bbb222" || d.Modified[0].After != "done" {
+		t.Fatalf("modified = %+v", d.Modified)
+	}
+}
@@ -16,8 +16,10 @@ import (
 )
 
 var (
-	doctorFix  bool
-	doctorJSON bool
+	doctorFix    bool
+	doctorDryRun bool
+	doctorStrict bool
+	doctorJSON   bool
 )
 
 var doctorCmd = &cobra.Command{
@@ -29,10 +31,12 @@ Checks for:
   - Orphaned paths (todos pointing to non-existent files)
   - Empty todos
   - Duplicate todos
-  - Stale todos (open for more than 30 days)
-  - Overdue todos (past due date)`,
+  - Stale todos (open with no activity in 30 days)
+  - Overdue todos (past due date)
+  - Unreachable commits (recorded commit no longer in the repository)`,
 	Example: `  todo doctor        # Run all checks
-  todo doctor --fix  # Auto-fix issues (remove orphans)`,
+  todo doctor --fix  # Auto-fix issues (remove orphans)
+  todo doctor --fix --dry-run  # Preview fixes without saving`,
 	RunE: runDoctor,
 }
 
@@ -40,15 +44,26 @@ func init() {
 	rootCmd.AddCommand(doctorCmd)
 
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Auto-fix issues where possible")
+	doctorCmd.Flags().BoolVar(&doctorDryRun, "dry-run", false, "With --fix, list what would change without saving")
+	doctorCmd.Flags().BoolVar(&doctorStrict, "strict", false, "Only treat todos with identical text as duplicates (default ignores case and spacing)")
 	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output results as JSON")
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 	Verbosef("project root: %s", projectRoot)
+	if doctorDryRun && !doctorFix {
+		return fmt.Errorf("--dry-run only applies to --fix")
+	}
+
+	// Check before loading: LoadTodos upgrades outdated files in place.
+	outdated, err := storage.FindOutdatedTodoFiles(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to check todo file versions: %w", err)
+	}
 
 	todos, err := storage.LoadTodos(projectRoot)
 	if err != nil {
@@ -56,17 +71,27 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	}
 	Verbosef("loaded %d todo(s)", len(todos))
 
+	ignore, err := storage.LoadIgnorePatterns(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", storage.IgnoreFile, err)
+	}
+
+	commitExists := doctorCommitExists(projectRoot)
+
 	if doctorJSON {
-		orphanedTodos, _, _ := checkOrphanedPaths(todos, projectRoot)
+		orphanedTodos, _, _ := checkOrphanedPaths(todos, projectRoot, ignore)
+		unreachable := checkUnreachableCommits(todos, commitExists)
 		report := map[string]any{
-			"total":      len(todos),
-			"stats":      countByStatus(todos),
-			"orphaned":   len(orphanedTodos),
-			"empty":      len(checkEmptyTodos(todos)),
-			"duplicates": len(checkDuplicateTodos(todos)),
-			"stale":      len(checkStaleTodos(todos)),
-			"overdue":    len(checkOverdueTodos(todos)),
-			"healthy":    len(orphanedTodos) == 0 && len(checkEmptyTodos(todos)) == 0 && len(checkDuplicateTodos(todos)) == 0 && len(checkStaleTodos(todos)) == 0 && len(checkOverdueTodos(todos)) == 0,
+			"total":              len(todos),
+			"stats":              countByStatus(todos),
+			"orphaned":           len(orphanedTodos),
+			"empty":              len(checkEmptyTodos(todos)),
+			"duplicates":         len(checkDuplicateTodos(todos, doctorStrict)),
+			"stale":              len(checkStaleTodos(todos)),
+			"overdue":            len(checkOverdueTodos(todos)),
+			"unreachableCommits": len(unreachable),
+			"migrated":           outdated,
+			"healthy":            len(orphanedTodos) == 0 && len(checkEmptyTodos(todos)) == 0 && len(checkDuplicateTodos(todos, doctorStrict)) == 0 && len(checkStaleTodos(todos)) == 0 && len(checkOverdueTodos(todos)) == 0 && len(unreachable) == 0,
 		}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
@@ -81,12 +106,26 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %s📋 Todos:%s   %s%d total%s\n", terminal.Dim, terminal.Reset, terminal.BrightWhite+terminal.Bold, len(todos), terminal.Reset)
 
 	// Git info
-	if git.IsGitRepo() {
-		branch, _ := git.GetCurrentBranch()
+	if git.IsGitRepoAt(projectRoot) {
+		branch, _ := git.GetCurrentBranch(projectRoot)
 		fmt.Printf("  %s🌿 Branch:%s  %s%s%s\n", terminal.Dim, terminal.Reset, terminal.Green, branch, terminal.Reset)
+		if commit, err := git.GetCurrentCommit(projectRoot); err == nil && commit != "" {
+			fmt.Printf("  %s🔗 Commit:%s  %s\n", terminal.Dim, terminal.Reset, formatCommitLink(projectRoot, commit))
+		}
 	}
 	fmt.Println()
 
+	for _, f := range outdated {
+		rel, err := storage.RelativeToRoot(projectRoot, f.Path)
+		if err != nil {
+			rel = f.Path
+		}
+		terminal.PrintInfo(fmt.Sprintf("Upgraded %s from file version %d to %d", rel, f.Version, types.TodoFileVersion))
+	}
+	if len(outdated) > 0 {
+		fmt.Println()
+	}
+
 	if len(todos) == 0 {
 		terminal.PrintSuccess("No todos to check.")
 		fmt.Println()
@@ -100,7 +139,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Check 1: Orphaned paths
 	fmt.Printf("  %s🔍 Checking for orphaned paths...%s\n", terminal.Dim, terminal.Reset)
-	orphanedTodos, orphanedPaths, totalPaths := checkOrphanedPaths(todos, projectRoot)
+	orphanedTodos, orphanedPaths, totalPaths := checkOrphanedPaths(todos, projectRoot, ignore)
 	if len(orphanedTodos) > 0 {
 		fmt.Printf("     %s⚠  %d orphaned path(s) found in %d todo(s)%s\n", terminal.BrightYellow+terminal.Bold, orphanedPaths, len(orphanedTodos), terminal.Reset)
 		issues += len(orphanedTodos)
@@ -122,7 +161,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Check 3: Duplicate todos
 	fmt.Printf("  %s🔍 Checking for duplicate todos...%s\n", terminal.Dim, terminal.Reset)
-	duplicates := checkDuplicateTodos(todos)
+	duplicates := checkDuplicateTodos(todos, doctorStrict)
 	if len(duplicates) > 0 {
 		fmt.Printf("     %s⚠  %d potential duplicate(s) found%s\n", terminal.BrightYellow+terminal.Bold, len(duplicates), terminal.Reset)
 		issues += len(duplicates)
@@ -134,7 +173,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %s🔍 Checking for stale todos...%s\n", terminal.Dim, terminal.Reset)
 	staleTodos := checkStaleTodos(todos)
 	if len(staleTodos) > 0 {
-		fmt.Printf("     %s⚠  %d stale todo(s) (open > 30 days)%s\n", terminal.BrightYellow+terminal.Bold, len(staleTodos), terminal.Reset)
+		fmt.Printf("     %s⚠  %d stale todo(s) (no activity in %d days)%s\n", terminal.BrightYellow+terminal.Bold, len(staleTodos), staleAfterDays, terminal.Reset)
 		issues += len(staleTodos)
 	} else {
 		fmt.Printf("     %s✓  No stale todos%s\n", terminal.Green, terminal.Reset)
@@ -148,14 +187,48 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("     %s✓  No overdue todos%s\n", terminal.Green, terminal.Reset)
 	}
+	// Check 6: Unreachable commits
+	unreachableCommits := checkUnreachableCommits(todos, commitExists)
+	if commitExists != nil {
+		fmt.Printf("  %s🔍 Checking recorded commits...%s\n", terminal.Dim, terminal.Reset)
+		if len(unreachableCommits) > 0 {
+			fmt.Printf("     %s⚠  %d todo(s) point to commits missing from the repository%s\n", terminal.BrightYellow+terminal.Bold, len(unreachableCommits), terminal.Reset)
+			issues += len(unreachableCommits)
+		} else {
+			fmt.Printf("     %s✓  All recorded commits exist%s\n", terminal.Green, terminal.Reset)
+		}
+	}
 
 	fmt.Println()
 
-	if doctorFix {
+	if doctorFix && doctorDryRun {
+		fmt.Printf("  %s🔍 Dry run — fixes that would be applied (nothing is saved):%s\n", terminal.Dim, terminal.Reset)
+		_, fixes := applyDoctorFixes(todos, projectRoot, doctorStrict, ignore)
+		for _, item := range fixes.removed {
+			fmt.Printf("     %s• remove%s %s%s%s %s %s(%s)%s\n", terminal.Yellow, terminal.Reset,
+				terminal.BrightCyan, shortTodoID(item.ID), terminal.Reset, item.Text, terminal.Dim, item.Detail, terminal.Reset)
+		}
+		for _, item := range fixes.stripped {
+			fmt.Printf("     %s• strip path%s %s from %s%s%s %s\n", terminal.Yellow, terminal.Reset,
+				item.Detail, terminal.BrightCyan, shortTodoID(item.ID), terminal.Reset, item.Text)
+		}
+		for _, todo := range unreachableCommits {
+			fmt.Printf("     %s• clear commit%s %s from %s%s%s %s\n", terminal.Yellow, terminal.Reset,
+				shortCommit(todo.Context.Commit), terminal.BrightCyan, shortTodoID(todo.ID), terminal.Reset, todo.Text)
+		}
+		if fixes.hasChanges() || len(unreachableCommits) > 0 {
+			fmt.Printf("  %sRun 'todo doctor --fix' to apply%s\n", terminal.Dim, terminal.Reset)
+		} else {
+			fmt.Printf("     %sNo changes needed%s\n", terminal.Green, terminal.Reset)
+		}
+		fmt.Println()
+	} else if doctorFix {
 		fmt.Printf("  %s🔧 Applying fixes...%s\n", terminal.Dim, terminal.Reset)
-		todos, fixes := applyDoctorFixes(todos, projectRoot)
+		var fixes doctorFixReport
+		todos, fixes = applyDoctorFixes(todos, projectRoot, doctorStrict, ignore)
+		cleared := clearUnreachableCommits(todos, unreachableCommits, time.Now())
 
-		if fixes.hasChanges() {
+		if fixes.hasChanges() || cleared > 0 {
 			modified = true
 			if fixes.removedOrphanedPaths > 0 {
 				fmt.Printf("     %s• removed %d invalid path(s)%s\n", terminal.Green, fixes.removedOrphanedPaths, terminal.Reset)
@@ -166,18 +239,22 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			if fixes.removedDuplicates > 0 {
 				fmt.Printf("     %s• removed %d duplicate todo(s)%s\n", terminal.Green, fixes.removedDuplicates, terminal.Reset)
 			}
+			if cleared > 0 {
+				fmt.Printf("     %s• cleared %d unreachable commit(s)%s\n", terminal.Green, cleared, terminal.Reset)
+			}
 		} else {
 			fmt.Printf("     %sNo changes needed%s\n", terminal.Green, terminal.Reset)
 		}
 		fmt.Println()
 
 		// Re-run checks after fixes so the summary reflects the latest state
-		orphanedTodos, orphanedPaths, totalPaths = checkOrphanedPaths(todos, projectRoot)
+		orphanedTodos, orphanedPaths, totalPaths = checkOrphanedPaths(todos, projectRoot, ignore)
 		emptyTodos = checkEmptyTodos(todos)
-		duplicates = checkDuplicateTodos(todos)
+		duplicates = checkDuplicateTodos(todos, doctorStrict)
 		staleTodos = checkStaleTodos(todos)
 		overdueTodos = checkOverdueTodos(todos)
-		issues = len(orphanedTodos) + len(emptyTodos) + len(duplicates) + len(staleTodos) + len(overdueTodos)
+		unreachableCommits = checkUnreachableCommits(todos, commitExists)
+		issues = len(orphanedTodos) + len(emptyTodos) + len(duplicates) + len(staleTodos) + len(overdueTodos) + len(unreachableCommits)
 	}
 
 	// Summary
@@ -211,8 +288,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			for _, todo := range orphanedTodos {
 				fmt.Printf("  %s  •%s %s\n", terminal.Dim, terminal.Reset, terminal.Truncate(todo.Text, 50))
 				for _, path := range todo.Context.Paths {
-					absPath := filepath.Join(projectRoot, path)
-					if _, err := os.Stat(absPath); os.IsNotExist(err) {
+					if _, err := os.Stat(resolveTodoPath(projectRoot, path)); os.IsNotExist(err) {
 						fmt.Printf("      %s❌ %s%s\n", terminal.Red, path, terminal.Reset)
 					}
 				}
@@ -223,7 +299,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		if len(staleTodos) > 0 {
 			fmt.Printf("  %s%sStale Todos (consider updating or completing):%s\n", terminal.Yellow, terminal.Bold, terminal.Reset)
 			for _, todo := range staleTodos {
-				age := formatTimeAgo(todo.CreatedAt)
+				age := "last activity " + formatTimeAgo(lastActivity(todo))
 				fmt.Printf("  %s  •%s %s %s(%s)%s\n", terminal.Dim, terminal.Reset, terminal.Truncate(todo.Text, 40), terminal.Dim, age, terminal.Reset)
 			}
 			fmt.Println()
@@ -239,6 +315,13 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Println()
 		}
+		if len(unreachableCommits) > 0 {
+			fmt.Printf("  %s%sUnreachable Commits (rebased away or not fetched; --fix clears them):%s\n", terminal.Yellow, terminal.Bold, terminal.Reset)
+			for _, todo := range unreachableCommits {
+				fmt.Printf("  %s  •%s %s %s(%s)%s\n", terminal.Dim, terminal.Reset, terminal.Truncate(todo.Text, 40), terminal.Dim, shortCommit(todo.Context.Commit), terminal.Reset)
+			}
+			fmt.Println()
+		}
 	}
 
 	// Save if modified
@@ -259,7 +342,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func checkOrphanedPaths(todos []types.Todo, projectRoot string) ([]types.Todo, int, int) {
+func checkOrphanedPaths(todos []types.Todo, projectRoot string, ignore *storage.IgnoreMatcher) ([]types.Todo, int, int) {
 	var orphaned []types.Todo
 	orphanedCount := 0
 	totalPaths := 0
@@ -272,8 +355,7 @@ func checkOrphanedPaths(todos []types.Todo, projectRoot string) ([]types.Todo, i
 		hasOrphan := false
 		for _, path := range todo.Context.Paths {
 			totalPaths++
-			absPath := filepath.Join(projectRoot, path)
-			if _, err := os.Stat(absPath); os.IsNotExist(err) {
+			if isOrphanedPath(projectRoot, path, ignore) {
 				orphanedCount++
 				hasOrphan = true
 			}
@@ -286,6 +368,36 @@ func checkOrphanedPaths(todos []types.Todo, projectRoot string) ([]types.Todo, i
 	return orphaned, orphanedCount, totalPaths
 }
 
+// isOrphanedPath reports whether a stored path no longer exists and is not
+// covered by .todosignore.
+func isOrphanedPath(projectRoot, path string, ignore *storage.IgnoreMatcher) bool {
+	if _, err := os.Stat(resolveTodoPath(projectRoot, path)); !os.IsNotExist(err) {
+		return false
+	}
+	rel := path
+	if filepath.IsAbs(path) {
+		r, err := filepath.Rel(projectRoot, path)
+		if err != nil || strings.HasPrefix(r, "..") {
+			return true
+		}
+		rel = r
+	}
+	return !ignore.Match(rel)
+}
+
+// resolveTodoPath maps a stored todo path to a filesystem path. Relative paths
+// are joined to the project root; absolute paths are canonicalized so paths
+// recorded through a symlink still resolve.
+func resolveTodoPath(projectRoot, path string) string {
+	if filepath.IsAbs(path) {
+		if canonical, err := storage.CanonicalPath(path); err == nil {
+			return canonical
+		}
+		return path
+	}
+	return filepath.Join(projectRoot, filepath.FromSlash(path))
+}
+
 func checkEmptyTodos(todos []types.Todo) []types.Todo {
 	var empty []types.Todo
 	for _, todo := range todos {
@@ -296,12 +408,21 @@ func checkEmptyTodos(todos []types.Todo) []types.Todo {
 	return empty
 }
 
-func checkDuplicateTodos(todos []types.Todo) []types.Todo {
+// duplicateKey is the text two todos must share to count as duplicates:
+// case- and whitespace-insensitive by default, exact (trimmed) when strict.
+func duplicateKey(text string, strict bool) string {
+	if strict {
+		return strings.TrimSpace(text)
+	}
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func checkDuplicateTodos(todos []types.Todo, strict bool) []types.Todo {
 	seen := make(map[string]bool)
 	var duplicates []types.Todo
 
 	for _, todo := range todos {
-		key := strings.TrimSpace(todo.Text)
+		key := duplicateKey(todo.Text, strict)
 		if seen[key] {
 			duplicates = append(duplicates, todo)
 		}
@@ -311,16 +432,32 @@ func checkDuplicateTodos(todos []types.Todo) []types.Todo {
 	return duplicates
 }
 
+// staleAfterDays is how long an open todo can go without activity before
+// doctor reports it as stale.
+const staleAfterDays = 30
+
+// lastActivity is the most recent of a todo's creation, last update, and last
+// review (todo touch).
+func lastActivity(todo types.Todo) time.Time {
+	last := todo.CreatedAt
+	if todo.UpdatedAt.After(last) {
+		last = todo.UpdatedAt
+	}
+	if todo.LastReviewed != nil && todo.LastReviewed.After(last) {
+		last = *todo.LastReviewed
+	}
+	return last
+}
+
 func checkStaleTodos(todos []types.Todo) []types.Todo {
 	var stale []types.Todo
-	now := time.Now()
+	cutoff := time.Now().AddDate(0, 0, -staleAfterDays)
 
 	for _, todo := range todos {
 		if todo.Status != types.StatusOpen {
 			continue
 		}
-		age := now.Sub(todo.CreatedAt)
-		if age.Hours() > 30*24 { // 30 days
+		if lastActivity(todo).Before(cutoff) {
 			stale = append(stale, todo)
 		}
 	}
@@ -342,43 +479,108 @@ func checkOverdueTodos(todos []types.Todo) []types.Todo {
 	return overdue
 }
 
+// doctorCommitExists is the commit lookup for checkUnreachableCommits in the
+// repository containing projectRoot, or nil outside a git repository.
+func doctorCommitExists(projectRoot string) func(string) bool {
+	if !git.IsGitRepoAt(projectRoot) {
+		return nil
+	}
+	return func(hash string) bool { return git.CommitExists(projectRoot, hash) }
+}
+
+// checkUnreachableCommits returns todos whose recorded commit is missing from
+// the repository. Each distinct hash is looked up once; a nil exists skips
+// the check.
+func checkUnreachableCommits(todos []types.Todo, exists func(string) bool) []types.Todo {
+	if exists == nil {
+		return nil
+	}
+	known := make(map[string]bool)
+	var missing []types.Todo
+	for _, todo := range todos {
+		hash := todo.Context.Commit
+		if hash == "" {
+			continue
+		}
+		found, ok := known[hash]
+		if !ok {
+			found = exists(hash)
+			known[hash] = found
+		}
+		if !found {
+			missing = append(missing, todo)
+		}
+	}
+	return missing
+}
+
+// clearUnreachableCommits drops the recorded commit from the todos listed in
+// unreachable and returns how many were cleared.
+func clearUnreachableCommits(todos []types.Todo, unreachable []types.Todo, now time.Time) int {
+	ids := make(map[string]bool, len(unreachable))
+	for _, todo := range unreachable {
+		ids[todo.ID] = true
+	}
+	cleared := 0
+	for i := range todos {
+		if ids[todos[i].ID] && todos[i].Context.Commit != "" {
+			todos[i].Context.Commit = ""
+			todos[i].UpdatedAt = now
+			cleared++
+		}
+	}
+	return cleared
+}
+
 type doctorFixReport struct {
 	removedOrphanedPaths int
 	removedEmpty         int
 	removedDuplicates    int
+	removed              []doctorFixItem // todos dropped, Detail is the reason
+	stripped             []doctorFixItem // paths dropped from kept todos, Detail is the path
+}
+
+// doctorFixItem records one todo affected by a fix, for the --dry-run report.
+type doctorFixItem struct {
+	ID     string
+	Text   string
+	Detail string
 }
 
 func (r doctorFixReport) hasChanges() bool {
 	return r.removedOrphanedPaths > 0 || r.removedEmpty > 0 || r.removedDuplicates > 0
 }
 
-func applyDoctorFixes(todos []types.Todo, projectRoot string) ([]types.Todo, doctorFixReport) {
+func applyDoctorFixes(todos []types.Todo, projectRoot string, strict bool, ignore *storage.IgnoreMatcher) ([]types.Todo, doctorFixReport) {
 	var cleaned []types.Todo
 	fixes := doctorFixReport{}
-	seenText := make(map[string]bool)
+	seenText := make(map[string]string)
 	now := time.Now()
 
 	for _, todo := range todos {
 		text := strings.TrimSpace(todo.Text)
 		if text == "" {
 			fixes.removedEmpty++
+			fixes.removed = append(fixes.removed, doctorFixItem{ID: todo.ID, Text: todo.Text, Detail: "empty"})
 			continue
 		}
 
-		if seenText[text] {
+		key := duplicateKey(text, strict)
+		if firstID, ok := seenText[key]; ok {
 			fixes.removedDuplicates++
+			fixes.removed = append(fixes.removed, doctorFixItem{ID: todo.ID, Text: todo.Text, Detail: "duplicate of " + shortTodoID(firstID)})
 			continue
 		}
-		seenText[text] = true
+		seenText[key] = todo.ID
 
 		if len(todo.Context.Paths) > 0 {
 			validPaths := []string{}
 			for _, path := range todo.Context.Paths {
-				absPath := filepath.Join(projectRoot, path)
-				if _, err := os.Stat(absPath); err == nil {
+				if !isOrphanedPath(projectRoot, path, ignore) {
 					validPaths = append(validPaths, path)
 				} else {
 					fixes.removedOrphanedPaths++
+					fixes.stripped = append(fixes.stripped, doctorFixItem{ID: todo.ID, Text: todo.Text, Detail: path})
 				}
 			}
 			if len(validPaths) != len(todo.Context.Paths) {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
 )
 
@@ -24,7 +25,7 @@ func TestApplyDoctorFixes(t *testing.T) {
 		{ID: "4", Text: "   ", CreatedAt: now, UpdatedAt: now},
 	}
 
-	cleaned, report := applyDoctorFixes(todos, projectRoot)
+	cleaned, report := applyDoctorFixes(todos, projectRoot, false, nil)
 
 	if report.removedEmpty != 1 {
 		t.Fatalf("expected 1 empty removal, got %d", report.removedEmpty)
@@ -45,4 +46,179 @@ func TestApplyDoctorFixes(t *testing.T) {
 			t.Fatalf("expected orphaned paths removed, got %v", todo.Context.Paths)
 		}
 	}
+
+	if len(report.removed) != 2 || report.removed[0].ID != "3" || report.removed[0].Detail != "duplicate of 2" || report.removed[1].Detail != "empty" {
+		t.Fatalf("unexpected removed items: %+v", report.removed)
+	}
+	if len(report.stripped) != 1 || report.stripped[0].ID != "1" || report.stripped[0].Detail != "missing.txt" {
+		t.Fatalf("unexpected stripped items: %+v", report.stripped)
+	}
+	if len(todos[0].Context.Paths) != 1 {
+		t.Fatalf("applyDoctorFixes must not modify its input, got %v", todos[0].Context.Paths)
+	}
+}
+
+func TestCheckDuplicateTodosNormalization(t *testing.T) {
+	todos := []types.Todo{
+		{ID: "1", Text: "Fix bug"},
+		{ID: "2", Text: "fix  bug"},
+		{ID: "3", Text: "  FIX\tBUG "},
+		{ID: "4", Text: "fix bugs"},
+	}
+
+	if got := checkDuplicateTodos(todos, false); len(got) != 2 || got[0].ID != "2" || got[1].ID != "3" {
+		t.Fatalf("expected case/whitespace variants flagged, got %+v", got)
+	}
+	if got := checkDuplicateTodos(todos, true); len(got) != 0 {
+		t.Fatalf("strict mode should not flag variants, got %+v", got)
+	}
+
+	cleaned, report := applyDoctorFixes(todos, t.TempDir(), false, nil)
+	if len(cleaned) != 2 || report.removedDuplicates != 2 {
+		t.Fatalf("fix should match detection: kept %d, removed %d", len(cleaned), report.removedDuplicates)
+	}
+	if cleaned, _ := applyDoctorFixes(todos, t.TempDir(), true, nil); len(cleaned) != 4 {
+		t.Fatalf("strict fix should keep all variants, kept %d", len(cleaned))
+	}
+}
+
+func TestDoctorFixSavesAndDryRunDoesNot(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() { doctorFix = false; doctorDryRun = false })
+
+	todos := []types.Todo{
+		*types.NewTodo("d1", "same"),
+		*types.NewTodo("d2", "same"),
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"doctor", "--fix", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("doctor --fix --dry-run failed: %v", err)
+	}
+	if loaded, _ := storage.LoadTodos(dir); len(loaded) != 2 {
+		t.Fatalf("dry run should not save, got %d todos", len(loaded))
+	}
+
+	doctorDryRun = false
+	rootCmd.SetArgs([]string{"doctor", "--fix"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("doctor --fix failed: %v", err)
+	}
+	if loaded, _ := storage.LoadTodos(dir); len(loaded) != 1 {
+		t.Fatalf("expected duplicate removed and saved, got %d todos", len(loaded))
+	}
+}
+
+func TestCheckOrphanedPathsFollowsSymlinks(t *testing.T) {
+	realRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(realRoot, "pkg"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(realRoot, "pkg"), filepath.Join(realRoot, "src")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	link := filepath.Join(t.TempDir(), "project-link")
+	if err := os.Symlink(realRoot, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "1", Text: "symlinked dir", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{"src"}}},
+		{ID: "2", Text: "absolute via link", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{filepath.Join(link, "pkg")}}},
+		{ID: "3", Text: "really missing", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{"gone"}}},
+	}
+
+	orphaned, count, total := checkOrphanedPaths(todos, realRoot, nil)
+	if total != 3 {
+		t.Fatalf("expected 3 paths checked, got %d", total)
+	}
+	if count != 1 || len(orphaned) != 1 || orphaned[0].ID != "3" {
+		t.Fatalf("expected only todo 3 orphaned, got %d: %+v", count, orphaned)
+	}
+}
+
+func TestCheckOrphanedPathsHonorsIgnore(t *testing.T) {
+	projectRoot := t.TempDir()
+	ignore, err := storage.CompileIgnorePatterns([]string{"build/", "*.gen.go"})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "1", Text: "build output", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{"build/app"}}},
+		{ID: "2", Text: "generated", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{"api/types.gen.go"}}},
+		{ID: "3", Text: "really missing", CreatedAt: now, UpdatedAt: now, Context: types.Context{Paths: []string{"src/gone.go"}}},
+	}
+
+	orphaned, count, _ := checkOrphanedPaths(todos, projectRoot, ignore)
+	if count != 1 || len(orphaned) != 1 || orphaned[0].ID != "3" {
+		t.Fatalf("expected only todo 3 orphaned, got %d: %+v", count, orphaned)
+	}
+
+	cleaned, report := applyDoctorFixes(todos, projectRoot, false, ignore)
+	if report.removedOrphanedPaths != 1 || len(cleaned[0].Context.Paths) != 1 || len(cleaned[1].Context.Paths) != 1 {
+		t.Fatalf("fix should only strip unignored paths: %+v", report)
+	}
+}
+
+func TestCheckUnreachableCommits(t *testing.T) {
+	todos := []types.Todo{
+		{ID: "1", Text: "on main", Context: types.Context{Commit: "abc1234"}},
+		{ID: "2", Text: "rebased away", Context: types.Context{Commit: "dead000"}},
+		{ID: "3", Text: "also rebased", Context: types.Context{Commit: "dead000"}},
+		{ID: "4", Text: "no commit"},
+	}
+	lookups := 0
+	exists := func(hash string) bool {
+		lookups++
+		return hash == "abc1234"
+	}
+
+	if got := checkUnreachableCommits(todos, nil); got != nil {
+		t.Fatalf("outside git the check should be skipped, got %+v", got)
+	}
+	missing := checkUnreachableCommits(todos, exists)
+	if len(missing) != 2 || missing[0].ID != "2" || missing[1].ID != "3" {
+		t.Fatalf("unexpected unreachable todos: %+v", missing)
+	}
+	if lookups != 2 {
+		t.Fatalf("each hash should be looked up once, got %d lookups", lookups)
+	}
+
+	now := time.Now()
+	if cleared := clearUnreachableCommits(todos, missing, now); cleared != 2 {
+		t.Fatalf("expected 2 cleared commits, got %d", cleared)
+	}
+	if todos[0].Context.Commit != "abc1234" || todos[1].Context.Commit != "" || todos[2].Context.Commit != "" {
+		t.Fatalf("unexpected commits after clearing: %+v", todos)
+	}
+	if !todos[1].UpdatedAt.Equal(now) {
+		t.Fatal("clearing a commit should bump updatedAt")
+	}
+}
+
+func TestCheckStaleTodosUsesLastActivity(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, -3, 0)
+	reviewed := now.AddDate(0, 0, -2)
+	todos := []types.Todo{
+		{ID: "1", Text: "untouched", Status: types.StatusOpen, CreatedAt: old, UpdatedAt: old},
+		{ID: "2", Text: "edited yesterday", Status: types.StatusOpen, CreatedAt: old, UpdatedAt: now.AddDate(0, 0, -1)},
+		{ID: "3", Text: "reviewed recently", Status: types.StatusOpen, CreatedAt: old, UpdatedAt: old, LastReviewed: &reviewed},
+		{ID: "4", Text: "old but done", Status: types.StatusDone, CreatedAt: old, UpdatedAt: old},
+	}
+
+	stale := checkStaleTodos(todos)
+	if len(stale) != 1 || stale[0].ID != "1" {
+		t.Fatalf("expected only the untouched todo to be stale, got %+v", stale)
+	}
+	if got := lastActivity(todos[2]); !got.Equal(reviewed) {
+		t.Fatalf("lastActivity = %v, want the review time %v", got, reviewed)
+	}
 }
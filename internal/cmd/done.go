@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
@@ -10,6 +11,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// completeTodo marks a todo done and, when it recurs, returns the next open
+// occurrence for the caller to append. The copy is created open, so saving it
+// never triggers another recurrence.
+func completeTodo(todo *types.Todo) (*types.Todo, error) {
+	todo.MarkDone()
+	if !todo.Recur.IsValid() {
+		return nil, nil
+	}
+	return spawnRecurrence(*todo)
+}
+
 func spawnRecurrence(completed types.Todo) (*types.Todo, error) {
 	id, err := storage.GenerateID()
 	if err != nil {
@@ -24,6 +36,8 @@ func spawnRecurrence(completed types.Todo) (*types.Todo, error) {
 	next.BlockedBy = completed.BlockedBy
 	next.Blocks = completed.Blocks
 	next.CreatedBy = completed.CreatedBy
+	next.Author = completed.Author
+	next.Assignee = completed.Assignee
 
 	base := time.Now()
 	if completed.DueAt != nil {
@@ -35,73 +49,161 @@ func spawnRecurrence(completed types.Todo) (*types.Todo, error) {
 	return next, nil
 }
 
+var (
+	doneAllInPath string
+	doneYes       bool
+	doneForce     bool
+	doneCommit    bool
+)
+
 var doneCmd = &cobra.Command{
 	Use:   "done <id|index> [id|index...]",
 	Short: "Mark one or more todos as done",
 	Long: `Mark todos as completed.
 
 You can specify todos by ID (or partial ID) or by index number
-as shown in 'todo list'. Multiple arguments are supported.`,
+as shown in 'todo list'. Multiple arguments are supported.
+
+--all-in-path completes every open todo with a path under a prefix instead,
+after confirming the count (skip the prompt with --yes).
+
+When statusTransitions in .todos/config.json doesn't allow a todo to move to
+done, nothing is completed unless --force is given.`,
 	Example: `  todo done 1           # Mark todo #1 as done
   todo done 1 2 3       # Mark multiple todos as done
-  todo done abc123      # Mark todo with ID starting with abc123`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runDone,
+  todo done abc123      # Mark todo with ID starting with abc123
+  todo done --all-in-path src/billing --yes`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("all-in-path") {
+			if len(args) > 0 {
+				return fmt.Errorf("--all-in-path cannot be combined with todo arguments")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeUndoneTodoArgs,
+	RunE:              runDone,
 }
 
 func init() {
 	rootCmd.AddCommand(doneCmd)
+	doneCmd.Flags().StringVar(&doneAllInPath, "all-in-path", "", "Complete every open todo with a path under this prefix")
+	doneCmd.Flags().BoolVarP(&doneYes, "yes", "y", false, "Don't ask for confirmation with --all-in-path")
+	doneCmd.Flags().BoolVar(&doneCommit, "commit", false, "Commit the todo files afterwards (default from autoCommit in config.json)")
+	doneCmd.Flags().BoolVar(&doneForce, "force", false, "Complete todos even if statusTransitions in config.json forbids it")
+
+	registerPathFlagCompletion(doneCmd, "all-in-path")
+}
+
+// openTodoIDsInPath returns the IDs of open todos with a path under prefix.
+// Only whole path segments match, so "src" covers "src/a.go" but not
+// "srcfoo/a.go".
+func openTodoIDsInPath(todos []types.Todo, prefix string) []string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	var ids []string
+	for _, t := range storage.FilterTodosByStatus(todos, types.StatusOpen) {
+		for _, p := range t.Context.Paths {
+			if p == prefix || strings.HasPrefix(p, prefix+"/") {
+				ids = append(ids, t.ID)
+				break
+			}
+		}
+	}
+	return ids
 }
 
 func runDone(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
+	pathScope := ""
+	if cmd.Flags().Changed("all-in-path") {
+		pathScope = normalizePathFilter(projectRoot, doneAllInPath)
+		if pathScope == "" || pathScope == "." {
+			return fmt.Errorf("--all-in-path needs a directory or file inside the project")
+		}
+
+		// Confirm before taking the lock so it isn't held while waiting for input.
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		count := len(openTodoIDsInPath(todos, pathScope))
+		if count == 0 {
+			terminal.PrintInfo(fmt.Sprintf("No open todos under %s", pathScope))
+			terminal.PrintBlank()
+			return nil
+		}
+		if !doneYes && !confirmPrompt(fmt.Sprintf("Mark %d open todo(s) under %s as done?", count, pathScope)) {
+			return fmt.Errorf("not confirmed; nothing completed (use --yes to skip the prompt)")
+		}
+	}
+
 	return storage.WithLock(projectRoot, func() error {
 		todos, err := storage.LoadTodos(projectRoot)
 		if err != nil {
 			return fmt.Errorf("failed to load todos: %w", err)
 		}
+		if pathScope != "" {
+			args = openTodoIDsInPath(todos, pathScope)
+		}
+
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		for _, idOrIndex := range args {
+			if todo, _, err := storage.ResolveTodo(todos, idOrIndex); err == nil {
+				if err := checkStatusTransition(config, *todo, types.StatusDone, doneForce); err != nil {
+					return err
+				}
+			}
+		}
 
-		completed := 0
+		var completedTexts []string
 		var recurring []types.Todo
 		for _, idOrIndex := range args {
-			todo, idx := storage.FindTodoByIDOrIndex(todos, idOrIndex)
-			if todo == nil {
-				terminal.PrintWarning(fmt.Sprintf("Not found: %s", idOrIndex))
+			todo, idx, err := storage.ResolveTodo(todos, idOrIndex)
+			if err != nil {
+				warnUnresolved(todos, idOrIndex, err)
 				continue
 			}
 			if todo.Status == types.StatusDone {
 				terminal.PrintWarning(fmt.Sprintf("Already done: %s", todo.Text))
 				continue
 			}
-			todos[idx].MarkDone()
+			next, err := completeTodo(&todos[idx])
 			terminal.PrintSuccess(fmt.Sprintf("Completed: %s", todo.Text))
-			completed++
+			completedTexts = append(completedTexts, todo.Text)
 
-			if todo.Recur.IsValid() {
-				next, err := spawnRecurrence(todos[idx])
-				if err != nil {
-					terminal.PrintWarning(fmt.Sprintf("Failed to create recurring copy: %v", err))
-					continue
-				}
+			if err != nil {
+				terminal.PrintWarning(fmt.Sprintf("Failed to create recurring copy: %v", err))
+			} else if next != nil {
 				recurring = append(recurring, *next)
 				terminal.PrintInfo(fmt.Sprintf("Recurring: created next %s occurrence", todo.Recur))
 			}
 		}
 
-		if completed == 0 {
-			fmt.Println()
+		if len(completedTexts) == 0 {
+			terminal.PrintBlank()
 			return nil
 		}
+		if pathScope != "" {
+			terminal.PrintBlank()
+			terminal.PrintSuccess(fmt.Sprintf("Completed %d todo(s) under %s", len(completedTexts), pathScope))
+		}
 
 		todos = append(todos, recurring...)
 
 		if err := storage.SaveTodos(projectRoot, todos); err != nil {
 			return fmt.Errorf("failed to save todos: %w", err)
 		}
+		if message := commitTodoChange(cmd, projectRoot, "done", completedTexts, doneCommit); message != "" {
+			terminal.PrintDim("Committed: " + message)
+		}
 
 		openCount := 0
 		for _, t := range todos {
@@ -110,6 +212,9 @@ func runDone(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if quiet {
+			return nil
+		}
 		fmt.Println()
 		if openCount == 0 {
 			fmt.Printf("  %s🎉 All todos complete! Great job!%s\n\n", terminal.BrightGreen, terminal.Reset)
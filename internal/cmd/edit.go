@@ -14,6 +14,8 @@ import (
 var (
 	editText           string
 	editPaths          []string
+	editAddPaths       []string
+	editRemovePaths    []string
 	editClearPaths     bool
 	editPriority       string
 	editStatus         string
@@ -33,6 +35,10 @@ var (
 	editClearRecur     bool
 	editAssign         string
 	editClearAssignee  bool
+	editEstimate       int
+	editAIHint         string
+	editClearAIHint    bool
+	editForce          bool
 )
 
 var editCmd = &cobra.Command{
@@ -41,8 +47,9 @@ var editCmd = &cobra.Command{
 	Long: `Update an existing todo without opening the interactive list.
 
 You can change the text, status, priority, or replace/clear any paths.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runEdit,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runEdit,
 }
 
 func init() {
@@ -50,9 +57,13 @@ func init() {
 
 	editCmd.Flags().StringVar(&editText, "text", "", "New todo text")
 	editCmd.Flags().StringArrayVarP(&editPaths, "path", "p", []string{}, "Replace paths (can be provided multiple times)")
+	editCmd.Flags().StringArrayVar(&editAddPaths, "add-path", []string{}, "Add path(s) without replacing existing paths")
+	editCmd.Flags().StringArrayVar(&editRemovePaths, "remove-path", []string{}, "Remove path(s)")
 	editCmd.Flags().BoolVar(&editClearPaths, "clear-paths", false, "Remove all associated paths")
-	editCmd.Flags().StringVar(&editPriority, "priority", "", "Set priority: low, medium, high")
-	editCmd.Flags().StringVar(&editStatus, "status", "", "Set status: open, done, blocked, waiting, tech-debt")
+	editCmd.Flags().StringVar(&editPriority, "priority", "", "Set priority: low, medium, high (or l, m, h)")
+	editCmd.Flags().IntVar(&editEstimate, "estimate", 0, "Set estimated effort in story points (0 clears it)")
+	editCmd.Flags().StringVar(&editStatus, "status", "", "Set status: open, done, blocked, waiting, tech-debt, or a custom status")
+	editCmd.Flags().BoolVar(&editForce, "force", false, "Allow a --status change that statusTransitions in config.json forbids")
 	editCmd.Flags().StringArrayVarP(&editTags, "tag", "t", []string{}, "Replace tags (repeat or comma-separate)")
 	editCmd.Flags().StringArrayVar(&editAddTags, "add-tag", []string{}, "Add tag(s) without replacing existing tags")
 	editCmd.Flags().StringArrayVar(&editRemoveTags, "remove-tag", []string{}, "Remove tag(s)")
@@ -61,6 +72,8 @@ func init() {
 	editCmd.Flags().BoolVar(&editClearDue, "clear-due", false, "Remove due date")
 	editCmd.Flags().StringVar(&editNotes, "notes", "", "Set notes/description")
 	editCmd.Flags().BoolVar(&editClearNotes, "clear-notes", false, "Remove notes")
+	editCmd.Flags().StringVar(&editAIHint, "ai-hint", "", "Set guidance for an AI assistant (see todo explain)")
+	editCmd.Flags().BoolVar(&editClearAIHint, "clear-ai-hint", false, "Remove the AI hint")
 	editCmd.Flags().StringArrayVar(&editBlockedBy, "blocked-by", []string{}, "Set blocker IDs (replaces existing)")
 	editCmd.Flags().StringArrayVar(&editBlocks, "blocks", []string{}, "Set IDs this todo blocks (replaces existing)")
 	editCmd.Flags().BoolVar(&editClearBlockedBy, "clear-blocked-by", false, "Remove all blockers")
@@ -71,6 +84,10 @@ func init() {
 	editCmd.Flags().BoolVar(&editClearAssignee, "clear-assignee", false, "Remove assignee")
 
 	registerPathFlagCompletion(editCmd, "path")
+	registerStatusFlagCompletion(editCmd, "status")
+	registerPriorityFlagCompletion(editCmd, "priority")
+	registerPathFlagCompletion(editCmd, "add-path")
+	registerPathFlagCompletion(editCmd, "remove-path")
 	registerAssigneeFlagCompletion(editCmd, "assign")
 }
 
@@ -81,11 +98,14 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	if editClearNotes && cmd.Flags().Changed("notes") {
 		return fmt.Errorf("cannot use --notes with --clear-notes")
 	}
+	if editClearAIHint && cmd.Flags().Changed("ai-hint") {
+		return fmt.Errorf("cannot use --ai-hint with --clear-ai-hint")
+	}
 	if editClearAssignee && cmd.Flags().Changed("assign") {
 		return fmt.Errorf("cannot use --assign with --clear-assignee")
 	}
 
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -96,9 +116,9 @@ func runEdit(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to load todos: %w", err)
 		}
 
-		todo, idx := storage.FindTodoByIDOrIndex(todos, args[0])
-		if todo == nil {
-			return &types.TodoNotFoundError{ID: args[0]}
+		_, idx, err := storage.ResolveTodo(todos, args[0])
+		if err != nil {
+			return err
 		}
 
 		updated := false
@@ -113,36 +133,74 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		}
 
 		if cmd.Flags().Changed("priority") {
-			p := types.Priority(strings.ToLower(editPriority))
-			if !p.IsValid() {
-				return fmt.Errorf("invalid priority: %s. Use: low, medium, high", editPriority)
+			p, err := types.ParsePriority(editPriority)
+			if err != nil {
+				return err
 			}
 			todos[idx].Priority = p
 			updated = true
 		}
 
+		if cmd.Flags().Changed("estimate") {
+			if err := types.ValidateEstimate(editEstimate); err != nil {
+				return err
+			}
+			todos[idx].Estimate = editEstimate
+			updated = true
+		}
+
 		if cmd.Flags().Changed("status") {
 			status := types.Status(strings.ToLower(editStatus))
 			if !status.IsValid() {
 				return &types.InvalidStatusError{Status: editStatus}
 			}
-			switch status {
-			case types.StatusDone:
-				todos[idx].MarkDone()
-			case types.StatusOpen:
-				todos[idx].MarkOpen()
-			default:
-				todos[idx].Status = status
-				todos[idx].CompletedAt = nil
+			config, err := storage.LoadConfig(projectRoot)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if err := checkStatusTransition(config, todos[idx], status, editForce); err != nil {
+				return err
 			}
+			todos[idx].SetStatus(status)
 			updated = true
 		}
 
 		if editClearPaths {
 			todos[idx].Context.Paths = []string{}
+			todos[idx].Context.Locations = nil
 			updated = true
 		} else if cmd.Flags().Changed("path") {
-			todos[idx].Context.Paths = normalizePaths(editPaths)
+			paths, pathLocations := splitPathLocations(normalizePaths(editPaths))
+			var locations []types.Location
+			for _, loc := range todos[idx].Context.Locations {
+				if containsPath(paths, loc.Path) {
+					locations = append(locations, loc)
+				}
+			}
+			todos[idx].Context.Paths = paths
+			todos[idx].Context.Locations = locations
+			for _, loc := range pathLocations {
+				todos[idx].AddLocation(loc)
+			}
+			updated = true
+		}
+		if cmd.Flags().Changed("add-path") {
+			paths, pathLocations := splitPathLocations(normalizePaths(editAddPaths))
+			todos[idx].Context.Paths = appendPaths(todos[idx].Context.Paths, paths)
+			for _, loc := range pathLocations {
+				todos[idx].AddLocation(loc)
+			}
+			updated = true
+		}
+		if cmd.Flags().Changed("remove-path") {
+			todos[idx].Context.Paths = removePaths(todos[idx].Context.Paths, editRemovePaths)
+			var locations []types.Location
+			for _, loc := range todos[idx].Context.Locations {
+				if !containsPath(normalizePaths(editRemovePaths), loc.Path) {
+					locations = append(locations, loc)
+				}
+			}
+			todos[idx].Context.Locations = locations
 			updated = true
 		}
 
@@ -151,7 +209,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 			updated = true
 		}
 		if cmd.Flags().Changed("tag") {
-			todos[idx].Tags = normalizeTags(editTags)
+			todos[idx].Tags = storage.NormalizeTags(editTags)
 			updated = true
 		}
 		if cmd.Flags().Changed("add-tag") {
@@ -167,7 +225,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 			todos[idx].DueAt = nil
 			updated = true
 		} else if cmd.Flags().Changed("due") {
-			dueAt, err := parseDueDateInput(editDue, time.Now())
+			dueAt, err := types.ParseDueDate(editDue, time.Now())
 			if err != nil {
 				return err
 			}
@@ -183,6 +241,14 @@ func runEdit(cmd *cobra.Command, args []string) error {
 			updated = true
 		}
 
+		if editClearAIHint {
+			todos[idx].Meta.AIHint = ""
+			updated = true
+		} else if cmd.Flags().Changed("ai-hint") {
+			todos[idx].Meta.AIHint = strings.TrimSpace(editAIHint)
+			updated = true
+		}
+
 		if editClearBlockedBy {
 			todos[idx].BlockedBy = nil
 			updated = true
@@ -223,7 +289,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		}
 
 		if !updated {
-			return fmt.Errorf("no updates provided; use --text, --status, --priority, --path, --tag, --due, --notes, --blocked-by, --blocks, --recur, --assign, or clear flags")
+			return fmt.Errorf("no updates provided; use --text, --status, --priority, --path, --tag, --due, --notes, --ai-hint, --blocked-by, --blocks, --recur, --assign, or clear flags")
 		}
 
 		todos[idx].UpdatedAt = time.Now()
@@ -233,7 +299,8 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		}
 
 		terminal.PrintSuccess("Todo updated")
-		fmt.Printf("  %s%s%s\n\n", terminal.Dim, todos[idx].Text, terminal.Reset)
+		terminal.PrintDim(todos[idx].Text)
+		terminal.PrintBlank()
 		return nil
 	})
 }
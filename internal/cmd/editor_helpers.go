@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+)
+
+// resolveEditor returns the editor command from the project config, then
+// $VISUAL or $EDITOR, falling back to vi.
+func resolveEditor(projectRoot string) string {
+	if cfg, err := storage.LoadConfig(projectRoot); err == nil {
+		if editor := strings.TrimSpace(cfg.Editor); editor != "" {
+			return editor
+		}
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return "vi"
+}
+
+// editorCommand builds the argv for opening path in editor, jumping to line
+// when the editor has a known syntax for it. Unknown editors just get the path.
+func editorCommand(editor, path string, line int) []string {
+	argv := strings.Fields(editor)
+	if len(argv) == 0 {
+		argv = []string{"vi"}
+	}
+	if line < 1 {
+		return append(argv, path)
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(argv[0])), ".exe")
+	switch name {
+	case "vi", "vim", "nvim", "gvim", "mvim", "nano", "emacs", "emacsclient", "micro", "kak", "joe", "ne":
+		return append(argv, "+"+strconv.Itoa(line), path)
+	case "code", "code-insiders", "codium", "vscodium", "cursor", "windsurf":
+		return append(argv, "--goto", fmt.Sprintf("%s:%d", path, line))
+	case "subl", "sublime_text", "zed", "hx", "helix":
+		return append(argv, fmt.Sprintf("%s:%d", path, line))
+	case "idea", "goland", "pycharm", "webstorm", "clion", "rubymine", "phpstorm", "rider":
+		return append(argv, "--line", strconv.Itoa(line), path)
+	default:
+		return append(argv, path)
+	}
+}
+
+// launchEditor runs the editor attached to the current terminal and waits for it.
+func launchEditor(editor, path string, line int) error {
+	argv := editorCommand(editor, path, line)
+	Verbosef("editor: %s", strings.Join(argv, " "))
+
+	c := exec.Command(argv[0], argv[1:]...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %q: %w", argv[0], err)
+	}
+	return nil
+}
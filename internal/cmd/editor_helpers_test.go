@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor string
+		line   int
+		want   string
+	}{
+		{"vim", 42, "vim +42 src/auth.go"},
+		{"/usr/bin/nvim", 7, "/usr/bin/nvim +7 src/auth.go"},
+		{"emacsclient -t", 3, "emacsclient -t +3 src/auth.go"},
+		{"code -w", 42, "code -w --goto src/auth.go:42"},
+		{"subl", 9, "subl src/auth.go:9"},
+		{"goland", 5, "goland --line 5 src/auth.go"},
+		{"vim", 0, "vim src/auth.go"},
+		{"unknown-editor", 42, "unknown-editor src/auth.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := strings.Join(editorCommand(tt.editor, "src/auth.go", tt.line), " ")
+			if got != tt.want {
+				t.Fatalf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
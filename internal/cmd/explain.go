@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var explainLines int
+
+// explainDirEntries caps how many entries of a directory path are listed.
+const explainDirEntries = 30
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <id|index>",
+	Short: "Assemble a todo's context for pasting into an AI assistant",
+	Long: `Print a todo as a plain Markdown block for an LLM prompt: the task, its
+metadata and notes, the AI hint stored with --ai-hint, and snippets of the files
+it is attached to. Locations (path:line) show the lines around that line; other
+files show their first lines; directories list their entries.
+
+Nothing is sent anywhere — this only gathers context.`,
+	Example: `  todo explain 1
+  todo explain abc123 --lines 80 | pbcopy`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().IntVar(&explainLines, "lines", 40, "Maximum lines per file snippet")
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	if explainLines < 1 {
+		return fmt.Errorf("--lines must be at least 1")
+	}
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+	todo, _, err := storage.ResolveTodo(todos, args[0])
+	if err != nil {
+		return err
+	}
+	return writeExplanation(cmd.OutOrStdout(), projectRoot, *todo, explainLines)
+}
+
+// writeExplanation renders the prompt block for todo. It is plain text with
+// no colors, so it pastes cleanly.
+func writeExplanation(w io.Writer, projectRoot string, todo types.Todo, maxLines int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Task\n\n%s\n\n", todo.Text)
+
+	fmt.Fprintf(&b, "- ID: %s\n", shortTodoID(todo.ID))
+	fmt.Fprintf(&b, "- Status: %s\n", todo.Status)
+	fmt.Fprintf(&b, "- Priority: %s\n", todo.Priority)
+	if len(todo.Tags) > 0 {
+		fmt.Fprintf(&b, "- Tags: %s\n", strings.Join(todo.Tags, ", "))
+	}
+	if todo.Context.Branch != "" {
+		fmt.Fprintf(&b, "- Branch: %s\n", todo.Context.Branch)
+	}
+	if todo.DueAt != nil {
+		fmt.Fprintf(&b, "- Due: %s\n", todo.DueAt.Local().Format("2006-01-02 15:04"))
+	}
+	if len(todo.BlockedBy) > 0 {
+		fmt.Fprintf(&b, "- Blocked by: %s\n", strings.Join(todo.BlockedBy, ", "))
+	}
+
+	if todo.Notes != "" {
+		fmt.Fprintf(&b, "\n## Notes\n\n%s\n", strings.TrimSpace(todo.Notes))
+	}
+	if hint := strings.TrimSpace(todo.Meta.AIHint); hint != "" {
+		fmt.Fprintf(&b, "\n## Guidance\n\n%s\n", hint)
+	}
+
+	if snippets := explainSnippets(projectRoot, todo, maxLines); len(snippets) > 0 {
+		b.WriteString("\n## Related code\n")
+		for _, s := range snippets {
+			b.WriteString("\n" + s)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// explainSnippets renders one block per location and path, in that order,
+// skipping paths already covered by a location.
+func explainSnippets(projectRoot string, todo types.Todo, maxLines int) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, loc := range todo.Context.Locations {
+		out = append(out, pathSnippet(projectRoot, loc.Path, loc.Line, maxLines))
+		seen[loc.Path] = true
+	}
+	for _, p := range todo.Context.Paths {
+		if !seen[p] {
+			out = append(out, pathSnippet(projectRoot, p, 0, maxLines))
+			seen[p] = true
+		}
+	}
+	return out
+}
+
+// pathSnippet renders a project-relative path: a directory listing, or the
+// lines of a file centered on line (the start of the file when line is 0).
+func pathSnippet(projectRoot, rel string, line, maxLines int) string {
+	abs := filepath.Join(projectRoot, filepath.FromSlash(rel))
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Sprintf("### %s\n\n(not found)\n", rel)
+	}
+	if info.IsDir() {
+		return dirSnippet(abs, rel)
+	}
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return fmt.Sprintf("### %s\n\n(unreadable: %v)\n", rel, err)
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return fmt.Sprintf("### %s\n\n(binary file, %d bytes)\n", rel, len(data))
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) == 0 {
+		return fmt.Sprintf("### %s\n\n(empty file)\n", rel)
+	}
+
+	start := 0
+	if line > 0 {
+		start = line - 1 - maxLines/2
+	}
+	if start > len(lines)-maxLines {
+		start = len(lines) - maxLines
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s (lines %d-%d of %d)\n\n```\n", rel, start+1, end, len(lines))
+	for _, l := range lines[start:end] {
+		b.WriteString(l + "\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+func dirSnippet(abs, rel string) string {
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return fmt.Sprintf("### %s/\n\n(unreadable: %v)\n", rel, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if e.IsDir() {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s/ (directory)\n\n", strings.TrimSuffix(rel, "/"))
+	for i, name := range names {
+		if i == explainDirEntries {
+			fmt.Fprintf(&b, "- … %d more\n", len(names)-explainDirEntries)
+			break
+		}
+		fmt.Fprintf(&b, "- %s\n", name)
+	}
+	if len(names) == 0 {
+		b.WriteString("(empty directory)\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestExplain(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	addPaths, addTags, addAt, addJSON = []string{}, []string{}, []string{}, false
+	t.Cleanup(func() {
+		addAIHint, addPaths, addAt, explainLines = "", []string{}, []string{}, 40
+		editAIHint, editClearAIHint = "", false
+		editCmd.Flags().Lookup("ai-hint").Changed = false
+		editCmd.Flags().Lookup("clear-ai-hint").Changed = false
+		rootCmd.SetOut(nil)
+	})
+
+	var src strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&src, "line %d\n", i)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pkg", "big.txt"), []byte(src.String()), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"add", "Tidy the parser", "--no-git", "--ai-hint", "Keep the public API stable",
+		"--at", "pkg/big.txt:50", "-p", "pkg"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	todos, err := storage.LoadTodos(dir)
+	if err != nil || len(todos) != 1 || todos[0].Meta.AIHint != "Keep the public API stable" {
+		t.Fatalf("expected the AI hint to be stored, got %+v (%v)", todos, err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"explain", "1", "--lines", "4"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Task\n\nTidy the parser\n",
+		"## Guidance\n\nKeep the public API stable\n",
+		"### pkg/big.txt (lines 48-51 of 100)\n\n```\nline 48\nline 49\nline 50\nline 51\n```\n",
+		"### pkg/ (directory)\n\n- big.txt\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("explain output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatal("explain output should carry no color codes")
+	}
+
+	rootCmd.SetArgs([]string{"edit", "1", "--clear-ai-hint"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("edit --clear-ai-hint: %v", err)
+	}
+	todos, _ = storage.LoadTodos(dir)
+	if todos[0].Meta.AIHint != "" || aiHintMarker(todos[0]) != "" {
+		t.Fatalf("expected the hint cleared, got %q", todos[0].Meta.AIHint)
+	}
+	if aiHintMarker(types.Todo{Meta: types.Meta{AIHint: "x"}}) == "" {
+		t.Fatal("expected a marker for todos with a hint")
+	}
+}
@@ -31,7 +31,7 @@ func init() {
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -52,7 +52,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 }
 
 func exportJSON(cmd *cobra.Command, todos []types.Todo) error {
-	out := &types.TodoFile{Version: 1, Todos: todos}
+	out := &types.TodoFile{Version: types.TodoFileVersion, Todos: todos}
 	enc := json.NewEncoder(cmd.OutOrStdout())
 	enc.SetIndent("", "  ")
 	return enc.Encode(out)
@@ -60,7 +60,7 @@ func exportJSON(cmd *cobra.Command, todos []types.Todo) error {
 
 func exportMarkdown(cmd *cobra.Command, todos []types.Todo) error {
 	w := cmd.OutOrStdout()
-	projectRoot, _ := storage.FindProjectRoot(".")
+	projectRoot, _ := resolveProjectRoot()
 	fmt.Fprintln(w, "# Todos")
 
 	groups := map[types.Priority][]types.Todo{}
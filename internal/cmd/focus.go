@@ -15,8 +15,11 @@ import (
 
 var (
 	focusAll      bool
+	focusBranch   bool
 	focusPriority string
 	focusJSON     bool
+	focusPath     string
+	focusMine     bool
 )
 
 var focusCmd = &cobra.Command{
@@ -25,9 +28,24 @@ var focusCmd = &cobra.Command{
 	Long: `Show todos relevant to your current context.
 
 By default, shows open todos that match the current git branch.
-If not in a git repo, shows all open todos.`,
-	Example: `  todo focus        # Show branch-relevant todos
-  todo focus --all  # Show all open todos`,
+If not in a git repo, shows all open todos.
+
+The default scope comes from focusScope in .todos/config.json ("branch" or
+"all", set with todo config --auto-branch-scope). --all and --branch override
+it for one run.
+
+Todos that are overdue or due within 24 hours come first, under a
+"Due soon" heading.
+
+--path narrows the focus to todos touching a directory or file. It combines
+with the branch scope, and still applies with --all.
+
+--mine keeps only the todos you created, matched against git user.name.`,
+	Example: `  todo focus                 # Show branch-relevant todos
+  todo focus --all           # Show all open todos
+  todo focus --branch        # Branch todos even when focusScope is "all"
+  todo focus --path src/auth # Branch todos touching src/auth
+  todo focus --all --mine    # Every open todo you created`,
 	RunE: runFocus,
 }
 
@@ -35,12 +53,19 @@ func init() {
 	rootCmd.AddCommand(focusCmd)
 
 	focusCmd.Flags().BoolVarP(&focusAll, "all", "a", false, "Show all open todos, not just branch-relevant")
-	focusCmd.Flags().StringVar(&focusPriority, "priority", "", "Filter by priority: low, medium, high")
+	focusCmd.Flags().StringVar(&focusPriority, "priority", "", "Filter by priority: low, medium, high (or l, m, h)")
+	focusCmd.Flags().BoolVar(&focusBranch, "branch", false, "Show only branch-relevant todos, even when focusScope is \"all\"")
+	focusCmd.MarkFlagsMutuallyExclusive("all", "branch")
 	focusCmd.Flags().BoolVar(&focusJSON, "json", false, "Output as JSON")
+	focusCmd.Flags().StringVarP(&focusPath, "path", "p", "", "Only todos with a path under this prefix")
+	focusCmd.Flags().BoolVar(&focusMine, "mine", false, "Only todos you created (matched by git user.name)")
+
+	registerPathFlagCompletion(focusCmd, "path")
+	registerPriorityFlagCompletion(focusCmd, "priority")
 }
 
 func runFocus(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -66,48 +91,44 @@ func runFocus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if focusMine {
+		if openTodos, err = filterMyTodos(openTodos); err != nil {
+			return err
+		}
+	}
 	if focusPriority != "" {
-		p := types.Priority(strings.ToLower(focusPriority))
-		if !p.IsValid() {
-			return fmt.Errorf("invalid priority: %s. Use: low, medium, high", focusPriority)
+		p, err := types.ParsePriority(focusPriority)
+		if err != nil {
+			return err
 		}
 		openTodos = storage.FilterTodosByPriority(openTodos, p)
 	}
 
+	pathScope := ""
+	if focusPath != "" {
+		pathScope = normalizePathFilter(projectRoot, focusPath)
+		openTodos = storage.FilterTodosByPath(openTodos, pathScope)
+	}
+
 	// Get current branch for filtering
 	currentBranch := ""
-	if !focusAll && config.AutoGit && git.IsGitRepo() {
-		currentBranch, _ = git.GetCurrentBranch()
-	} else if !focusAll && config.AutoGit && currentBranch == "" && config.DefaultBranch != "" {
-		currentBranch = config.DefaultBranch
+	if focusBranchScoped(config, focusAll, focusBranch) {
+		currentBranch = currentFocusBranch(projectRoot, config)
 	}
 
-	// Filter by branch if applicable
-	var focusedTodos []types.Todo
-	if currentBranch != "" && !focusAll {
-		// First, get todos matching current branch
-		for _, t := range openTodos {
-			if t.Context.Branch == currentBranch {
-				focusedTodos = append(focusedTodos, t)
-			}
-		}
-		// Also include todos with no branch (global todos)
-		for _, t := range openTodos {
-			if t.Context.Branch == "" {
-				focusedTodos = append(focusedTodos, t)
-			}
-		}
-	} else {
-		focusedTodos = openTodos
-	}
+	focusedTodos := filterTodosForBranch(openTodos, currentBranch)
 
-	sortTodosForExecution(focusedTodos, time.Now())
+	now := time.Now()
+	sortTodosForExecution(focusedTodos, now)
+	dueSoon := partitionDueSoon(focusedTodos, now)
 
 	if focusJSON {
 		payload := map[string]any{
-			"todos":  focusedTodos,
-			"count":  len(focusedTodos),
-			"branch": currentBranch,
+			"todos":   focusedTodos,
+			"count":   len(focusedTodos),
+			"dueSoon": dueSoon,
+			"branch":  currentBranch,
+			"path":    pathScope,
 		}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
@@ -146,6 +167,9 @@ func runFocus(cmd *cobra.Command, args []string) error {
 	if currentBranch != "" && !focusAll {
 		fmt.Printf("  %s🌿 Branch: %s%s\n", terminal.Dim, currentBranch, terminal.Reset)
 	}
+	if pathScope != "" {
+		fmt.Printf("  %s📁 Path: %s%s\n", terminal.Dim, pathScope, terminal.Reset)
+	}
 	fmt.Println()
 
 	if len(focusedTodos) == 0 {
@@ -158,6 +182,13 @@ func runFocus(cmd *cobra.Command, args []string) error {
 		var prefix string
 		var textStyle string
 
+		if i == 0 && dueSoon > 0 {
+			fmt.Printf("  %s⏰ Due soon (%d)%s\n", terminal.BrightYellow+terminal.Bold, dueSoon, terminal.Reset)
+		}
+		if i == dueSoon && dueSoon > 0 {
+			fmt.Printf("  %s📋 Up next%s\n\n", terminal.Bold, terminal.Reset)
+		}
+
 		if i == 0 {
 			// First todo - highlighted as current focus
 			fmt.Printf("  %s%s─── CURRENT FOCUS ───%s\n", terminal.BrightCyan, terminal.Dim, terminal.Reset)
@@ -170,9 +201,14 @@ func runFocus(cmd *cobra.Command, args []string) error {
 
 		dueBadge := ""
 		if todo.DueAt != nil {
-			if isOverdueDueDate(todo.DueAt, time.Now()) {
+			switch {
+			case isOverdueDueDate(todo.DueAt, now):
 				dueBadge = terminal.BrightRed + "[OVERDUE]" + terminal.Reset
-			} else {
+			case isDueToday(todo.DueAt, now):
+				dueBadge = terminal.BrightYellow + "[" + todo.DueAt.Format("DUE TODAY 15:04") + "]" + terminal.Reset
+			case isDueSoon(todo.DueAt, now):
+				dueBadge = terminal.BrightYellow + "[" + todo.DueAt.Format("due tomorrow 15:04") + "]" + terminal.Reset
+			default:
 				dueBadge = terminal.Cyan + "[" + todo.DueAt.Format("due 2006-01-02 15:04") + "]" + terminal.Reset
 			}
 		}
@@ -190,11 +226,14 @@ func runFocus(cmd *cobra.Command, args []string) error {
 			if i != 0 {
 				pathColor = terminal.Dim
 			}
-			fmt.Printf("     %s📁 %s%s\n", pathColor, strings.Join(todo.Context.Paths, ", "), terminal.Reset)
+			fmt.Printf("     %s📁 %s%s\n", pathColor, formatTodoPaths(todo.Context), terminal.Reset)
 		}
 		if len(todo.Tags) > 0 {
 			fmt.Printf("     %s🏷️ %s%s\n", terminal.Dim, strings.Join(todo.Tags, ", "), terminal.Reset)
 		}
+		if i == 0 && todo.Context.Commit != "" {
+			fmt.Printf("     %s🔗 %s%s\n", terminal.Dim, formatCommitLink(projectRoot, todo.Context.Commit), terminal.Reset)
+		}
 
 		// Time ago
 		timeAgo := formatTimeAgo(todo.CreatedAt)
@@ -253,3 +292,49 @@ func focusPriorityBadge(p types.Priority) string {
 		return terminal.Yellow + "[med]" + terminal.Reset
 	}
 }
+
+// focusBranchScoped reports whether focus limits todos to the current branch:
+// --all or --branch when given, else the config's focusScope.
+func focusBranchScoped(config *types.Config, all, branch bool) bool {
+	switch {
+	case all:
+		return false
+	case branch:
+		return true
+	}
+	return config.FocusScope != "all"
+}
+
+// currentFocusBranch returns the branch that focus and next scope to: the
+// checked-out git branch, or config.DefaultBranch outside a repository.
+// It is empty when autoGit is off.
+func currentFocusBranch(projectRoot string, config *types.Config) string {
+	if !config.AutoGit {
+		return ""
+	}
+	if git.IsGitRepoAt(projectRoot) {
+		branch, _ := git.GetCurrentBranch(projectRoot)
+		return branch
+	}
+	return config.DefaultBranch
+}
+
+// filterTodosForBranch keeps todos created on branch followed by todos with
+// no branch (global todos). An empty branch keeps everything.
+func filterTodosForBranch(todos []types.Todo, branch string) []types.Todo {
+	if branch == "" {
+		return todos
+	}
+	var scoped []types.Todo
+	for _, t := range todos {
+		if t.Context.Branch == branch {
+			scoped = append(scoped, t)
+		}
+	}
+	for _, t := range todos {
+		if t.Context.Branch == "" {
+			scoped = append(scoped, t)
+		}
+	}
+	return scoped
+}
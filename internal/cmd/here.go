@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"path/filepath"
 	"strings"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
@@ -31,20 +30,15 @@ func init() {
 }
 
 func runHere(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
-	cwd, err := filepath.Abs(".")
+	relDir, err := storage.RelativeToRoot(projectRoot, ".")
 	if err != nil {
 		return fmt.Errorf("failed to determine current directory: %w", err)
 	}
-
-	relDir, err := filepath.Rel(projectRoot, cwd)
-	if err != nil {
-		relDir = "."
-	}
 	if relDir == "." {
 		relDir = ""
 	}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyJSON  bool
+	historyPrune bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id|index>",
+	Short: "Show the status timeline of a todo",
+	Long: fmt.Sprintf(`Print when a todo was created and every status change since, oldest first.
+Only the last %d changes are kept per todo.
+
+With --prune, delete the oldest snapshots in .todos/snapshots beyond
+historyLimit in config.json (default %d) instead. Saving a snapshot prunes
+too, so this is only needed after lowering the limit.`, types.MaxStatusHistory, types.DefaultHistoryLimit),
+	Example: `  todo history 1
+  todo history abc123 --json
+  todo history --prune`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if historyPrune {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output as JSON")
+	historyCmd.Flags().BoolVar(&historyPrune, "prune", false, "Delete the oldest snapshots beyond historyLimit")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	if historyPrune {
+		return pruneSnapshotHistory(projectRoot)
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	todo, _, err := storage.ResolveTodo(todos, args[0])
+	if err != nil {
+		return err
+	}
+
+	if historyJSON {
+		history := todo.History
+		if history == nil {
+			history = []types.StatusChange{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"id":      todo.ID,
+			"text":    todo.Text,
+			"status":  todo.Status,
+			"created": todo.CreatedAt,
+			"history": history,
+		})
+	}
+
+	const layout = "2006-01-02 15:04"
+	fmt.Printf("\n  %s%s%s %s(%s)%s\n\n", terminal.Bold, todo.Text, terminal.Reset, terminal.Dim, shortTodoID(todo.ID), terminal.Reset)
+	fmt.Printf("  %s%s%s  created\n", terminal.Dim, todo.CreatedAt.Local().Format(layout), terminal.Reset)
+	for _, change := range todo.History {
+		fmt.Printf("  %s%s%s  %s%s%s → %s%s%s\n",
+			terminal.Dim, change.At.Local().Format(layout), terminal.Reset,
+			terminal.StatusColor(string(change.From)), change.From, terminal.Reset,
+			terminal.StatusColor(string(change.To)), change.To, terminal.Reset)
+	}
+	if len(todo.History) == 0 {
+		fmt.Printf("  %sNo status changes recorded (currently %s)%s\n", terminal.Dim, todo.Status, terminal.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+// pruneSnapshotHistory deletes the snapshots beyond historyLimit, for
+// todo history --prune.
+func pruneSnapshotHistory(projectRoot string) error {
+	var limit int
+	var pruned []string
+	err := storage.WithLock(projectRoot, func() error {
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		limit = storage.HistoryLimit(config)
+		pruned, err = storage.PruneSnapshots(projectRoot, limit)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(pruned) == 0 {
+		terminal.PrintInfo(fmt.Sprintf("No snapshots to prune (historyLimit %d)", limit))
+	} else {
+		terminal.PrintSuccess(fmt.Sprintf("Pruned %d snapshot(s) to keep %d: %s", len(pruned), limit, strings.Join(pruned, ", ")))
+	}
+	terminal.PrintBlank()
+	return nil
+}
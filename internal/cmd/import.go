@@ -29,7 +29,7 @@ func init() {
 }
 
 func runImport(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -82,6 +82,7 @@ func runImport(cmd *cobra.Command, args []string) error {
 			if strings.TrimSpace(t.CreatedBy) == "" {
 				t.CreatedBy = creator
 			}
+			t.Meta.Source = types.SourceImport
 			existing = append(existing, t)
 			idSet[t.ID] = struct{}{}
 			added++
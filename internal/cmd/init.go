@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
@@ -16,14 +17,19 @@ var (
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new todo project",
-	Long: `Initialize a new todo project in the current directory.
+	Long: `Initialize a new todo project in the current directory, or in the
+directory given with --project.
 
 This creates a .todos/ directory containing:
   - users/: Per-creator todo files (firstname-lastname.json from git user.name)
   - config.json: Project-specific configuration
 
 The .todos/ directory can be committed to version control
-to share todos with your team.`,
+to share todos with your team.
+
+If a parent directory already contains a .todos/ project, init warns
+that a nested project would be created and asks for confirmation.
+Non-interactive sessions must pass --force to proceed.`,
 	Example: `  todo init          # Initialize in current directory
   todo init --force  # Reinitialize existing project`,
 	RunE: runInit,
@@ -32,13 +38,23 @@ to share todos with your team.`,
 func init() {
 	rootCmd.AddCommand(initCmd)
 
-	initCmd.Flags().BoolVarP(&forceInit, "force", "f", false, "Force reinitialize even if already initialized")
+	initCmd.Flags().BoolVarP(&forceInit, "force", "f", false, "Force reinitialize, or create a project nested inside another one")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
 	terminal.PrintHeader("INITIALIZE PROJECT", "📦")
 
-	projectPath, err := storage.InitProject(".", forceInit)
+	dir := "."
+	if projectDir != "" {
+		dir = projectDir
+	}
+	if !forceInit {
+		if err := confirmNestedProject(dir); err != nil {
+			return err
+		}
+	}
+
+	projectPath, err := storage.InitProject(dir, forceInit)
 	if err != nil {
 		if _, ok := err.(*types.AlreadyInitializedError); ok {
 			terminal.PrintWarning("Project already initialized")
@@ -63,3 +79,33 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// confirmNestedProject checks whether path sits inside an existing project.
+// When it does, the user is warned and asked to confirm; without a terminal
+// an error is returned pointing at --force.
+func confirmNestedProject(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	parent := filepath.Dir(absPath)
+	if parent == absPath {
+		return nil
+	}
+	parentRoot, err := storage.FindProjectRoot(parent)
+	if err != nil {
+		// No enclosing project
+		return nil
+	}
+
+	terminal.PrintWarning("A todo project already exists in a parent directory")
+	fmt.Printf("  %sExisting project:%s %s\n", terminal.Dim, terminal.Reset, parentRoot)
+	fmt.Printf("  %sNew project:%s      %s\n", terminal.Dim, terminal.Reset, absPath)
+	fmt.Printf("  %sCommands run below %s will use the nested project.%s\n\n", terminal.Dim, absPath, terminal.Reset)
+
+	if !confirmPrompt("Create a nested project?") {
+		return fmt.Errorf("refusing to create nested project inside %s (use --force to override)", parentRoot)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+)
+
+var (
+	originURLOnce sync.Once
+	originURL     string
+)
+
+// formatCommitLink renders a commit hash as an OSC 8 link to its page on the
+// origin remote of the repository containing projectRoot when the terminal
+// supports it, and as the bare hash otherwise.
+func formatCommitLink(projectRoot, commit string) string {
+	if commit == "" || !terminal.SupportsHyperlinks() {
+		return commit
+	}
+	originURLOnce.Do(func() {
+		originURL, _ = git.GetRemoteURL(projectRoot)
+	})
+	return terminal.Hyperlink(git.CommitURL(originURL, commit), commit)
+}
+
+// shortCommit abbreviates a commit hash to the 7 characters git shows by
+// default. Hashes recorded by add are already short.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -3,10 +3,15 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/contributors"
+	"github.com/bagadi-alnour/todo-cli/internal/stats"
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
@@ -14,17 +19,34 @@ import (
 )
 
 var (
-	listStatic    bool
-	listStatus    string
-	listPath      string
-	listPriority  string
-	listTags      []string
-	listOverdue   bool
-	listDueBefore string
-	listDueAfter  string
-	listDetails   bool
-	listJSON      bool
-	listAssignee  string
+	listStatic     bool
+	listStatus     string
+	listPath       string
+	listPriority   string
+	listTags       []string
+	listOverdue    bool
+	listDueBefore  string
+	listDueAfter   string
+	listTree       bool
+	listSince      string
+	listUntil      string
+	listBy         string
+	listDetails    bool
+	listJSON       bool
+	listCount      bool
+	listAssignee   string
+	listWatch      bool
+	listMouse      bool
+	listNoPriority bool
+	listGroupBy    string
+	listFormat     string
+	listLimit      int
+	listOpenOnly   bool
+	listSource     string
+	listShowDates  bool
+	listDateFormat string
+	listFullText   bool
+	listMine       bool
 )
 
 var listCmd = &cobra.Command{
@@ -39,14 +61,33 @@ By default, opens an interactive view where you can:
   - Delete with d or x
   - Press ? for help
   - Press q to quit
+  - With --mouse, click a todo to select it or its icon to toggle it
 
 Use --static for non-interactive output and --details when you need the full
-metadata for every todo.`,
+metadata for every todo.
+
+--limit N keeps the first N todos after filtering and sorting, and always
+prints the static list, since the interactive view works on the full list.
+
+--count prints only the number of todos left after filtering (0 when none
+match), for shell prompts and dashboards.
+
+--show-dates adds when each todo was created ("created 3 days ago"), and
+when it was last updated with --verbose. --date-format absolute prints
+2024-06-01 style dates instead. Both imply --static.`,
 	Example: `  todo list                  # Interactive mode
   todo list --static         # Non-interactive output
   todo list --static --details # Full metadata in non-interactive output
   todo list --status open    # Filter by status
-  todo list --path src/      # Filter by path`,
+  todo list --open-only --limit 5 # Top five open todos
+  todo list --open-only --count  # Number of open todos
+  todo list --path src/      # Filter by path
+  todo list --mine           # Only todos you created
+  todo list --show-dates     # Add "created 3 days ago" to each todo
+  todo list --watch          # Live static list for a second monitor
+  todo list --format oneline # One plain line per todo for scripts
+  todo list --format '{{short .ID}}\t{{.Status}}\t{{.Text}}'
+  todo list --mouse          # Click to select and toggle`,
 	Aliases: []string{"ls"},
 	RunE:    runList,
 }
@@ -55,56 +96,214 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 
 	listCmd.Flags().BoolVar(&listStatic, "static", false, "Non-interactive output")
-	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status: open, done, blocked, waiting, tech-debt")
+	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status: open, done, blocked, waiting, tech-debt, or a custom status")
 	listCmd.Flags().StringVarP(&listPath, "path", "p", "", "Filter by path prefix")
-	listCmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority: low, medium, high")
+	listCmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority: low, medium, high (or l, m, h)")
 	listCmd.Flags().StringArrayVarP(&listTags, "tag", "t", []string{}, "Filter by tag(s), OR matching (repeat or comma-separate)")
 	listCmd.Flags().BoolVar(&listOverdue, "overdue", false, "Show only overdue open todos")
 	listCmd.Flags().StringVar(&listDueBefore, "due-before", "", "Show todos due on/before this date/time")
 	listCmd.Flags().StringVar(&listDueAfter, "due-after", "", "Show todos due on/after this date/time")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Show todos created on/after this date or age (2024-06-01, 7d)")
+	listCmd.Flags().StringVar(&listUntil, "until", "", "Show todos created on/before this date or age (2024-06-30, 1d)")
+	listCmd.Flags().StringVar(&listBy, "by", "created", "Timestamp --since/--until compare: created or updated")
 	listCmd.Flags().BoolVar(&listDetails, "details", false, "Show full todo details in list output")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
+	listCmd.Flags().BoolVar(&listCount, "count", false, "Print only the number of matching todos")
 	listCmd.Flags().StringVar(&listAssignee, "assignee", "", "Filter by assignee (name, email prefix, or me)")
+	listCmd.Flags().BoolVar(&listWatch, "watch", false, "Keep a static list on screen, re-rendering when todos change")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "Nest todos under the todos blocking them (implies --static)")
+	listCmd.Flags().StringVar(&listGroupBy, "group-by", "", "Group the list into sections: status, priority, branch, path (first path)")
+	listCmd.Flags().BoolVar(&listNoPriority, "no-priority", false, "Hide the priority arrows (↑ high, → medium, ↓ low) in list rows")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print one line per todo from a Go template (e.g. '{{.ID}} {{.Text}}') or a preset: oneline, tsv, ids")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most N todos, after filtering and sorting (implies --static)")
+	listCmd.Flags().BoolVar(&listMine, "mine", false, "Show only todos you created (matched by git user.name)")
+	listCmd.Flags().StringVar(&listSource, "source", "", "Filter by where todos were created: cli, web, import, scan")
+	listCmd.Flags().BoolVar(&listOpenOnly, "open-only", false, "Show only open todos (shortcut for --status open)")
+	listCmd.Flags().BoolVar(&listShowDates, "show-dates", false, "Show when each todo was created (and updated, with --verbose); implies --static")
+	listCmd.Flags().StringVar(&listDateFormat, "date-format", "relative", "How --show-dates prints times: relative or absolute (YYYY-MM-DD); implies --show-dates")
+	listCmd.Flags().BoolVar(&listFullText, "full-text", false, "Wrap long todo text instead of cutting it off (static rows, and the selected row in the interactive list)")
+	listCmd.Flags().BoolVar(&listMouse, "mouse", false, "Enable mouse clicks and wheel scrolling in the interactive list")
 
 	registerPathFlagCompletion(listCmd, "path")
+	registerStatusFlagCompletion(listCmd, "status")
+	registerPriorityFlagCompletion(listCmd, "priority")
 	registerAssigneeFlagCompletion(listCmd, "assignee")
+	_ = listCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions([]string{"created", "updated"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("date-format", cobra.FixedCompletions([]string{"relative", "absolute"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("source", cobra.FixedCompletions(types.Sources, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 	Verbosef("project root: %s", projectRoot)
 
-	todos, err := storage.LoadTodos(projectRoot)
+	if err := validateGroupBy(listGroupBy); err != nil {
+		return err
+	}
+	if listTree && listGroupBy != "" {
+		return fmt.Errorf("cannot use --tree with --group-by")
+	}
+	if listLimit < 0 {
+		return fmt.Errorf("--limit must be a non-negative number")
+	}
+	if listDateFormat != "relative" && listDateFormat != "absolute" {
+		return fmt.Errorf("unknown --date-format %q (use relative or absolute)", listDateFormat)
+	}
+	if cmd.Flags().Changed("date-format") {
+		listShowDates = true
+	}
+
+	if listCount {
+		switch {
+		case listJSON:
+			return fmt.Errorf("cannot use --count with --json")
+		case listWatch:
+			return fmt.Errorf("cannot use --count with --watch")
+		case cmd.Flags().Changed("format"):
+			return fmt.Errorf("cannot use --count with --format")
+		}
+	}
+
+	if listWatch {
+		if listJSON {
+			return fmt.Errorf("cannot use --watch with --json")
+		}
+		if cmd.Flags().Changed("format") {
+			return fmt.Errorf("cannot use --watch with --format")
+		}
+		return watchStaticList(projectRoot)
+	}
+
+	var format *template.Template
+	if cmd.Flags().Changed("format") {
+		if listJSON {
+			return fmt.Errorf("cannot use --format with --json")
+		}
+		if format, err = parseListFormat(listFormat); err != nil {
+			return err
+		}
+	}
+
+	// An unfiltered count only needs the IDs, which matters for huge lists.
+	if listCount && !listFiltered() {
+		count, err := storage.CountTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		if listLimit > 0 && count > listLimit {
+			count = listLimit
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), count)
+		return nil
+	}
+
+	todos, err := loadListTodos(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load todos: %w", err)
+		return err
+	}
+	total := len(todos)
+	todos = limitTodos(todos, listLimit)
+
+	if listCount {
+		fmt.Fprintln(cmd.OutOrStdout(), len(todos))
+		return nil
+	}
+
+	// A format template replaces the decorated output entirely, so pipelines
+	// see only the rendered lines (and nothing for an empty list).
+	if format != nil {
+		return writeFormattedList(cmd.OutOrStdout(), todos, format)
 	}
-	Verbosef("loaded %d todo(s)", len(todos))
 
-	// Apply filters
-	if listStatus != "" {
-		status := types.Status(listStatus)
-		if !status.IsValid() {
-			return &types.InvalidStatusError{Status: listStatus}
+	if listJSON {
+		payload := map[string]any{
+			"todos": todos,
+			"count": len(todos),
+			"stats": countByStatus(todos),
+		}
+		if listLimit > 0 {
+			payload["total"] = total
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(payload)
+	}
+
+	if len(todos) == 0 {
+		terminal.PrintInfo("No todos found")
+		if listFiltered() {
+			terminal.PrintDim("Try removing filters or add a new todo with: todo add \"Your task\"")
+		} else {
+			terminal.PrintDim("Add your first todo with: todo add \"Your task\"")
+		}
+		fmt.Println()
+		return nil
+	}
+
+	// Check for interactive mode
+	if listStatic || listLimit > 0 || listTree || listShowDates || !terminal.ShouldInteract() {
+		if err := displayStaticList(todos, projectRoot, listDetails); err != nil {
+			return err
+		}
+		printLimitNote(len(todos), total)
+		return nil
+	}
+
+	if listGroupBy != "" {
+		todos = orderByGroups(todos, listGroupBy)
+	}
+	return runInteractiveList(todos, projectRoot, listDetails, listMouse)
+}
+
+// listFiltered reports whether any list filter flag is set.
+func listFiltered() bool {
+	return listStatus != "" || listOpenOnly || listPath != "" || listPriority != "" || len(listTags) > 0 || listOverdue || listDueBefore != "" || listDueAfter != "" || listSince != "" || listUntil != "" || listAssignee != "" || listSource != "" || listMine
+}
+
+// loadListTodos loads todos and applies the list filter flags, sorted for display.
+func loadListTodos(projectRoot string) ([]types.Todo, error) {
+	statusFilter := listStatus
+	if listOpenOnly {
+		if statusFilter != "" && types.Status(statusFilter) != types.StatusOpen {
+			return nil, fmt.Errorf("cannot use --open-only with --status %s", statusFilter)
 		}
+		statusFilter = string(types.StatusOpen)
+	}
+	status := types.Status(statusFilter)
+	if statusFilter != "" && !status.IsValid() {
+		return nil, &types.InvalidStatusError{Status: statusFilter}
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load todos: %w", err)
+	}
+	Verbosef("loaded %d todo(s)", len(todos))
+	if statusFilter != "" {
 		todos = storage.FilterTodosByStatus(todos, status)
 	}
 
+	if listMine {
+		if todos, err = filterMyTodos(todos); err != nil {
+			return nil, err
+		}
+	}
 	if listPath != "" {
-		todos = storage.FilterTodosByPath(todos, listPath)
+		todos = storage.FilterTodosByPath(todos, normalizePathFilter(projectRoot, listPath))
 	}
 
 	if listPriority != "" {
-		p := types.Priority(strings.ToLower(listPriority))
-		if !p.IsValid() {
-			return fmt.Errorf("invalid priority: %s. Use: low, medium, high", listPriority)
+		p, err := types.ParsePriority(listPriority)
+		if err != nil {
+			return nil, err
 		}
 		todos = storage.FilterTodosByPriority(todos, p)
 	}
 	if len(listTags) > 0 {
-		todos = storage.FilterTodosByTags(todos, normalizeTags(listTags))
+		todos = storage.FilterTodosByTags(todos, storage.NormalizeTags(listTags))
 	}
 	if listOverdue {
 		todos = storage.FilterOverdueTodos(todos, time.Now())
@@ -112,61 +311,154 @@ func runList(cmd *cobra.Command, args []string) error {
 	if listDueBefore != "" {
 		cutoff, err := parseDueFilterInput(listDueBefore, time.Now(), true)
 		if err != nil {
-			return fmt.Errorf("invalid --due-before value: %w", err)
+			return nil, fmt.Errorf("invalid --due-before value: %w", err)
 		}
 		todos = storage.FilterTodosDueBefore(todos, cutoff)
 	}
 	if listDueAfter != "" {
 		cutoff, err := parseDueFilterInput(listDueAfter, time.Now(), false)
 		if err != nil {
-			return fmt.Errorf("invalid --due-after value: %w", err)
+			return nil, fmt.Errorf("invalid --due-after value: %w", err)
 		}
 		todos = storage.FilterTodosDueAfter(todos, cutoff)
 	}
+	if listSince != "" || listUntil != "" {
+		if listBy != "created" && listBy != "updated" {
+			return nil, fmt.Errorf("invalid --by value %q (use created or updated)", listBy)
+		}
+		now := time.Now()
+		var since, until time.Time
+		if listSince != "" {
+			if since, err = parsePastDateInput(listSince, now, false); err != nil {
+				return nil, fmt.Errorf("invalid --since value: %w", err)
+			}
+		}
+		if listUntil != "" {
+			if until, err = parsePastDateInput(listUntil, now, true); err != nil {
+				return nil, fmt.Errorf("invalid --until value: %w", err)
+			}
+		}
+		todos = storage.FilterTodosInWindow(todos, since, until, listBy == "updated")
+	}
+	if listSource != "" {
+		todos = storage.FilterTodosBySource(todos, listSource)
+	}
 	if listAssignee != "" {
 		emails, err := contributors.MatchEmails(projectRoot, listAssignee)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		todos = storage.FilterTodosByAssignee(todos, emails)
 	}
 
 	storage.SortTodosByPriority(todos)
+	return todos, nil
+}
 
-	if listJSON {
-		payload := map[string]any{
-			"todos": todos,
-			"count": len(todos),
-			"stats": countByStatus(todos),
-		}
-		enc := json.NewEncoder(cmd.OutOrStdout())
-		enc.SetIndent("", "  ")
-		return enc.Encode(payload)
+// filterMyTodos keeps the todos the current git user created, for --mine.
+// When no todo records an author and none is in the user's own file, it warns
+// on stderr (so --json and --count output stay clean) and keeps every todo.
+func filterMyTodos(todos []types.Todo) ([]types.Todo, error) {
+	name, err := storage.CurrentUserName()
+	if err != nil {
+		return nil, fmt.Errorf("--mine needs git user.name (or TODO_USER_NAME): %w", err)
+	}
+	slug, _ := storage.CurrentUserSlug()
+	if !storage.HasAuthorInfo(todos, slug) {
+		fmt.Fprintf(os.Stderr, "%s⚠ No todo records who created it; --mine shows every todo%s\n", terminal.BrightYellow, terminal.Reset)
+		return todos, nil
 	}
+	return storage.FilterTodosByAuthor(todos, name, slug), nil
+}
 
-	if len(todos) == 0 {
-		terminal.PrintInfo("No todos found")
-		if listStatus != "" || listPath != "" || listPriority != "" || len(listTags) > 0 || listOverdue || listDueBefore != "" || listDueAfter != "" || listAssignee != "" {
-			terminal.PrintDim("Try removing filters or add a new todo with: todo add \"Your task\"")
-		} else {
-			terminal.PrintDim("Add your first todo with: todo add \"Your task\"")
-		}
-		fmt.Println()
-		return nil
+// limitTodos keeps the first n todos; n <= 0 means no limit.
+func limitTodos(todos []types.Todo, n int) []types.Todo {
+	if n <= 0 || len(todos) <= n {
+		return todos
 	}
+	return todos[:n]
+}
 
-	// Check for interactive mode
-	if listStatic || !terminal.IsInteractiveTerminal() {
-		return displayStaticList(todos, projectRoot, listDetails)
+// printLimitNote tells how many todos --limit left out of the static list.
+func printLimitNote(shown, total int) {
+	if shown < total {
+		terminal.PrintDim(fmt.Sprintf("Showing %d of %d todos (--limit %d)", shown, total, listLimit))
+		fmt.Println()
 	}
+}
+
+// watchStaticList re-renders the static list whenever the todo files change,
+// until interrupted.
+func watchStaticList(projectRoot string) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	fmt.Print(terminal.HideCursor)
+	defer fmt.Print(terminal.ShowCursor)
+
+	var lastMod time.Time
+	first := true
+	for {
+		if mod := storage.TodosModTime(projectRoot); first || mod.After(lastMod) {
+			first = false
+			lastMod = mod
+			fmt.Print(terminal.CursorHome + terminal.ClearScreen)
+			fmt.Printf("  %s👀 Watching for changes — Ctrl+C to stop (updated %s)%s\n",
+				terminal.Dim, time.Now().Format("15:04:05"), terminal.Reset)
+			todos, err := loadListTodos(projectRoot)
+			if err != nil {
+				terminal.PrintError(err.Error())
+			} else if len(todos) == 0 {
+				fmt.Println()
+				terminal.PrintInfo("No todos found")
+			} else {
+				limited := limitTodos(todos, listLimit)
+				if err := displayStaticList(limited, projectRoot, listDetails); err != nil {
+					return err
+				}
+				printLimitNote(len(limited), len(todos))
+			}
+		}
 
-	return runInteractiveList(todos, projectRoot, listDetails)
+		select {
+		case <-interrupt:
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
-func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded bool) error {
+func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded, mouse bool) error {
 	selectedIndex := 0
+	var rows []int
 	showDeleteConfirm := false
 	showDoneConfirm := false
+	var count keyCount
+
+	// Filter-as-you-type: view holds the indices into todos that match query,
+	// and selectedIndex points into view.
+	query := ""
+	typingQuery := false
+	view := filterView(todos, query)
+
+	// Add mode: a prompt for the text of a new todo.
+	addingTodo := false
+	newText := ""
+
+	// With --group-by, todos arrive ordered by group. Labels are fixed here so
+	// a todo that changes status stays under its section until the next run.
+	var groupOf map[string]string
+	if listGroupBy != "" {
+		groupOf = make(map[string]string, len(todos))
+		for _, todo := range todos {
+			groupOf[todo.ID] = groupLabel(todo, listGroupBy)
+		}
+	}
 
 	// Set terminal to raw mode
 	termState, err := terminal.MakeRaw()
@@ -175,9 +467,26 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 	}
 	defer termState.Restore()
 
+	// Start on the todo selected when the list last closed, if it still exists.
+	config, err := storage.LoadConfig(projectRoot)
+	if err == nil && config.LastSelected != "" {
+		for i, idx := range view {
+			if todos[idx].ID == config.LastSelected {
+				selectedIndex = i
+				break
+			}
+		}
+	}
+
 	// Switch to alternate screen
 	terminal.Write(terminal.AltScreenOn + terminal.HideCursor)
 	defer terminal.Write(terminal.ShowCursor + terminal.AltScreenOff)
+	if mouse {
+		// Terminals without mouse reporting ignore these codes; the keyboard
+		// keeps working either way.
+		terminal.Write(terminal.MouseOn)
+		defer terminal.Write(terminal.MouseOff)
+	}
 
 	showError := func(err error) {
 		terminal.Write(terminal.CursorHome + terminal.ClearScreen)
@@ -186,27 +495,60 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 		terminal.ReadKey()
 	}
 
+	refilter := func() {
+		view = filterView(todos, query)
+		if selectedIndex >= len(view) {
+			selectedIndex = len(view) - 1
+		}
+		if selectedIndex < 0 {
+			selectedIndex = 0
+		}
+	}
+
+	// selected returns the index into todos of the selected row, or -1.
+	selected := func() int {
+		if selectedIndex < 0 || selectedIndex >= len(view) {
+			return -1
+		}
+		return view[selectedIndex]
+	}
+
+	defer func() {
+		id := ""
+		if idx := selected(); idx >= 0 {
+			id = todos[idx].ID
+		}
+		if err := saveLastSelected(projectRoot, id); err != nil {
+			Verbosef("list: failed to remember selection: %v", err)
+		}
+	}()
+
 	for {
-		if showDeleteConfirm {
-			displayDeleteConfirm(todos, selectedIndex)
+		if addingTodo {
+			displayAddPrompt(newText)
+		} else if showDeleteConfirm {
+			displayDeleteConfirm(todos, selected())
 		} else if showDoneConfirm {
-			displayDoneConfirm(todos, selectedIndex)
+			displayDoneConfirm(todos, selected())
 		} else {
-			displayInteractiveTodos(todos, projectRoot, selectedIndex, detailsExpanded)
+			rows = displayInteractiveTodos(viewTodos(todos, view), projectRoot, selectedIndex, detailsExpanded, query, typingQuery, groupOf)
 		}
 
 		key := terminal.ReadKey()
 
 		if showDeleteConfirm {
 			switch key {
-			case "y", "Y":
-				if selectedIndex >= 0 && selectedIndex < len(todos) {
-					todos = storage.DeleteTodo(todos, selectedIndex)
-					if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			case "y", "Y", "d": // a second d completes vim-style dd
+				if idx := selected(); idx >= 0 {
+					id := todos[idx].ID
+					err := saveListChange(projectRoot, id, func(all []types.Todo, i int) ([]types.Todo, error) {
+						return storage.DeleteTodo(all, i), nil
+					})
+					if err != nil {
 						showError(err)
-					}
-					if selectedIndex >= len(todos) && selectedIndex > 0 {
-						selectedIndex--
+					} else {
+						todos = storage.DeleteTodo(todos, idx)
+						refilter()
 					}
 					if len(todos) == 0 {
 						return nil
@@ -222,10 +564,34 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 		if showDoneConfirm {
 			switch key {
 			case "y", "Y":
-				if selectedIndex >= 0 && selectedIndex < len(todos) {
-					todos[selectedIndex].MarkDone()
-					if err := storage.SaveTodos(projectRoot, todos); err != nil {
+				if idx := selected(); idx >= 0 {
+					var updated types.Todo
+					var spawned *types.Todo
+					err := saveListChange(projectRoot, todos[idx].ID, func(all []types.Todo, i int) ([]types.Todo, error) {
+						if err := checkListTransition(projectRoot, all[i], types.StatusDone); err != nil {
+							return nil, err
+						}
+						next, err := completeTodo(&all[i])
+						if err != nil {
+							return nil, err
+						}
+						updated, spawned = all[i], next
+						if next != nil {
+							all = append(all, *next)
+						}
+						return all, nil
+					})
+					if err != nil {
 						showError(err)
+					} else {
+						todos[idx] = updated
+						if spawned != nil {
+							todos = append(todos, *spawned)
+							if groupOf != nil {
+								groupOf[spawned.ID] = groupLabel(*spawned, listGroupBy)
+							}
+						}
+						refilter()
 					}
 				}
 				showDoneConfirm = false
@@ -235,34 +601,141 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 			continue
 		}
 
-		switch key {
-		case "q", "Q", "ESC":
-			return nil
+		if addingTodo {
+			switch key {
+			case "ESC":
+				addingTodo = false
+			case "ENTER":
+				addingTodo = false
+				text := strings.TrimSpace(newText)
+				if text == "" {
+					continue
+				}
+				todo, err := addListTodo(projectRoot, text)
+				if err != nil {
+					showError(err)
+					continue
+				}
+				todos = append(todos, *todo)
+				if groupOf != nil {
+					groupOf[todo.ID] = groupLabel(*todo, listGroupBy)
+				}
+				// Clear the filter so the new todo is visible, then select it.
+				query = ""
+				refilter()
+				for i, idx := range view {
+					if idx == len(todos)-1 {
+						selectedIndex = i
+					}
+				}
+			case "BACKSPACE":
+				if r := []rune(newText); len(r) > 0 {
+					newText = string(r[:len(r)-1])
+				}
+			case "SPACE":
+				newText += " "
+			default:
+				if len(key) == 1 && key[0] >= 0x20 && key[0] < 0x7f {
+					newText += key
+				}
+			}
+			continue
+		}
+
+		if typingQuery {
+			switch key {
+			case "ESC":
+				query, typingQuery = "", false
+			case "ENTER":
+				typingQuery = false
+			case "BACKSPACE":
+				if r := []rune(query); len(r) > 0 {
+					query = string(r[:len(r)-1])
+				}
+			case "SPACE":
+				query += " "
+			case "UP", "DOWN":
+				if next, ok := moveSelection(selectedIndex, len(view), key, 0); ok {
+					selectedIndex = next
+				}
+				continue
+			default:
+				if len(key) == 1 && key[0] >= 0x20 && key[0] < 0x7f {
+					query += key
+				}
+			}
+			selectedIndex = 0
+			refilter()
+			continue
+		}
+
+		// Digits build a count for the next motion (5j, 3k, 10g); any other
+		// key consumes and resets it.
+		if count.feed(key) {
+			continue
+		}
+		if next, ok := moveSelection(selectedIndex, len(view), key, count.take()); ok {
+			selectedIndex = next
+			continue
+		}
 
-		case "DOWN", "j":
-			if selectedIndex < len(todos)-1 {
-				selectedIndex++
+		if row, col, ok := terminal.ParseClick(key); ok {
+			clicked, onIcon := clickTarget(rows, row, col)
+			if clicked < 0 {
+				continue
+			}
+			selectedIndex = clicked
+			if !onIcon {
+				continue
 			}
+			key = "SPACE"
+		}
 
-		case "UP", "k":
-			if selectedIndex > 0 {
-				selectedIndex--
+		switch key {
+		case "ESC":
+			// Esc first clears an active filter, then quits.
+			if query != "" {
+				query = ""
+				refilter()
+				continue
 			}
+			return nil
+
+		case "q", "Q":
+			return nil
+
+		case "/":
+			typingQuery = true
+
+		case "a", "A", "n", "N":
+			addingTodo, newText = true, ""
 
 		case "SPACE", "ENTER":
-			if selectedIndex >= 0 && selectedIndex < len(todos) {
-				if todos[selectedIndex].Status == types.StatusDone {
-					todos[selectedIndex].MarkOpen()
-					if err := storage.SaveTodos(projectRoot, todos); err != nil {
-						showError(err)
+			idx := selected()
+			if idx < 0 {
+				continue
+			}
+			if todos[idx].Status == types.StatusDone {
+				var updated types.Todo
+				err := saveListChange(projectRoot, todos[idx].ID, func(all []types.Todo, i int) ([]types.Todo, error) {
+					if err := checkListTransition(projectRoot, all[i], types.StatusOpen); err != nil {
+						return nil, err
 					}
+					all[i].MarkOpen()
+					updated = all[i]
+					return all, nil
+				})
+				if err != nil {
+					showError(err)
 				} else {
-					showDoneConfirm = true
+					todos[idx] = updated
 				}
+			} else {
+				showDoneConfirm = true
 			}
 
 		case "d", "D", "x", "X":
-			if selectedIndex >= 0 && selectedIndex < len(todos) {
+			if selected() >= 0 {
 				showDeleteConfirm = true
 			}
 
@@ -272,12 +745,6 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 		case "LEFT":
 			detailsExpanded = false
 
-		case "g":
-			selectedIndex = 0
-
-		case "G":
-			selectedIndex = len(todos) - 1
-
 		case "?", "h", "H":
 			displayHelp()
 			terminal.ReadKey()
@@ -285,9 +752,96 @@ func runInteractiveList(todos []types.Todo, projectRoot string, detailsExpanded
 	}
 }
 
-func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedIndex int, detailsExpanded bool) {
+// addListTodo creates a todo with text for the interactive list's add key,
+// capturing git context like add does, and saves it.
+func addListTodo(projectRoot, text string) (*types.Todo, error) {
+	config, err := storage.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	id, err := storage.GenerateID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+	todo := types.NewTodo(id, text)
+	if err := storage.ApplyCreator(todo); err != nil {
+		return nil, err
+	}
+	if branch, commit := captureGitContext(projectRoot, config); branch != "" {
+		todo.SetGitContext(branch, commit)
+	}
+
+	err = storage.WithLock(projectRoot, func() error {
+		all, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		return storage.SaveTodos(projectRoot, append(all, *todo))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// saveLastSelected records id as the interactive list's selection in the
+// project config. It skips the write when nothing changed.
+func saveLastSelected(projectRoot, id string) error {
+	return storage.WithLock(projectRoot, func() error {
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if config.LastSelected == id {
+			return nil
+		}
+		config.LastSelected = id
+		return storage.SaveConfig(projectRoot, config)
+	})
+}
+
+// saveListChange re-reads every todo under the lock, applies change to the
+// todo with id, and saves the result. The interactive list can show a
+// filtered subset, and saving that slice directly would drop the todos it
+// hides from their owner files.
+func saveListChange(projectRoot, id string, change func(all []types.Todo, idx int) ([]types.Todo, error)) error {
+	return storage.WithLock(projectRoot, func() error {
+		all, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		for i := range all {
+			if all[i].ID != id {
+				continue
+			}
+			all, err = change(all, i)
+			if err != nil {
+				return err
+			}
+			return storage.SaveTodos(projectRoot, all)
+		}
+		return fmt.Errorf("todo %s no longer exists", shortTodoID(id))
+	})
+}
+
+// checkListTransition applies statusTransitions to a change made with the
+// interactive list's keys. The config is read each time so edits to it apply
+// while the list is open; there is no --force here.
+func checkListTransition(projectRoot string, todo types.Todo, status types.Status) error {
+	config, err := storage.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return types.ValidateTransition(todo.Status, status, config)
+}
+
+// displayInteractiveTodos renders the interactive list and returns the
+// 1-based screen row of each todo's line, for mapping mouse clicks.
+func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedIndex int, detailsExpanded bool, query string, typingQuery bool, groupOf map[string]string) []int {
 	terminal.Write(terminal.CursorHome + terminal.ClearScreen)
 	now := time.Now()
+	rows := make([]int, len(todos))
+	row := listHeaderRows + 1
 
 	terminal.WriteLine("")
 	terminal.WriteLine(fmt.Sprintf("  %s%s╭─────────────────────────────────────────────────────╮%s", terminal.Bold, terminal.BrightCyan, terminal.Reset))
@@ -295,20 +849,43 @@ func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedInd
 	terminal.WriteLine(fmt.Sprintf("  %s%s╰─────────────────────────────────────────────────────╯%s", terminal.Bold, terminal.BrightCyan, terminal.Reset))
 	terminal.WriteLine("")
 
-	terminal.WriteLine(fmt.Sprintf("  %s↑↓%s navigate  %s␣%s toggle  %si%s info  %sd%s delete  %sq%s quit  %s?%s help",
-		terminal.Yellow+terminal.Bold, terminal.Reset+terminal.Dim,
-		terminal.Green+terminal.Bold, terminal.Reset+terminal.Dim,
-		terminal.Cyan+terminal.Bold, terminal.Reset+terminal.Dim,
-		terminal.Red+terminal.Bold, terminal.Reset+terminal.Dim,
-		terminal.BrightRed+terminal.Bold, terminal.Reset+terminal.Dim,
-		terminal.Cyan+terminal.Bold, terminal.Reset))
+	// The filter replaces the key hints so the header keeps listHeaderRows lines.
+	if typingQuery || query != "" {
+		cursor, hint := "", "esc clear"
+		if typingQuery {
+			cursor, hint = "▏", "enter keep  esc clear"
+		}
+		terminal.WriteLine(fmt.Sprintf("  %s/%s%s%s%s  %s%d match(es)  %s%s",
+			terminal.Yellow+terminal.Bold, terminal.Reset, query, cursor, terminal.Reset,
+			terminal.Dim, len(todos), hint, terminal.Reset))
+	} else {
+		terminal.WriteLine(fmt.Sprintf("  %s↑↓%s navigate  %s␣%s toggle  %si%s info  %sd%s delete  %s/%s filter  %sq%s quit  %s?%s help",
+			terminal.Yellow+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.Green+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.Cyan+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.Red+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.Yellow+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.BrightRed+terminal.Bold, terminal.Reset+terminal.Dim,
+			terminal.Cyan+terminal.Bold, terminal.Reset))
+	}
 	terminal.WriteLine("")
 
+	if len(todos) == 0 {
+		terminal.WriteLine(fmt.Sprintf("  %sNo todos match \"%s\"%s", terminal.Dim, query, terminal.Reset))
+		return rows
+	}
+
 	for i, todo := range todos {
+		if groupOf != nil {
+			if label := groupOf[todo.ID]; i == 0 || label != groupOf[todos[i-1].ID] {
+				terminal.WriteLine(fmt.Sprintf("  %s── %s %s──%s", terminal.Dim, groupHeaderLabel(label, listGroupBy), terminal.Reset+terminal.Dim, terminal.Reset))
+				row++
+			}
+		}
+
 		isSelected := i == selectedIndex
 		var line string
 
-		priorityLabel, priorityColor := priorityVisual(todo.Priority)
 		if isSelected {
 			line = fmt.Sprintf("  %s%s▸ ", terminal.Bold, terminal.BrightCyan)
 		} else {
@@ -328,7 +905,12 @@ func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedInd
 			}
 		}
 
-		line += fmt.Sprintf("%s%s%s ", priorityColor, priorityLabel, terminal.Reset)
+		line += priorityIndicator(todo.Priority) + idLabel(todo)
+		if isSelected {
+			line += terminal.Bold + terminal.BrightWhite
+		} else if todo.Status == types.StatusDone {
+			line += terminal.Dim
+		}
 
 		duePrefix := ""
 		if todo.DueAt != nil {
@@ -338,20 +920,29 @@ func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedInd
 				duePrefix = terminal.BrightCyan + "⏳ " + terminal.Reset
 			}
 		}
-		text := terminal.Truncate(todo.Text, 50)
 		assigneePrefix := ""
 		if todo.Assignee != "" {
 			assigneePrefix = terminal.BrightMagenta + "@" + formatAssigneeLabel(projectRoot, todo.Assignee) + " " + terminal.Reset
 		}
-		line += assigneePrefix + duePrefix + text + terminal.Reset
+		line += assigneePrefix + duePrefix + recurMarker(todo) + aiHintMarker(todo)
+		textLines := []string{terminal.Truncate(todo.Text, 50)}
+		if listFullText && isSelected {
+			textLines = wrapTodoText(line, todo.Text)
+		}
 
-		terminal.WriteLine(line)
+		terminal.WriteLine(line + textLines[0] + terminal.Reset)
+		rows[i] = row
+		row++
+		for _, l := range textLines[1:] {
+			terminal.WriteLine(terminal.Bold + terminal.BrightWhite + l + terminal.Reset)
+			row++
+		}
 
 		if isSelected {
 			if detailsExpanded {
-				writeTodoDetailLines(todo, projectRoot, "      ", now, true)
+				row += writeTodoDetailLines(todo, projectRoot, "      ", now, true)
 			} else {
-				writeTodoSummaryLines(todo, projectRoot, now)
+				row += writeTodoSummaryLines(todo, projectRoot, now)
 			}
 		}
 	}
@@ -377,22 +968,48 @@ func displayInteractiveTodos(todos []types.Todo, projectRoot string, selectedInd
 	stats := countByStatus(todos)
 	terminal.WriteLine(fmt.Sprintf("  %s%s●%s %d open  %s●%s %d done%s",
 		terminal.Dim, terminal.Blue, terminal.Dim, stats["open"], terminal.Green, terminal.Dim, stats["done"], terminal.Reset))
+	return rows
+}
+
+// listHeaderRows is the number of lines displayInteractiveTodos prints above
+// the first todo.
+const listHeaderRows = 7
+
+// listIconColumn is the last screen column of a todo line's "▸ ✓" prefix;
+// clicks at or left of it toggle the todo.
+const listIconColumn = 6
+
+// clickTarget maps a clicked screen position to a todo index (-1 when the
+// row holds no todo) and whether the click landed on its status icon.
+func clickTarget(rows []int, row, col int) (int, bool) {
+	for i, r := range rows {
+		if r == row {
+			return i, col <= listIconColumn
+		}
+	}
+	return -1, false
 }
 
-func writeTodoSummaryLines(todo types.Todo, projectRoot string, now time.Time) {
+func writeTodoSummaryLines(todo types.Todo, projectRoot string, now time.Time) int {
+	lines := 0
 	if len(todo.Context.Paths) > 0 {
-		terminal.WriteLine(fmt.Sprintf("      %s📁 %s%s", terminal.Dim, strings.Join(todo.Context.Paths, ", "), terminal.Reset))
+		lines++
+		terminal.WriteLine(fmt.Sprintf("      %s📁 %s%s", terminal.Dim, formatTodoPaths(todo.Context), terminal.Reset))
 	}
 	if todo.Context.Branch != "" {
+		lines++
 		terminal.WriteLine(fmt.Sprintf("      %s🌿 %s%s", terminal.Dim, todo.Context.Branch, terminal.Reset))
 	}
 	if todo.Notes != "" {
+		lines++
 		terminal.WriteLine(fmt.Sprintf("      %s📝 %s%s", terminal.Dim, terminal.Truncate(todo.Notes, 60), terminal.Reset))
 	}
 	if len(todo.Tags) > 0 {
+		lines++
 		terminal.WriteLine(fmt.Sprintf("      %s🏷️ %s%s", terminal.Dim, strings.Join(todo.Tags, ", "), terminal.Reset))
 	}
 	if todo.Assignee != "" {
+		lines++
 		terminal.WriteLine(fmt.Sprintf("      %s👤 %s%s", terminal.Dim, formatAssigneeLabel(projectRoot, todo.Assignee), terminal.Reset))
 	}
 	if todo.DueAt != nil {
@@ -400,8 +1017,23 @@ func writeTodoSummaryLines(todo types.Todo, projectRoot string, now time.Time) {
 		if isOverdueDueDate(todo.DueAt, now) {
 			color = terminal.BrightRed
 		}
+		lines++
 		terminal.WriteLine(fmt.Sprintf("      %s⏳ %s%s", color, formatDueLabel(todo.DueAt, now), terminal.Reset))
 	}
+	return lines
+}
+
+func displayAddPrompt(text string) {
+	terminal.Write(terminal.CursorHome + terminal.ClearScreen)
+
+	terminal.WriteLine("")
+	terminal.WriteLine(fmt.Sprintf("  %s%s╭─────────────────────────────────────────────────────╮%s", terminal.Bold, terminal.BrightCyan, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %s%s│  ➕  NEW TODO                                        │%s", terminal.Bold, terminal.BrightCyan, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %s%s╰─────────────────────────────────────────────────────╯%s", terminal.Bold, terminal.BrightCyan, terminal.Reset))
+	terminal.WriteLine("")
+	terminal.WriteLine(fmt.Sprintf("  %s>%s %s%s▏%s", terminal.Green+terminal.Bold, terminal.Reset, terminal.BrightWhite, text, terminal.Reset))
+	terminal.WriteLine("")
+	terminal.WriteLine(fmt.Sprintf("  Press %s%sEnter%s to add, %s%sEsc%s to cancel", terminal.Green+terminal.Bold, "", terminal.Reset, terminal.Red+terminal.Bold, "", terminal.Reset))
 }
 
 func displayDeleteConfirm(todos []types.Todo, selectedIndex int) {
@@ -424,7 +1056,7 @@ func displayDeleteConfirm(todos []types.Todo, selectedIndex int) {
 
 	terminal.WriteLine(fmt.Sprintf("  %sThis action cannot be undone.%s", terminal.Red, terminal.Reset))
 	terminal.WriteLine("")
-	terminal.WriteLine(fmt.Sprintf("  Press %s%sY%s to confirm, %s%sN%s to cancel", terminal.Green+terminal.Bold, "", terminal.Reset, terminal.Red+terminal.Bold, "", terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  Press %s%sY%s (or %s%sd%s) to confirm, %s%sN%s to cancel", terminal.Green+terminal.Bold, "", terminal.Reset, terminal.Green+terminal.Bold, "", terminal.Reset, terminal.Red+terminal.Bold, "", terminal.Reset))
 }
 
 func displayDoneConfirm(todos []types.Todo, selectedIndex int) {
@@ -462,6 +1094,7 @@ func displayHelp() {
 	terminal.WriteLine(fmt.Sprintf("  %s↓%s %sj%s    Move down", terminal.Yellow+terminal.Bold, terminal.Reset, terminal.Dim, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %sg%s      Jump to top", terminal.Yellow+terminal.Bold, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %sG%s      Jump to bottom", terminal.Yellow+terminal.Bold, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %s5j%s %s10g%s Move 5 down / jump to #10 (counts work with j k g G)", terminal.Yellow+terminal.Bold, terminal.Reset, terminal.Yellow+terminal.Bold, terminal.Reset))
 	terminal.WriteLine("")
 
 	terminal.WriteLine(fmt.Sprintf("  %sActions%s", terminal.Bold+terminal.Green, terminal.Reset))
@@ -469,10 +1102,12 @@ func displayHelp() {
 	terminal.WriteLine(fmt.Sprintf("  %sEnter%s  Toggle todo status", terminal.Green+terminal.Bold, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %si%s      Expand/collapse selected todo details", terminal.Cyan+terminal.Bold, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %s→%s/%s←%s    Expand/collapse selected todo details", terminal.Cyan+terminal.Bold, terminal.Reset, terminal.Cyan+terminal.Bold, terminal.Reset))
-	terminal.WriteLine(fmt.Sprintf("  %sd%s/%sx%s   Delete selected todo", terminal.Red+terminal.Bold, terminal.Reset, terminal.Red+terminal.Bold, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %sa%s/%sn%s   Add a new todo", terminal.Green+terminal.Bold, terminal.Reset, terminal.Green+terminal.Bold, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %sd%s/%sx%s   Delete selected todo (%sdd%s deletes without the Y)", terminal.Red+terminal.Bold, terminal.Reset, terminal.Red+terminal.Bold, terminal.Reset, terminal.Red+terminal.Bold, terminal.Reset))
 	terminal.WriteLine("")
 
 	terminal.WriteLine(fmt.Sprintf("  %sOther%s", terminal.Bold+terminal.Cyan, terminal.Reset))
+	terminal.WriteLine(fmt.Sprintf("  %s/%s      Filter by text (Enter keeps, Esc clears)", terminal.Yellow+terminal.Bold, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %sq%s      Quit", terminal.Red+terminal.Bold, terminal.Reset))
 	terminal.WriteLine(fmt.Sprintf("  %s?%s      Show this help", terminal.Cyan+terminal.Bold, terminal.Reset))
 	terminal.WriteLine("")
@@ -491,10 +1126,10 @@ func displayStaticList(todos []types.Todo, projectRoot string, details bool) err
 	fmt.Printf("\n  %s%s📋 TODO LIST%s\n", terminal.Bold, terminal.BrightCyan, terminal.Reset)
 	fmt.Printf("  %s─────────────────────────────────────────%s\n\n", terminal.Dim, terminal.Reset)
 
-	for i, todo := range todos {
+	printTodo := func(i int) {
+		todo := todos[i]
 		statusColor := terminal.StatusColor(string(todo.Status))
 		checkbox := terminal.StatusIcon(string(todo.Status))
-		priorityLabel, priorityColor := priorityVisual(todo.Priority)
 
 		textStyle := ""
 		if todo.Status == types.StatusDone {
@@ -505,11 +1140,12 @@ func displayStaticList(todos []types.Todo, projectRoot string, details bool) err
 		if todo.Assignee != "" {
 			assigneePrefix = fmt.Sprintf("%s@%s %s", terminal.BrightMagenta, formatAssigneeLabel(projectRoot, todo.Assignee), terminal.Reset)
 		}
-		fmt.Printf("  %s%d.%s %s%s%s %s%s%s %s%s%s%s\n",
+		prefix := fmt.Sprintf("  %s%d.%s %s%s%s %s%s%s%s",
 			terminal.Dim, i+1, terminal.Reset,
 			statusColor, checkbox, terminal.Reset,
-			priorityColor, priorityLabel, terminal.Reset,
-			assigneePrefix, textStyle, todo.Text, terminal.Reset)
+			priorityIndicator(todo.Priority)+idLabel(todo),
+			assigneePrefix, recurMarker(todo)+aiHintMarker(todo), textStyle)
+		fmt.Println(prefix + staticTodoText(prefix, todo.Text, textStyle) + terminal.Reset + listDatesSuffix(todo))
 
 		if details {
 			writeTodoDetailLines(todo, projectRoot, "     ", now, false)
@@ -518,7 +1154,7 @@ func displayStaticList(todos []types.Todo, projectRoot string, details bool) err
 				fmt.Printf("     %s📝 %s%s\n", terminal.Dim, terminal.Truncate(todo.Notes, 60), terminal.Reset)
 			}
 			if len(todo.Context.Paths) > 0 {
-				fmt.Printf("     %s📁 %s%s\n", terminal.Dim, strings.Join(todo.Context.Paths, ", "), terminal.Reset)
+				fmt.Printf("     %s📁 %s%s\n", terminal.Dim, formatTodoPaths(todo.Context), terminal.Reset)
 			}
 			if todo.Context.Branch != "" {
 				fmt.Printf("     %s🌿 %s%s\n", terminal.Dim, todo.Context.Branch, terminal.Reset)
@@ -539,18 +1175,107 @@ func displayStaticList(todos []types.Todo, projectRoot string, details bool) err
 		}
 	}
 
+	var tree *todoTree
+	if listTree {
+		t, err := buildTodoTree(todos)
+		if err != nil {
+			terminal.PrintWarning(fmt.Sprintf("Can't draw a tree (%v); showing a flat list", err))
+		} else {
+			tree = &t
+		}
+	}
+
+	if tree != nil {
+		printTodoTree(todos, *tree, projectRoot)
+	} else if listGroupBy != "" {
+		// Numbers stay those of the flat list so they still work as indexes.
+		for g, group := range groupTodos(todos, listGroupBy) {
+			if g > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("  %s%s %s", terminal.Bold, groupHeaderLabel(group.Label, listGroupBy), terminal.Reset)
+			fmt.Printf("%s(%d)%s\n", terminal.Dim, len(group.Indices), terminal.Reset)
+			for _, i := range group.Indices {
+				printTodo(i)
+			}
+		}
+	} else {
+		for i := range todos {
+			printTodo(i)
+		}
+	}
+
 	stats := countByStatus(todos)
 	fmt.Println()
 	fmt.Printf("  %s%s●%s %d open  %s●%s %d done%s\n",
 		terminal.Dim, terminal.Blue, terminal.Dim, stats["open"], terminal.Green, terminal.Dim, stats["done"], terminal.Reset)
-	fmt.Printf("\n  %s💡 Run 'todo list' in a terminal for interactive mode%s\n", terminal.Dim, terminal.Reset)
+	fmt.Println()
+	if len(todos) > 0 {
+		fmt.Printf("  %s💡 Commands count numbers in file order, not this view's; pass the ID instead: todo done %s%s\n", terminal.Dim, shortTodoID(todos[0].ID), terminal.Reset)
+	}
+	fmt.Printf("  %s💡 Run 'todo list' in a terminal for interactive mode%s\n", terminal.Dim, terminal.Reset)
 	fmt.Printf("  %s💡 Run 'todo ui' for web interface%s\n\n", terminal.Dim, terminal.Reset)
 
 	return nil
 }
 
-func writeTodoDetailLines(todo types.Todo, projectRoot string, indent string, now time.Time, useRawMode bool) {
+// staticTodoText returns todo text for a static row that starts with
+// prefix: as is by default, or with --full-text wrapped to the terminal
+// width, continuation lines indented under the start of the text and styled
+// with style.
+func staticTodoText(prefix, text, style string) string {
+	if !listFullText {
+		return text
+	}
+	lines := wrapTodoText(prefix, text)
+	for i := 1; i < len(lines); i++ {
+		lines[i] = style + lines[i]
+	}
+	return strings.Join(lines, terminal.Reset+"\n")
+}
+
+// wrapTodoText wraps text to fit the terminal after prefix, which may hold
+// color codes. The first line continues prefix; the others are indented with
+// spaces to line up under it. Text is kept at least minWrapWidth columns wide
+// however long the prefix.
+func wrapTodoText(prefix, text string) []string {
+	const minWrapWidth = 20
+	indent := terminal.VisibleWidth(prefix)
+	width := terminal.Width() - indent
+	if width < minWrapWidth {
+		width = minWrapWidth
+	}
+	lines := terminal.Wrap(text, width)
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.Repeat(" ", indent) + lines[i]
+	}
+	return lines
+}
+
+// listDatesSuffix is the --show-dates annotation for a static list row: when
+// the todo was created and, with --verbose, last updated. Empty without
+// --show-dates.
+func listDatesSuffix(todo types.Todo) string {
+	if !listShowDates || todo.CreatedAt.IsZero() {
+		return ""
+	}
+	date := func(t time.Time) string {
+		if listDateFormat == "absolute" {
+			return t.Local().Format("2006-01-02")
+		}
+		return formatTimeAgo(t)
+	}
+	dates := "created " + date(todo.CreatedAt)
+	if verbose && !todo.UpdatedAt.IsZero() {
+		dates += ", updated " + date(todo.UpdatedAt)
+	}
+	return fmt.Sprintf(" %s· %s%s", terminal.Dim, dates, terminal.Reset)
+}
+
+func writeTodoDetailLines(todo types.Todo, projectRoot string, indent string, now time.Time, useRawMode bool) int {
+	lines := 0
 	write := func(line string) {
+		lines++
 		if useRawMode {
 			terminal.WriteLine(line)
 			return
@@ -599,14 +1324,15 @@ func writeTodoDetailLines(todo types.Todo, projectRoot string, indent string, no
 	if todo.Recur != "" {
 		writeDetail("Recur", string(todo.Recur))
 	}
+	writeDetail("Time", trackedTimeLabel(todo, now))
 	if len(todo.Context.Paths) > 0 {
-		writeDetail("Paths", strings.Join(todo.Context.Paths, ", "))
+		writeDetail("Paths", formatTodoPaths(todo.Context))
 	}
 	if todo.Context.Branch != "" {
 		writeDetail("Branch", todo.Context.Branch)
 	}
 	if todo.Context.Commit != "" {
-		writeDetail("Commit", todo.Context.Commit)
+		writeDetail("Commit", formatCommitLink(projectRoot, todo.Context.Commit))
 	}
 	if len(todo.BlockedBy) > 0 {
 		writeDetail("Blocked by", strings.Join(todo.BlockedBy, ", "))
@@ -625,20 +1351,11 @@ func writeTodoDetailLines(todo types.Todo, projectRoot string, indent string, no
 	if todo.CompletedAt != nil {
 		writeDate("Done", *todo.CompletedAt)
 	}
+	return lines
 }
 
 func countByStatus(todos []types.Todo) map[string]int {
-	counts := map[string]int{
-		"open":      0,
-		"done":      0,
-		"blocked":   0,
-		"waiting":   0,
-		"tech-debt": 0,
-	}
-	for _, t := range todos {
-		counts[string(t.Status)]++
-	}
-	return counts
+	return stats.CountByStatus(todos)
 }
 
 func normalizePriority(p types.Priority) types.Priority {
@@ -651,10 +1368,43 @@ func normalizePriority(p types.Priority) types.Priority {
 func priorityVisual(p types.Priority) (string, string) {
 	switch normalizePriority(p) {
 	case types.PriorityHigh:
-		return "[H]", terminal.BrightRed
+		return "[H]", terminal.PriorityColor(string(types.PriorityHigh))
 	case types.PriorityLow:
-		return "[L]", terminal.Dim
+		return "[L]", terminal.PriorityColor(string(types.PriorityLow))
 	default:
-		return "[M]", terminal.Yellow
+		return "[M]", terminal.PriorityColor(string(types.PriorityMedium))
+	}
+}
+
+// priorityIndicator renders the colored priority arrow used in list rows,
+// or nothing when --no-priority is set.
+func priorityIndicator(p types.Priority) string {
+	if listNoPriority {
+		return ""
+	}
+	level := string(normalizePriority(p))
+	return terminal.PriorityColor(level) + terminal.PriorityIcon(level) + terminal.Reset + " "
+}
+
+// idLabel is the dim short ID list rows show before the text. Numbers given
+// to done, edit, and status count todos in file order while the list is
+// sorted by priority, so the ID is the reliable handle.
+func idLabel(todo types.Todo) string {
+	return terminal.Dim + shortTodoID(todo.ID) + terminal.Reset + " "
+}
+
+// recurMarker flags recurring todos in list rows.
+func recurMarker(todo types.Todo) string {
+	if !todo.Recur.IsValid() {
+		return ""
+	}
+	return "🔁 "
+}
+
+// aiHintMarker flags todos carrying an AI hint (see todo explain).
+func aiHintMarker(todo types.Todo) string {
+	if strings.TrimSpace(todo.Meta.AIHint) == "" {
+		return ""
 	}
+	return "🤖 "
 }
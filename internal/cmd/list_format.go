@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// listFormatPresets are the named --format templates.
+var listFormatPresets = map[string]string{
+	"oneline": `{{short .ID}} {{.Status}} {{.Priority}} {{.Text}}`,
+	"tsv":     `{{.ID}}\t{{.Status}}\t{{.Priority}}\t{{join .Context.Paths ","}}\t{{.Text}}`,
+	"ids":     `{{.ID}}`,
+}
+
+var listFormatFuncs = template.FuncMap{
+	"short": shortTodoID,
+	"join":  func(items []string, sep string) string { return strings.Join(items, sep) },
+	"date": func(t any) string {
+		switch v := t.(type) {
+		case time.Time:
+			return v.Local().Format("2006-01-02")
+		case *time.Time:
+			if v != nil {
+				return v.Local().Format("2006-01-02")
+			}
+		}
+		return ""
+	},
+}
+
+// parseListFormat compiles a --format value: a preset name or a text/template
+// string. Literal \t and \n sequences are expanded so separators can be typed
+// in a shell without quoting tricks.
+func parseListFormat(format string) (*template.Template, error) {
+	if preset, ok := listFormatPresets[format]; ok {
+		format = preset
+	}
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+	tmpl, err := template.New("format").Funcs(listFormatFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeFormattedList renders one line per todo with tmpl. Templates get the
+// todo itself and no list position: the list is sorted by priority, so a
+// position wouldn't be the index other commands resolve.
+func writeFormattedList(w io.Writer, todos []types.Todo, tmpl *template.Template) error {
+	var line strings.Builder
+	for _, todo := range todos {
+		line.Reset()
+		if err := tmpl.Execute(&line, todo); err != nil {
+			return fmt.Errorf("failed to render --format for todo %s: %w", shortTodoID(todo.ID), err)
+		}
+		out := strings.TrimSuffix(line.String(), "\n")
+		if _, err := fmt.Fprintln(w, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
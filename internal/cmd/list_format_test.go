@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestWriteFormattedList(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("abcdef123456", "first"),
+		*types.NewTodo("fedcba654321", "second"),
+	}
+	todos[0].Context.Paths = []string{"src/a", "src/b"}
+	todos[1].Status = types.StatusBlocked
+
+	render := func(format string) string {
+		t.Helper()
+		tmpl, err := parseListFormat(format)
+		if err != nil {
+			t.Fatalf("parse %q: %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := writeFormattedList(&buf, todos, tmpl); err != nil {
+			t.Fatalf("render %q: %v", format, err)
+		}
+		return buf.String()
+	}
+
+	if got, want := render("oneline"), "abcdef12 open medium first\nfedcba65 blocked medium second\n"; got != want {
+		t.Fatalf("oneline = %q; want %q", got, want)
+	}
+	if got, want := render(`{{short .ID}}\t{{join .Context.Paths ","}}\t{{.Text}}`), "abcdef12\tsrc/a,src/b\tfirst\nfedcba65\t\tsecond\n"; got != want {
+		t.Fatalf("custom = %q; want %q", got, want)
+	}
+	if got := render("{{.ID}}\n"); got != "abcdef123456\nfedcba654321\n" {
+		t.Fatalf("a trailing newline in the template should not double lines, got %q", got)
+	}
+
+	if _, err := parseListFormat("{{.Text"); err == nil || !strings.Contains(err.Error(), "invalid --format template") {
+		t.Fatalf("expected a template compile error, got %v", err)
+	}
+	tmpl, err := parseListFormat("{{.Nope}}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := writeFormattedList(&bytes.Buffer{}, todos, tmpl); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
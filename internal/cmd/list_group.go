@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// todoGroup is one section of a grouped list: a label and the indices of its
+// todos in the original slice, in their original order.
+type todoGroup struct {
+	Label   string
+	Indices []int
+}
+
+func validateGroupBy(by string) error {
+	switch by {
+	case "", "status", "priority", "branch", "path":
+		return nil
+	}
+	return fmt.Errorf("invalid --group-by value: %s. Use: status, priority, branch, path", by)
+}
+
+// groupLabel returns the section a todo falls under when grouping by by.
+func groupLabel(todo types.Todo, by string) string {
+	switch by {
+	case "status":
+		return string(todo.Status)
+	case "priority":
+		return string(normalizePriority(todo.Priority))
+	case "branch":
+		if todo.Context.Branch == "" {
+			return "(no branch)"
+		}
+		return todo.Context.Branch
+	case "path":
+		if len(todo.Context.Paths) == 0 {
+			return "(no path)"
+		}
+		return todo.Context.Paths[0]
+	}
+	return ""
+}
+
+// groupRank orders sections: statuses in workflow order with done last,
+// priorities high to low, and placeholder groups after named ones. Groups of
+// equal rank sort by label.
+func groupRank(label, by string) int {
+	switch by {
+	case "status":
+		switch types.Status(label) {
+		case types.StatusOpen:
+			return 0
+		case types.StatusBlocked:
+			return 1
+		case types.StatusWaiting:
+			return 2
+		case types.StatusTechDebt:
+			return 3
+		case types.StatusDone:
+			return 5
+		}
+		return 4 // custom statuses sit between the built-in open states and done
+	case "priority":
+		return -types.Priority(label).PriorityWeight()
+	case "branch", "path":
+		if label == "(no branch)" || label == "(no path)" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// groupTodos splits todos into non-empty sections ordered by groupRank.
+func groupTodos(todos []types.Todo, by string) []todoGroup {
+	var groups []todoGroup
+	position := make(map[string]int)
+	for i, todo := range todos {
+		label := groupLabel(todo, by)
+		g, ok := position[label]
+		if !ok {
+			g = len(groups)
+			position[label] = g
+			groups = append(groups, todoGroup{Label: label})
+		}
+		groups[g].Indices = append(groups[g].Indices, i)
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		ra, rb := groupRank(groups[a].Label, by), groupRank(groups[b].Label, by)
+		if ra != rb {
+			return ra < rb
+		}
+		return groups[a].Label < groups[b].Label
+	})
+	return groups
+}
+
+// orderByGroups returns todos reordered so each group is contiguous, for the
+// interactive list's flat navigation.
+func orderByGroups(todos []types.Todo, by string) []types.Todo {
+	ordered := make([]types.Todo, 0, len(todos))
+	for _, g := range groupTodos(todos, by) {
+		for _, idx := range g.Indices {
+			ordered = append(ordered, todos[idx])
+		}
+	}
+	return ordered
+}
+
+// groupHeaderLabel decorates a group label with the icon used for its field.
+func groupHeaderLabel(label, by string) string {
+	switch by {
+	case "status":
+		return terminal.StatusColor(label) + terminal.StatusIcon(label) + " " + label
+	case "priority":
+		return terminal.PriorityColor(label) + terminal.PriorityIcon(label) + " " + label
+	case "branch":
+		return "🌿 " + label
+	case "path":
+		return "📁 " + label
+	}
+	return label
+}
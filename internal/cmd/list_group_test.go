@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestGroupTodos(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("a", "done one"),
+		*types.NewTodo("b", "open one"),
+		*types.NewTodo("c", "blocked"),
+		*types.NewTodo("d", "open two"),
+		*types.NewTodo("e", "debt"),
+	}
+	todos[0].Status = types.StatusDone
+	todos[2].Status = types.StatusBlocked
+	todos[4].Status = types.StatusTechDebt
+	todos[0].Priority = types.PriorityLow
+	todos[2].Priority = types.PriorityHigh
+	todos[1].Context.Branch = "main"
+	todos[3].Context.Branch = "feature/x"
+	todos[1].Context.Paths = []string{"src/b", "docs"}
+
+	labels := func(groups []todoGroup) []string {
+		out := make([]string, len(groups))
+		for i, g := range groups {
+			out[i] = g.Label
+		}
+		return out
+	}
+
+	byStatus := groupTodos(todos, "status")
+	if got, want := labels(byStatus), []string{"open", "blocked", "tech-debt", "done"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("status groups = %v; want %v", got, want)
+	}
+	if got := byStatus[0].Indices; !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Fatalf("open group should keep list order, got %v", got)
+	}
+
+	if got, want := labels(groupTodos(todos, "priority")), []string{"high", "medium", "low"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("priority groups = %v; want %v", got, want)
+	}
+	if got, want := labels(groupTodos(todos, "branch")), []string{"feature/x", "main", "(no branch)"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("branch groups = %v; want %v", got, want)
+	}
+	if got, want := labels(groupTodos(todos, "path")), []string{"src/b", "(no path)"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("path groups = %v; want %v", got, want)
+	}
+
+	ordered := orderByGroups(todos, "status")
+	ids := make([]string, len(ordered))
+	for i, todo := range ordered {
+		ids[i] = todo.ID
+	}
+	if want := []string{"b", "d", "c", "e", "a"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("orderByGroups = %v; want %v", ids, want)
+	}
+
+	if err := validateGroupBy("assignee"); err == nil {
+		t.Fatal("expected an error for an unsupported --group-by field")
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// keyCount accumulates a vim-style numeric prefix ("5" in "5j") across the
+// single tokens returned by terminal.ReadKey.
+type keyCount struct {
+	n int
+}
+
+// feed consumes key into the prefix and reports whether it was a digit. A
+// leading 0 is not a count, so it falls through as a normal key.
+func (c *keyCount) feed(key string) bool {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' || (key == "0" && c.n == 0) {
+		return false
+	}
+	if c.n < 100000 {
+		c.n = c.n*10 + int(key[0]-'0')
+	}
+	return true
+}
+
+// take returns the typed count (0 when none) and resets the prefix.
+func (c *keyCount) take() int {
+	n := c.n
+	c.n = 0
+	return n
+}
+
+// moveSelection applies a motion key with an optional count to the selected
+// index of a list of length total. It reports false for non-motion keys.
+// Without a count every motion behaves exactly like the single key.
+func moveSelection(selected, total int, key string, count int) (int, bool) {
+	if total == 0 {
+		return selected, isMotionKey(key)
+	}
+	steps := count
+	if steps == 0 {
+		steps = 1
+	}
+
+	switch key {
+	case "DOWN", "j":
+		selected += steps
+	case "UP", "k":
+		selected -= steps
+	case "g":
+		// 5g jumps to the fifth todo; plain g jumps to the top.
+		selected = 0
+		if count > 0 {
+			selected = count - 1
+		}
+	case "G":
+		selected = total - 1
+		if count > 0 {
+			selected = count - 1
+		}
+	default:
+		return selected, false
+	}
+
+	if selected < 0 {
+		selected = 0
+	}
+	if selected > total-1 {
+		selected = total - 1
+	}
+	return selected, true
+}
+
+func isMotionKey(key string) bool {
+	switch key {
+	case "DOWN", "j", "UP", "k", "g", "G":
+		return true
+	}
+	return false
+}
+
+// filterView returns the indices of todos whose text contains query,
+// ignoring case. An empty query matches every todo.
+func filterView(todos []types.Todo, query string) []int {
+	query = strings.ToLower(query)
+	view := make([]int, 0, len(todos))
+	for i, t := range todos {
+		if query == "" || strings.Contains(strings.ToLower(t.Text), query) {
+			view = append(view, i)
+		}
+	}
+	return view
+}
+
+// viewTodos returns the todos selected by view, in view order.
+func viewTodos(todos []types.Todo, view []int) []types.Todo {
+	visible := make([]types.Todo, len(view))
+	for i, idx := range view {
+		visible[i] = todos[idx]
+	}
+	return visible
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestKeyCount(t *testing.T) {
+	var c keyCount
+	for _, k := range []string{"1", "2"} {
+		if !c.feed(k) {
+			t.Fatalf("expected %q to be consumed as a digit", k)
+		}
+	}
+	if c.feed("j") {
+		t.Fatal("motion key should not be consumed as a digit")
+	}
+	if n := c.take(); n != 12 {
+		t.Fatalf("expected count 12, got %d", n)
+	}
+	if n := c.take(); n != 0 {
+		t.Fatalf("take should reset the count, got %d", n)
+	}
+	if c.feed("0") {
+		t.Fatal("a leading 0 is not a count")
+	}
+	c.feed("1")
+	if !c.feed("0") || c.take() != 10 {
+		t.Fatal("0 after a digit should extend the count")
+	}
+}
+
+func TestMoveSelection(t *testing.T) {
+	tests := []struct {
+		selected, count int
+		key             string
+		want            int
+		ok              bool
+	}{
+		{0, 0, "j", 1, true},
+		{0, 0, "DOWN", 1, true},
+		{0, 5, "j", 5, true},
+		{8, 5, "j", 9, true}, // clamped to the last todo
+		{6, 3, "k", 3, true},
+		{2, 5, "UP", 0, true},
+		{7, 0, "g", 0, true},
+		{7, 4, "g", 3, true},
+		{2, 0, "G", 9, true},
+		{2, 40, "G", 9, true},
+		{2, 3, "x", 2, false},
+	}
+	for _, tt := range tests {
+		got, ok := moveSelection(tt.selected, 10, tt.key, tt.count)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("moveSelection(%d, 10, %q, %d) = %d, %v; want %d, %v", tt.selected, tt.key, tt.count, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestClickTarget(t *testing.T) {
+	// Todo 1 is selected and has two summary lines below it.
+	rows := []int{8, 9, 12}
+	tests := []struct {
+		row, col int
+		want     int
+		onIcon   bool
+	}{
+		{8, 5, 0, true},
+		{9, 20, 1, false},
+		{10, 20, -1, false}, // summary line of todo 1
+		{12, 6, 2, true},
+		{3, 5, -1, false}, // header
+	}
+	for _, tt := range tests {
+		got, onIcon := clickTarget(rows, tt.row, tt.col)
+		if got != tt.want || onIcon != tt.onIcon {
+			t.Errorf("clickTarget(row %d, col %d) = %d, %v; want %d, %v", tt.row, tt.col, got, onIcon, tt.want, tt.onIcon)
+		}
+	}
+}
+
+func TestFilterView(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("a", "Fix login bug"),
+		*types.NewTodo("b", "Write docs"),
+		*types.NewTodo("c", "Debug LOGIN flow"),
+	}
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"", []int{0, 1, 2}},
+		{"login", []int{0, 2}},
+		{"DOCS", []int{1}},
+		{"g l", []int{2}},
+		{"nothing", []int{}},
+	}
+	for _, tt := range tests {
+		got := filterView(todos, tt.query)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filterView(%q) = %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	visible := viewTodos(todos, []int{2, 0})
+	if len(visible) != 2 || visible[0].ID != "c" || visible[1].ID != "a" {
+		t.Errorf("viewTodos returned %v", visible)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// todoTree is the --tree layout of a list: a todo nests under the todo that
+// blocks it, so work reads top-down in the order it can be done. Indices
+// point into the listed todos and keep their order.
+type todoTree struct {
+	Roots    []int
+	Children map[int][]int
+}
+
+// treeCycleError reports blockers that depend on each other, which can't be
+// drawn as a tree.
+type treeCycleError struct {
+	IDs []string
+}
+
+func (e *treeCycleError) Error() string {
+	return fmt.Sprintf("blocker cycle between %s", strings.Join(e.IDs, " → "))
+}
+
+// buildTodoTree links todos by blockedBy and blocks, matching references by ID
+// prefix like the commands that accept short IDs. A todo with several
+// blockers nests under the one that comes first in todos; blockers outside
+// todos (e.g. hidden by a filter) are ignored, making the todo a root.
+func buildTodoTree(todos []types.Todo) (todoTree, error) {
+	resolve := func(ref string) int {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return -1
+		}
+		for i, t := range todos {
+			if strings.HasPrefix(t.ID, ref) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// edges[parent] lists every todo the parent blocks, for cycle detection.
+	edges := make(map[int][]int)
+	parent := make(map[int]int)
+	link := func(p, child int) {
+		if p < 0 || p == child {
+			return
+		}
+		edges[p] = append(edges[p], child)
+		if _, ok := parent[child]; !ok || p < parent[child] {
+			parent[child] = p
+		}
+	}
+	for i, t := range todos {
+		for _, ref := range t.BlockedBy {
+			link(resolve(ref), i)
+		}
+		for _, ref := range t.Blocks {
+			if child := resolve(ref); child >= 0 {
+				link(i, child)
+			}
+		}
+	}
+
+	if cycle := findTreeCycle(len(todos), edges); cycle != nil {
+		ids := make([]string, len(cycle))
+		for i, idx := range cycle {
+			ids[i] = shortTodoID(todos[idx].ID)
+		}
+		return todoTree{}, &treeCycleError{IDs: ids}
+	}
+
+	tree := todoTree{Children: make(map[int][]int)}
+	for i := range todos {
+		if p, ok := parent[i]; ok {
+			tree.Children[p] = append(tree.Children[p], i)
+		} else {
+			tree.Roots = append(tree.Roots, i)
+		}
+	}
+	return tree, nil
+}
+
+// findTreeCycle returns the nodes of one cycle in edges, closed by repeating
+// its first node, or nil when there is none.
+func findTreeCycle(n int, edges map[int][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, n)
+	var stack []int
+	var visit func(int) []int
+	visit = func(node int) []int {
+		state[node] = visiting
+		stack = append(stack, node)
+		for _, next := range edges[node] {
+			switch state[next] {
+			case visiting:
+				for i, s := range stack {
+					if s == next {
+						return append(append([]int{}, stack[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = done
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// printTodoTree prints one line per todo, indented under its blocker with box
+// connectors. Numbers stay those of the flat, priority-sorted list; they are
+// display numbers, not the file-order indexes commands accept, so each row
+// also shows its short ID.
+func printTodoTree(todos []types.Todo, tree todoTree, projectRoot string) {
+	var walk func(i int, prefix string, last, root bool)
+	walk = func(i int, prefix string, last, root bool) {
+		todo := todos[i]
+		connector, childPrefix := "", ""
+		if !root {
+			connector, childPrefix = "├─ ", prefix+"│  "
+			if last {
+				connector, childPrefix = "└─ ", prefix+"   "
+			}
+		}
+
+		textStyle := ""
+		if todo.Status == types.StatusDone {
+			textStyle = terminal.Dim
+		}
+		assigneePrefix := ""
+		if todo.Assignee != "" {
+			assigneePrefix = fmt.Sprintf("%s@%s %s", terminal.BrightMagenta, formatAssigneeLabel(projectRoot, todo.Assignee), terminal.Reset)
+		}
+		rowPrefix := fmt.Sprintf("  %s%s%s%s%d.%s %s%s%s %s%s%s%s",
+			terminal.Dim, prefix, connector, terminal.Reset+terminal.Dim, i+1, terminal.Reset,
+			terminal.StatusColor(string(todo.Status)), terminal.StatusIcon(string(todo.Status)), terminal.Reset,
+			priorityIndicator(todo.Priority)+idLabel(todo),
+			assigneePrefix, recurMarker(todo)+aiHintMarker(todo), textStyle)
+		fmt.Println(rowPrefix + staticTodoText(rowPrefix, todo.Text, textStyle) + terminal.Reset + listDatesSuffix(todo))
+
+		children := tree.Children[i]
+		for n, child := range children {
+			walk(child, childPrefix, n == len(children)-1, false)
+		}
+	}
+	for _, root := range tree.Roots {
+		walk(root, "", true, true)
+	}
+}
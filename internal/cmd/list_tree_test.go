@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestBuildTodoTree(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("aaa111", "design schema"),
+		*types.NewTodo("bbb222", "write migration"),
+		*types.NewTodo("ccc333", "backfill data"),
+		*types.NewTodo("ddd444", "unrelated"),
+		*types.NewTodo("eee555", "blocked by something filtered out"),
+	}
+	todos[1].BlockedBy = []string{"aaa"} // short IDs resolve by prefix
+	todos[0].Blocks = []string{"ccc333"}
+	todos[2].BlockedBy = []string{"bbb222"} // two blockers: nests under aaa111, listed first
+	todos[4].BlockedBy = []string{"zzz999"}
+
+	tree, err := buildTodoTree(todos)
+	if err != nil {
+		t.Fatalf("buildTodoTree: %v", err)
+	}
+	if !reflect.DeepEqual(tree.Roots, []int{0, 3, 4}) {
+		t.Fatalf("roots = %v", tree.Roots)
+	}
+	if !reflect.DeepEqual(tree.Children[0], []int{1, 2}) || len(tree.Children[1]) != 0 {
+		t.Fatalf("children = %v", tree.Children)
+	}
+}
+
+func TestBuildTodoTreeCycle(t *testing.T) {
+	todos := []types.Todo{
+		*types.NewTodo("aaa111", "one"),
+		*types.NewTodo("bbb222", "two"),
+		*types.NewTodo("ccc333", "three"),
+	}
+	todos[0].BlockedBy = []string{"ccc333"}
+	todos[1].BlockedBy = []string{"aaa111"}
+	todos[2].BlockedBy = []string{"bbb222"}
+
+	_, err := buildTodoTree(todos)
+	var cycle *treeCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+	if len(cycle.IDs) != 4 || cycle.IDs[0] != cycle.IDs[3] {
+		t.Fatalf("expected a closed cycle of three todos, got %v", cycle.IDs)
+	}
+}
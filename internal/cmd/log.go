@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logSince  string
+	logBranch bool
+	logJSON   bool
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recently completed todos grouped by day",
+	Long: `List completed todos (including archived ones), newest first, grouped under
+Today, Yesterday, and dated headers. The output is plain enough to paste
+into a standup note.`,
+	Example: `  todo log
+  todo log --since 7d
+  todo log --since 2026-01-01 --branch
+  todo log --json`,
+	RunE: runLog,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	logCmd.Flags().StringVar(&logSince, "since", "", "Only show todos completed within a window (24h, 7d, 2w) or since a date (YYYY-MM-DD, today, yesterday)")
+	logCmd.Flags().BoolVar(&logBranch, "branch", false, "Only show todos from the current git branch")
+	logCmd.Flags().BoolVar(&logJSON, "json", false, "Output as JSON")
+}
+
+// logDay is one group of completed todos in the log output.
+type logDay struct {
+	Label string       `json:"label"`
+	Date  string       `json:"date"`
+	Todos []types.Todo `json:"todos"`
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var since time.Time
+	if logSince != "" {
+		since, err = parsePastDateInput(logSince, now, false)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+	archived, err := storage.LoadArchive(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", err)
+	}
+	todos = append(todos, archived...)
+
+	if logBranch {
+		branch, err := git.GetCurrentBranch(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to detect current branch: %w", err)
+		}
+		todos = storage.FilterTodosByBranch(todos, branch)
+	}
+
+	done := storage.FilterTodosByStatus(todos, types.StatusDone)
+	if !since.IsZero() {
+		var recent []types.Todo
+		for _, t := range done {
+			if !logCompletedAt(t).Before(since) {
+				recent = append(recent, t)
+			}
+		}
+		done = recent
+	}
+
+	days := groupLogByDay(done, now)
+
+	if logJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"days":  days,
+			"count": len(done),
+		})
+	}
+
+	if len(days) == 0 {
+		terminal.PrintInfo("No completed todos in this window")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	for _, day := range days {
+		fmt.Printf("%s%s%s\n", terminal.Bold, day.Label, terminal.Reset)
+		for _, t := range day.Todos {
+			fmt.Printf("- %s\n", t.Text)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// groupLogByDay sorts completed todos by completion time (newest first) and
+// buckets them by local calendar day.
+func groupLogByDay(todos []types.Todo, now time.Time) []logDay {
+	sorted := make([]types.Todo, len(todos))
+	copy(sorted, todos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return logCompletedAt(sorted[i]).After(logCompletedAt(sorted[j]))
+	})
+
+	var days []logDay
+	for _, t := range sorted {
+		completed := logCompletedAt(t)
+		date := completed.In(now.Location()).Format("2006-01-02")
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, logDay{
+				Label: logDayLabel(completed, now),
+				Date:  date,
+			})
+		}
+		days[len(days)-1].Todos = append(days[len(days)-1].Todos, t)
+	}
+	return days
+}
+
+// logCompletedAt returns when a todo was completed. Todos written before
+// CompletedAt was recorded fall back to UpdatedAt.
+func logCompletedAt(t types.Todo) time.Time {
+	if t.CompletedAt != nil {
+		return *t.CompletedAt
+	}
+	return t.UpdatedAt
+}
+
+func logDayLabel(t, now time.Time) string {
+	t = t.In(now.Location())
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	switch {
+	case !t.Before(today):
+		return "Today"
+	case !t.Before(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+	case t.Year() == now.Year():
+		return t.Format("Monday, Jan 2")
+	default:
+		return t.Format("Monday, Jan 2, 2006")
+	}
+}
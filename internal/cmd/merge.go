@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeJSON          bool
+	mergeDriver        bool
+	mergeInstallDriver bool
+)
+
+// mergeDriverName is the merge driver registered by --install-driver.
+const mergeDriverName = "todo"
+
+// mergeDriverPatterns are the todo files routed through the merge driver.
+var mergeDriverPatterns = []string{
+	".todos/users/*.json",
+	".todos/todos.json",
+	".todos/archive.json",
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <theirs.json>",
+	Short: "Merge another todo file into this project by ID",
+	Long: `Union the todos of another todo file (e.g. the other side of a git conflict)
+with the project's todos, by ID:
+
+  - todos only one side has are kept
+  - todos both sides have but that differ take the one with the later updatedAt
+
+With --driver, merge file <theirs> into file <ours> in place, for use as a git
+merge driver. --install-driver registers that driver in the repository's git
+config and routes the todo files to it in .gitattributes, so conflicting todo
+changes merge by themselves.`,
+	Example: `  git show feature:.todos/users/jane-doe.json > /tmp/theirs.json
+  todo merge /tmp/theirs.json
+  todo merge --install-driver`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case mergeInstallDriver:
+			return cobra.NoArgs(cmd, args)
+		case mergeDriver:
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: runMerge,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.Flags().BoolVar(&mergeJSON, "json", false, "Output the merge summary as JSON")
+	mergeCmd.Flags().BoolVar(&mergeDriver, "driver", false, "Merge file <theirs> into file <ours> in place (git merge driver mode)")
+	mergeCmd.Flags().BoolVar(&mergeInstallDriver, "install-driver", false, "Register todo merge as the git merge driver for .todos files")
+	mergeCmd.MarkFlagsMutuallyExclusive("driver", "install-driver")
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	switch {
+	case mergeInstallDriver:
+		return runInstallMergeDriver()
+	case mergeDriver:
+		return runMergeDriver(args[0], args[1])
+	}
+
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	theirs, err := storage.ReadTodoFile(args[0])
+	if err != nil {
+		return err
+	}
+	creator, err := storage.CurrentUserSlug()
+	if err != nil {
+		return err
+	}
+	for i := range theirs {
+		if strings.TrimSpace(theirs[i].CreatedBy) == "" {
+			theirs[i].CreatedBy = creator
+		}
+	}
+
+	var merged []types.Todo
+	var result storage.MergeResult
+	err = storage.WithLock(projectRoot, func() error {
+		ours, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		merged, result = storage.MergeTodos(ours, theirs)
+		if len(result.Added) == 0 && len(result.Theirs) == 0 {
+			return nil
+		}
+		if err := storage.SaveTodos(projectRoot, merged); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if mergeJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"added":     result.Added,
+			"theirs":    result.Theirs,
+			"ours":      result.Ours,
+			"conflicts": result.Conflicts(),
+			"count":     len(merged),
+		})
+	}
+	printMergeResult(merged, result)
+	return nil
+}
+
+// runMergeDriver merges theirs into ours in place. Git invokes it with %A %B
+// and takes ours as the result; a zero exit marks the merge clean.
+func runMergeDriver(oursPath, theirsPath string) error {
+	ours, err := storage.ReadTodoFile(oursPath)
+	if err != nil {
+		return err
+	}
+	theirs, err := storage.ReadTodoFile(theirsPath)
+	if err != nil {
+		return err
+	}
+	merged, result := storage.MergeTodos(ours, theirs)
+	if err := storage.WriteTodoFile(oursPath, merged); err != nil {
+		return err
+	}
+	if result.Conflicts() > 0 {
+		fmt.Fprintf(os.Stderr, "todo merge: %d todo(s) changed on both sides, kept the newer version\n", result.Conflicts())
+	}
+	return nil
+}
+
+func runInstallMergeDriver() error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	if !git.IsGitRepoAt(projectRoot) {
+		return fmt.Errorf("%s is not inside a git repository", projectRoot)
+	}
+
+	key := "merge." + mergeDriverName
+	if err := git.SetConfig(projectRoot, key+".name", "todo-cli JSON merge by todo ID"); err != nil {
+		return err
+	}
+	if err := git.SetConfig(projectRoot, key+".driver", "todo merge --driver %A %B"); err != nil {
+		return err
+	}
+
+	attributesPath := filepath.Join(projectRoot, ".gitattributes")
+	added, err := ensureGitAttributes(attributesPath, mergeDriverPatterns, "merge="+mergeDriverName)
+	if err != nil {
+		return err
+	}
+
+	terminal.PrintSuccess("Registered the todo merge driver in .git/config")
+	if len(added) > 0 {
+		terminal.PrintSuccess(fmt.Sprintf("Added %d line(s) to %s", len(added), attributesPath))
+		for _, line := range added {
+			terminal.PrintDim(line)
+		}
+	} else {
+		terminal.PrintInfo(".gitattributes already routes the todo files to the driver")
+	}
+	terminal.PrintInfo("Commit .gitattributes; each clone runs todo merge --install-driver once")
+	fmt.Println()
+	return nil
+}
+
+// ensureGitAttributes appends "<pattern> <attribute>" for each pattern that
+// doesn't already have that line, and returns the lines added. Later lines
+// win in .gitattributes, so the new ones override earlier merge=union entries.
+func ensureGitAttributes(path string, patterns []string, attribute string) ([]string, error) {
+	existing := map[string]bool{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		existing[strings.Join(strings.Fields(scanner.Text()), " ")] = true
+	}
+
+	var added []string
+	for _, pattern := range patterns {
+		line := pattern + " " + attribute
+		if !existing[line] {
+			added = append(added, line)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += strings.Join(added, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return added, nil
+}
+
+func printMergeResult(merged []types.Todo, result storage.MergeResult) {
+	if len(result.Added) == 0 && result.Conflicts() == 0 {
+		terminal.PrintInfo("Nothing to merge; both sides already agree")
+		fmt.Println()
+		return
+	}
+
+	text := make(map[string]string, len(merged))
+	for _, t := range merged {
+		text[t.ID] = t.Text
+	}
+	terminal.PrintSuccess(fmt.Sprintf("Merged: %d added, %d conflict(s) resolved", len(result.Added), result.Conflicts()))
+	for _, id := range result.Added {
+		fmt.Printf("  %s+ %s%s %s\n", terminal.Green, shortTodoID(id), terminal.Reset, text[id])
+	}
+	for _, id := range result.Theirs {
+		fmt.Printf("  %s~ %s%s %s %s(theirs, newer)%s\n", terminal.Yellow, shortTodoID(id), terminal.Reset, text[id], terminal.Dim, terminal.Reset)
+	}
+	for _, id := range result.Ours {
+		fmt.Printf("  %s~ %s%s %s %s(ours kept)%s\n", terminal.Yellow, shortTodoID(id), terminal.Reset, text[id], terminal.Dim, terminal.Reset)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestMergeCommand(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		mergeDriver, mergeInstallDriver, mergeJSON = false, false, false
+		mergeCmd.Flags().Lookup("driver").Changed = false
+	})
+
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ours := []types.Todo{
+		{ID: "aaa111", Text: "ours stale", Status: types.StatusOpen, CreatedBy: "test-user", UpdatedAt: base},
+		{ID: "bbb222", Text: "shared", Status: types.StatusOpen, CreatedBy: "test-user", UpdatedAt: base},
+	}
+	if err := storage.SaveTodos(dir, ours); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	theirs := []types.Todo{
+		{ID: "aaa111", Text: "theirs fresh", Status: types.StatusDone, CreatedBy: "test-user", UpdatedAt: base.Add(time.Hour)},
+		{ID: "ccc333", Text: "from the branch", Status: types.StatusOpen, UpdatedAt: base},
+	}
+	theirsPath := filepath.Join(t.TempDir(), "theirs.json")
+	if err := storage.WriteTodoFile(theirsPath, theirs); err != nil {
+		t.Fatalf("write theirs: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"merge", theirsPath})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	todos, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	got := map[string]types.Todo{}
+	for _, todo := range todos {
+		got[todo.ID] = todo
+	}
+	if len(todos) != 3 || got["aaa111"].Text != "theirs fresh" || got["bbb222"].Text != "shared" ||
+		got["ccc333"].CreatedBy != "test-user" {
+		t.Fatalf("unexpected merge result: %+v", todos)
+	}
+
+	// Driver mode rewrites <ours> in place without touching the project.
+	oursPath := filepath.Join(t.TempDir(), "ours.json")
+	if err := storage.WriteTodoFile(oursPath, ours); err != nil {
+		t.Fatalf("write ours: %v", err)
+	}
+	rootCmd.SetArgs([]string{"merge", "--driver", oursPath, theirsPath})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("merge --driver: %v", err)
+	}
+	merged, err := storage.ReadTodoFile(oursPath)
+	if err != nil || len(merged) != 3 || merged[0].Text != "theirs fresh" {
+		t.Fatalf("driver result = %+v, %v", merged, err)
+	}
+}
+
+func TestMergeInstallDriver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		mergeInstallDriver = false
+		mergeCmd.Flags().Lookup("install-driver").Changed = false
+	})
+	if out, err := exec.Command("git", "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	attributes := filepath.Join(dir, ".gitattributes")
+	if err := os.WriteFile(attributes, []byte(".todos/users/*.json merge=union"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		rootCmd.SetArgs([]string{"merge", "--install-driver"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("merge --install-driver: %v", err)
+		}
+	}
+
+	out, err := exec.Command("git", "config", "merge.todo.driver").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "todo merge --driver %A %B" {
+		t.Fatalf("driver config = %q, %v", out, err)
+	}
+	data, _ := os.ReadFile(attributes)
+	want := ".todos/users/*.json merge=union\n.todos/users/*.json merge=todo\n.todos/todos.json merge=todo\n.todos/archive.json merge=todo\n"
+	if string(data) != want {
+		t.Fatalf(".gitattributes =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -5,52 +5,30 @@ import (
 	"strconv"
 	"strings"
 	"time"
-)
 
-func normalizeTags(raw []string) []string {
-	if len(raw) == 0 {
-		return nil
-	}
-	seen := make(map[string]struct{}, len(raw))
-	tags := make([]string, 0, len(raw))
-	for _, value := range raw {
-		for _, token := range strings.Split(value, ",") {
-			tag := strings.ToLower(strings.TrimSpace(token))
-			if tag == "" {
-				continue
-			}
-			if _, ok := seen[tag]; ok {
-				continue
-			}
-			seen[tag] = struct{}{}
-			tags = append(tags, tag)
-		}
-	}
-	if len(tags) == 0 {
-		return nil
-	}
-	return tags
-}
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
 
 func mergeTags(existing []string, toAdd []string) []string {
 	combined := append([]string{}, existing...)
 	combined = append(combined, toAdd...)
-	return normalizeTags(combined)
+	return storage.NormalizeTags(combined)
 }
 
 func removeTags(existing []string, toRemove []string) []string {
 	if len(existing) == 0 || len(toRemove) == 0 {
-		return normalizeTags(existing)
+		return storage.NormalizeTags(existing)
 	}
 	removals := make(map[string]struct{}, len(toRemove))
-	for _, tag := range normalizeTags(toRemove) {
+	for _, tag := range storage.NormalizeTags(toRemove) {
 		removals[tag] = struct{}{}
 	}
 	if len(removals) == 0 {
-		return normalizeTags(existing)
+		return storage.NormalizeTags(existing)
 	}
 	var out []string
-	for _, tag := range normalizeTags(existing) {
+	for _, tag := range storage.NormalizeTags(existing) {
 		if _, remove := removals[tag]; !remove {
 			out = append(out, tag)
 		}
@@ -61,75 +39,70 @@ func removeTags(existing []string, toRemove []string) []string {
 	return out
 }
 
-func parseDueDateInput(input string, now time.Time) (*time.Time, error) {
+func parseDueFilterInput(input string, now time.Time, endOfDayForDate bool) (time.Time, error) {
+	raw := strings.TrimSpace(input)
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("date filter cannot be empty")
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", raw, now.Location()); err == nil {
+		if endOfDayForDate {
+			return endOfDay(parsed), nil
+		}
+		return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+	dueAt, err := types.ParseDueDate(raw, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return *dueAt, nil
+}
+
+// parsePastDateInput parses a point in the past for log --since and list
+// --since/--until: a relative age (6h, 7d, 2w), today, yesterday, YYYY-MM-DD,
+// or RFC3339. A bare date is its start, or its end when endOfDayForDate is
+// set, so "--until 2024-06-30" includes that whole day.
+func parsePastDateInput(input string, now time.Time, endOfDayForDate bool) (time.Time, error) {
 	raw := strings.TrimSpace(strings.ToLower(input))
 	if raw == "" {
-		return nil, fmt.Errorf("due date cannot be empty")
+		return time.Time{}, fmt.Errorf("date cannot be empty")
 	}
 
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		start := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+		if endOfDayForDate {
+			return endOfDay(start)
+		}
+		return start
+	}
 	switch raw {
 	case "today":
-		due := endOfDay(now)
-		return &due, nil
-	case "tomorrow":
-		due := endOfDay(now.Add(24 * time.Hour))
-		return &due, nil
+		return day(now), nil
+	case "yesterday":
+		return day(now.AddDate(0, 0, -1)), nil
 	}
 
-	if strings.HasPrefix(raw, "+") && len(raw) > 2 {
-		amount, err := strconv.Atoi(raw[1 : len(raw)-1])
-		if err == nil && amount >= 0 {
-			unit := raw[len(raw)-1]
-			switch unit {
+	// Ages are plain digits; "+2d" is a future due date, not a past one.
+	if len(raw) > 1 && raw[0] >= '0' && raw[0] <= '9' {
+		if amount, err := strconv.Atoi(raw[:len(raw)-1]); err == nil {
+			switch raw[len(raw)-1] {
 			case 'h':
-				due := now.Add(time.Duration(amount) * time.Hour)
-				return &due, nil
+				return now.Add(-time.Duration(amount) * time.Hour), nil
 			case 'd':
-				due := endOfDay(now.Add(time.Duration(amount) * 24 * time.Hour))
-				return &due, nil
+				return now.AddDate(0, 0, -amount), nil
 			case 'w':
-				due := endOfDay(now.Add(time.Duration(amount) * 7 * 24 * time.Hour))
-				return &due, nil
+				return now.AddDate(0, 0, -7*amount), nil
 			}
 		}
 	}
 
-	if parsed, err := time.Parse(time.RFC3339, input); err == nil {
-		due := parsed
-		return &due, nil
-	}
-
-	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
-		if parsed, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
-			due := parsed
-			return &due, nil
-		}
-	}
-
-	if parsed, err := time.ParseInLocation("2006-01-02", input, now.Location()); err == nil {
-		due := endOfDay(parsed)
-		return &due, nil
-	}
-
-	return nil, fmt.Errorf("invalid due date %q (use YYYY-MM-DD, YYYY-MM-DDTHH:MM, RFC3339, today, tomorrow, +2d, +1w, or +6h)", input)
-}
-
-func parseDueFilterInput(input string, now time.Time, endOfDayForDate bool) (time.Time, error) {
-	raw := strings.TrimSpace(input)
-	if raw == "" {
-		return time.Time{}, fmt.Errorf("date filter cannot be empty")
+	if parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(input)); err == nil {
+		return parsed, nil
 	}
 	if parsed, err := time.ParseInLocation("2006-01-02", raw, now.Location()); err == nil {
-		if endOfDayForDate {
-			return endOfDay(parsed), nil
-		}
-		return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, now.Location()), nil
-	}
-	dueAt, err := parseDueDateInput(raw, now)
-	if err != nil {
-		return time.Time{}, err
+		return day(parsed), nil
 	}
-	return *dueAt, nil
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, RFC3339, today, yesterday, 6h, 7d, or 2w)", input)
 }
 
 func endOfDay(t time.Time) time.Time {
@@ -144,6 +117,24 @@ func isOverdueDueDate(dueAt *time.Time, now time.Time) bool {
 	return dueAt.Before(now)
 }
 
+// dueSoonWindow is how far ahead a due date counts as due soon.
+const dueSoonWindow = 24 * time.Hour
+
+// isDueSoon reports whether a todo is overdue or due within dueSoonWindow.
+func isDueSoon(dueAt *time.Time, now time.Time) bool {
+	return dueAt != nil && dueAt.Before(now.Add(dueSoonWindow))
+}
+
+// isDueToday reports whether dueAt falls on now's calendar day.
+func isDueToday(dueAt *time.Time, now time.Time) bool {
+	if dueAt == nil {
+		return false
+	}
+	dy, dm, dd := dueAt.In(now.Location()).Date()
+	ny, nm, nd := now.Date()
+	return dy == ny && dm == nm && dd == nd
+}
+
 func formatDueLabel(dueAt *time.Time, now time.Time) string {
 	if dueAt == nil {
 		return ""
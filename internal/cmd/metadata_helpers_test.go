@@ -5,45 +5,31 @@ import (
 	"time"
 )
 
-func TestNormalizeTags(t *testing.T) {
-	got := normalizeTags([]string{"API, backend", "api", "  ui "})
-	want := []string{"api", "backend", "ui"}
-
-	if len(got) != len(want) {
-		t.Fatalf("tag count mismatch: got %v want %v", got, want)
+func TestMergeAndRemoveTags(t *testing.T) {
+	merged := mergeTags([]string{"backend", "api"}, []string{"UI", "api,docs"})
+	want := []string{"api", "backend", "docs", "ui"}
+	if len(merged) != len(want) {
+		t.Fatalf("merge mismatch: got %v want %v", merged, want)
 	}
 	for i := range want {
-		if got[i] != want[i] {
-			t.Fatalf("tag mismatch at %d: got %q want %q", i, got[i], want[i])
+		if merged[i] != want[i] {
+			t.Fatalf("merge mismatch at %d: got %v want %v", i, merged, want)
 		}
 	}
-}
-
-func TestParseDueDateInput(t *testing.T) {
-	now := time.Date(2026, 2, 18, 10, 0, 0, 0, time.UTC)
-
-	due, err := parseDueDateInput("today", now)
-	if err != nil {
-		t.Fatalf("parse today: %v", err)
-	}
-	if due.Hour() != 23 || due.Minute() != 59 {
-		t.Fatalf("expected end-of-day for today, got %s", due.Format(time.RFC3339))
-	}
 
-	due, err = parseDueDateInput("+2d", now)
-	if err != nil {
-		t.Fatalf("parse +2d: %v", err)
+	removed := removeTags(merged, []string{"API", "docs, missing"})
+	want = []string{"backend", "ui"}
+	if len(removed) != len(want) {
+		t.Fatalf("remove mismatch: got %v want %v", removed, want)
 	}
-	if due.Day() != 20 {
-		t.Fatalf("expected day 20 for +2d, got %s", due.Format("2006-01-02"))
+	for i := range want {
+		if removed[i] != want[i] {
+			t.Fatalf("remove mismatch at %d: got %v want %v", i, removed, want)
+		}
 	}
 
-	due, err = parseDueDateInput("2026-03-01T14:30", now)
-	if err != nil {
-		t.Fatalf("parse absolute datetime: %v", err)
-	}
-	if due.Year() != 2026 || due.Month() != time.March || due.Day() != 1 || due.Hour() != 14 {
-		t.Fatalf("unexpected due result: %s", due.Format(time.RFC3339))
+	if got := removeTags([]string{"api"}, []string{"api"}); got != nil {
+		t.Fatalf("expected nil after removing all tags, got %v", got)
 	}
 }
 
@@ -66,3 +52,37 @@ func TestParseDueFilterInput_DateBoundaries(t *testing.T) {
 		t.Fatalf("expected end-of-day cutoff, got %s", beforeCutoff.Format(time.RFC3339))
 	}
 }
+
+func TestParsePastDateInput(t *testing.T) {
+	now := time.Date(2026, 2, 18, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		endOfDay bool
+		want     time.Time
+	}{
+		{"7d", false, now.AddDate(0, 0, -7)},
+		{"2w", true, now.AddDate(0, 0, -14)},
+		{"6h", false, now.Add(-6 * time.Hour)},
+		{"2026-02-01", false, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-02-01", true, time.Date(2026, 2, 1, 23, 59, 59, 0, time.UTC)},
+		{"today", false, time.Date(2026, 2, 18, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", true, time.Date(2026, 2, 17, 23, 59, 59, 0, time.UTC)},
+		{"2026-02-10T08:00:00Z", true, time.Date(2026, 2, 10, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parsePastDateInput(tt.input, now, tt.endOfDay)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.input, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Fatalf("parse %q (endOfDay=%v) = %s, want %s", tt.input, tt.endOfDay, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+		}
+	}
+
+	for _, bad := range []string{"", "soon", "-3d", "+2d"} {
+		if _, err := parsePastDateInput(bad, now, false); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
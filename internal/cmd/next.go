@@ -18,6 +18,7 @@ var (
 	nextPath     string
 	nextTags     []string
 	nextJSON     bool
+	nextAny      bool
 )
 
 var nextCmd = &cobra.Command{
@@ -25,6 +26,9 @@ var nextCmd = &cobra.Command{
 	Short: "Recommend the next todo to work on",
 	Long: `Pick the best next todo based on urgency and priority.
 
+Like focus, candidates are scoped to the current git branch (plus todos with
+no branch); use --any to consider every branch.
+
 Ranking rules:
   1. Overdue items first
   2. Then soonest due date
@@ -33,6 +37,7 @@ Ranking rules:
 	Example: `  todo next
   todo next --tag backend
   todo next --path src/auth --priority high
+  todo next --any
   todo next --json`,
 	RunE: runNext,
 }
@@ -41,27 +46,40 @@ func init() {
 	rootCmd.AddCommand(nextCmd)
 
 	nextCmd.Flags().BoolVarP(&nextAll, "all", "a", false, "Include non-done todos (open + blocked + waiting + tech-debt)")
-	nextCmd.Flags().StringVar(&nextPriority, "priority", "", "Filter by priority: low, medium, high")
+	nextCmd.Flags().StringVar(&nextPriority, "priority", "", "Filter by priority: low, medium, high (or l, m, h)")
 	nextCmd.Flags().StringVarP(&nextPath, "path", "p", "", "Filter by path prefix")
 	nextCmd.Flags().StringArrayVarP(&nextTags, "tag", "t", []string{}, "Filter by tag(s), OR matching (repeat or comma-separate)")
 	nextCmd.Flags().BoolVar(&nextJSON, "json", false, "Output result as JSON")
+	nextCmd.Flags().BoolVar(&nextAny, "any", false, "Ignore branch scoping and consider todos from every branch")
 
 	registerPathFlagCompletion(nextCmd, "path")
+	registerPriorityFlagCompletion(nextCmd, "priority")
 }
 
 func runNext(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 	Verbosef("project root: %s", projectRoot)
 
+	config, err := storage.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	todos, err := storage.LoadTodos(projectRoot)
 	if err != nil {
 		return fmt.Errorf("failed to load todos: %w", err)
 	}
 	Verbosef("loaded %d todo(s)", len(todos))
 
+	branch := ""
+	if !nextAny {
+		branch = currentFocusBranch(projectRoot, config)
+	}
+	Verbosef("branch scope: %q", branch)
+
 	candidates := make([]types.Todo, 0, len(todos))
 	for _, t := range todos {
 		if nextAll {
@@ -73,28 +91,33 @@ func runNext(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	candidates = filterTodosForBranch(candidates, branch)
+
 	if nextPath != "" {
-		candidates = storage.FilterTodosByPath(candidates, nextPath)
+		candidates = storage.FilterTodosByPath(candidates, normalizePathFilter(projectRoot, nextPath))
 	}
 	if len(nextTags) > 0 {
-		candidates = storage.FilterTodosByTags(candidates, normalizeTags(nextTags))
+		candidates = storage.FilterTodosByTags(candidates, storage.NormalizeTags(nextTags))
 	}
 	if nextPriority != "" {
-		p := types.Priority(strings.ToLower(nextPriority))
-		if !p.IsValid() {
-			return fmt.Errorf("invalid priority: %s. Use: low, medium, high", nextPriority)
+		p, err := types.ParsePriority(nextPriority)
+		if err != nil {
+			return err
 		}
 		candidates = storage.FilterTodosByPriority(candidates, p)
 	}
 
 	if len(candidates) == 0 {
 		if nextJSON {
-			payload := map[string]any{"todo": nil, "message": "No matching todo found"}
+			payload := map[string]any{"todo": nil, "message": "No matching todo found", "branch": branch}
 			enc := json.NewEncoder(cmd.OutOrStdout())
 			enc.SetIndent("", "  ")
 			return enc.Encode(payload)
 		}
 		terminal.PrintInfo("No matching todo found")
+		if branch != "" {
+			fmt.Printf("  %sScoped to branch %s; try --any%s\n", terminal.Dim, branch, terminal.Reset)
+		}
 		fmt.Println()
 		return nil
 	}
@@ -108,6 +131,7 @@ func runNext(cmd *cobra.Command, args []string) error {
 			"todo":   selected,
 			"reason": nextReason(selected, now),
 			"count":  len(candidates),
+			"branch": branch,
 		}
 		enc := json.NewEncoder(cmd.OutOrStdout())
 		enc.SetIndent("", "  ")
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openPathIndex int
+	openPrint     bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <id|index>",
+	Short: "Open a todo's file in your editor",
+	Long: `Open a path attached to a todo in $VISUAL or $EDITOR.
+
+The first path is used unless --path-index picks another (1-based). When a
+todo has several paths and no index is given, they are listed and you are
+asked to choose.
+
+Paths with a recorded line (todo add --at path:line, todo scan) open at
+that line. vim, nvim, nano, emacs and friends get +N; VS Code gets
+--goto file:line; Sublime, Zed and Helix get file:line; JetBrains IDEs get
+--line N. Other editors open the file without a line.
+
+--print writes the absolute path instead of launching an editor.`,
+	Example: `  todo open 1
+  todo open abc123 --path-index 2
+  EDITOR="code -w" todo open 2
+  cd "$(dirname "$(todo open 1 --print)")"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runOpen,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().IntVar(&openPathIndex, "path-index", 0, "Which of the todo's paths to open (1-based)")
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the absolute path instead of opening an editor")
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	todo, _, err := storage.ResolveTodo(todos, args[0])
+	if err != nil {
+		return err
+	}
+
+	targets := openTargets(todo)
+	if len(targets) == 0 {
+		return fmt.Errorf("todo %s has no paths to open", args[0])
+	}
+
+	var target types.Location
+	switch {
+	case cmd.Flags().Changed("path-index"):
+		if openPathIndex < 1 || openPathIndex > len(targets) {
+			return fmt.Errorf("invalid --path-index %d: todo has %d path(s)", openPathIndex, len(targets))
+		}
+		target = targets[openPathIndex-1]
+	case len(targets) == 1:
+		target = targets[0]
+	default:
+		options := make([]string, len(targets))
+		for i, t := range targets {
+			options[i] = t.String()
+		}
+		choice, ok := choosePrompt("This todo has several paths. Open which one?", options)
+		if !ok {
+			return fmt.Errorf("todo has %d paths: %s (choose one with --path-index)", len(targets), strings.Join(options, ", "))
+		}
+		target = targets[choice]
+	}
+
+	path := resolveTodoPath(projectRoot, target.Path)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "%s⚠ Path does not exist: %s (see 'todo doctor' for orphaned paths)%s\n", terminal.Yellow, target.Path, terminal.Reset)
+	}
+
+	if openPrint {
+		fmt.Fprintln(cmd.OutOrStdout(), path)
+		return nil
+	}
+	return launchEditor(resolveEditor(projectRoot), path, target.Line)
+}
+
+// openTargets lists a todo's paths in order, each carrying the line of its
+// first recorded location when there is one.
+func openTargets(todo *types.Todo) []types.Location {
+	targets := make([]types.Location, 0, len(todo.Context.Paths))
+	seen := make(map[string]bool)
+	for _, p := range todo.Context.Paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		loc := types.Location{Path: p}
+		for _, l := range todo.Context.Locations {
+			if l.Path == p {
+				loc.Line = l.Line
+				break
+			}
+		}
+		targets = append(targets, loc)
+	}
+	// Locations written by older versions may lack a mirrored path.
+	for _, l := range todo.Context.Locations {
+		if !seen[l.Path] {
+			seen[l.Path] = true
+			targets = append(targets, l)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var orderJSON bool
+
+var orderCmd = &cobra.Command{
+	Use:   "order <id|index>...",
+	Short: "Reorder todos",
+	Long: `Move the given todos to the front of the list, in the order given. Todos not
+named keep their relative order after them.
+
+Order is stored per owner file, so todos created by different people can't be
+interleaved; their relative order is kept per owner.`,
+	Example: `  todo order 3 1        # #3 first, then #1, then the rest
+  todo order abc123 def456 --json`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeTodoArgs,
+	RunE:              runOrder,
+}
+
+func init() {
+	rootCmd.AddCommand(orderCmd)
+	orderCmd.Flags().BoolVar(&orderJSON, "json", false, "Output the new order as JSON")
+}
+
+func runOrder(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	var saved []types.Todo
+	var ids []string
+	err = storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		// Resolve every argument up front so indices refer to the current order.
+		seen := make(map[string]bool, len(args))
+		for _, arg := range args {
+			todo, _, err := storage.ResolveTodo(todos, arg)
+			if err != nil {
+				return err
+			}
+			if seen[todo.ID] {
+				return fmt.Errorf("todo %s is listed more than once", shortTodoID(todo.ID))
+			}
+			seen[todo.ID] = true
+			ids = append(ids, todo.ID)
+		}
+
+		if err := storage.SaveTodos(projectRoot, storage.ReorderTodos(todos, ids)); err != nil {
+			return err
+		}
+		saved, err = storage.LoadTodos(projectRoot)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if orderJSON {
+		order := make([]string, len(saved))
+		for i, t := range saved {
+			order[i] = t.ID
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{"order": order})
+	}
+
+	terminal.PrintSuccess(fmt.Sprintf("Reordered %d todo(s)", len(ids)))
+	if !orderHeld(saved, ids) {
+		terminal.PrintWarning("Some todos belong to other owners; order is only kept within each owner's file")
+	}
+	for i, t := range saved {
+		fmt.Printf("  %s%3d.%s %s%s%s %s\n", terminal.Dim, i+1, terminal.Reset,
+			terminal.StatusColor(string(t.Status)), terminal.StatusIcon(string(t.Status)), terminal.Reset, t.Text)
+	}
+	fmt.Println()
+	return nil
+}
+
+// orderHeld reports whether todos lists ids first, in the requested order.
+func orderHeld(todos []types.Todo, ids []string) bool {
+	if len(todos) < len(ids) {
+		return false
+	}
+	for i, id := range ids {
+		if todos[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -6,7 +6,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -15,10 +14,42 @@ func registerPathFlagCompletion(command *cobra.Command, flagName string) {
 	_ = command.RegisterFlagCompletionFunc(flagName, completePath)
 }
 
+// registerDirFlagCompletion wires up directory-only completion for a flag
+// that names a folder.
+func registerDirFlagCompletion(command *cobra.Command, flagName string) {
+	_ = command.RegisterFlagCompletionFunc(flagName, completeDir)
+}
+
 // completePath suggests files and directories relative to the todo project root (when found)
 // so completions stay consistent even when running commands from subdirectories.
 func completePath(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	baseDir := findProjectRootOrWD()
+	completions, ok := pathCompletions(findProjectRootOrWD(), toComplete, false)
+	if !ok {
+		// Fall back to shell defaults if we can't read the directory
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDir suggests only directories. Unlike completePath it works from
+// the working directory, since flags like --project resolve relative paths
+// from there rather than from a project root.
+func completeDir(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	completions, ok := pathCompletions(wd, toComplete, true)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// pathCompletions lists the entries matching toComplete, resolved against
+// baseDir unless absolute, with directories ending in a separator. ok is
+// false when the directory can't be read.
+func pathCompletions(baseDir, toComplete string, dirsOnly bool) (completions []string, ok bool) {
 	input := expandHome(toComplete)
 
 	dirPart, prefix := splitPathInput(input)
@@ -34,16 +65,18 @@ func completePath(cmd *cobra.Command, _ []string, toComplete string) ([]string,
 
 	entries, err := os.ReadDir(searchDir)
 	if err != nil {
-		// Fall back to shell defaults if we can't read the directory
-		return nil, cobra.ShellCompDirectiveDefault
+		return nil, false
 	}
 
-	completions := make([]string, 0, len(entries))
+	completions = make([]string, 0, len(entries))
 	for _, entry := range entries {
 		name := entry.Name()
 		if prefix != "" && !strings.HasPrefix(name, prefix) {
 			continue
 		}
+		if dirsOnly && !entry.IsDir() {
+			continue
+		}
 
 		candidate := name
 		if dirPart != "" {
@@ -58,11 +91,11 @@ func completePath(cmd *cobra.Command, _ []string, toComplete string) ([]string,
 	}
 
 	sort.Strings(completions)
-	return completions, cobra.ShellCompDirectiveNoFileComp
+	return completions, true
 }
 
 func findProjectRootOrWD() string {
-	if root, err := storage.FindProjectRoot("."); err == nil {
+	if root, err := resolveProjectRoot(); err == nil {
 		return root
 	}
 
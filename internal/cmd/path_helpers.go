@@ -1,6 +1,14 @@
 package cmd
 
-import "strings"
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
 
 // normalizePaths expands comma-separated path lists and trims whitespace.
 // It preserves ordering and drops empty entries.
@@ -18,6 +26,40 @@ func normalizePaths(raw []string) []string {
 	return paths
 }
 
+// appendPaths adds paths not already present, keeping the existing order.
+func appendPaths(existing []string, add []string) []string {
+	out := append([]string{}, existing...)
+	for _, p := range normalizePaths(add) {
+		if !containsPath(out, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// removePaths drops every entry matching one of remove, keeping the order of
+// the rest.
+func removePaths(existing []string, remove []string) []string {
+	drop := normalizePaths(remove)
+	out := make([]string, 0, len(existing))
+	for _, p := range existing {
+		if !containsPath(drop, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func containsPath(paths []string, target string) bool {
+	target = filepath.Clean(target)
+	for _, p := range paths {
+		if filepath.Clean(p) == target {
+			return true
+		}
+	}
+	return false
+}
+
 // splitTrailingPaths separates trailing path-like tokens from the text when the path
 // flag was provided but Cobra left extra tokens inside the text positional.
 func splitTrailingPaths(text string, existing []string) (string, []string) {
@@ -51,6 +93,98 @@ func splitTrailingPaths(text string, existing []string) (string, []string) {
 	return newText, paths
 }
 
+// parseLocation parses a path:line reference such as src/auth.go:42.
+// The line suffix is required and must be a positive integer.
+func parseLocation(raw string) (types.Location, error) {
+	raw = strings.TrimSpace(raw)
+	idx := strings.LastIndex(raw, ":")
+	if idx <= 0 || idx == len(raw)-1 {
+		return types.Location{}, fmt.Errorf("invalid location %q. Use: path:line (e.g. src/auth.go:42)", raw)
+	}
+
+	line, err := strconv.Atoi(raw[idx+1:])
+	if err != nil || line < 1 {
+		return types.Location{}, fmt.Errorf("invalid line number in %q: must be a positive integer", raw)
+	}
+
+	path := strings.TrimSpace(raw[:idx])
+	if path == "" {
+		return types.Location{}, fmt.Errorf("invalid location %q: path is empty", raw)
+	}
+	return types.Location{Path: path, Line: line}, nil
+}
+
+// splitPathLocations separates line anchors from paths given to --path, so
+// src/auth.go:42 works like --at src/auth.go:42. The returned paths keep their
+// order with any :line suffix dropped; entries without a valid line suffix
+// (including Windows drive letters) are kept whole.
+func splitPathLocations(paths []string) ([]string, []types.Location) {
+	files := make([]string, 0, len(paths))
+	var locations []types.Location
+	for _, p := range paths {
+		if idx := strings.LastIndex(p, ":"); idx > 0 {
+			if line, err := strconv.Atoi(p[idx+1:]); err == nil && line > 0 && p[idx+1] != '+' {
+				loc := types.Location{Path: p[:idx], Line: line}
+				locations = append(locations, loc)
+				p = loc.Path
+			}
+		}
+		if !containsPath(files, p) {
+			files = append(files, p)
+		}
+	}
+	return files, locations
+}
+
+// formatTodoPaths renders a todo's paths for list output, showing each path
+// with a recorded line as path:line.
+func formatTodoPaths(ctx types.Context) string {
+	parts := make([]string, 0, len(ctx.Paths))
+	for _, p := range ctx.Paths {
+		anchored := false
+		for _, loc := range ctx.Locations {
+			if loc.Line > 0 && loc.Path == p {
+				parts = append(parts, loc.String())
+				anchored = true
+			}
+		}
+		if !anchored {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatLocations renders locations as a comma-separated path:line list.
+func formatLocations(locs []types.Location) string {
+	parts := make([]string, 0, len(locs))
+	for _, l := range locs {
+		parts = append(parts, l.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// normalizePathFilter maps a --path filter to the project-relative form used
+// in stored todos. Absolute paths (including ones reached through symlinks)
+// are made relative to the project root; "./" prefixes are dropped.
+func normalizePathFilter(projectRoot, raw string) string {
+	p := strings.TrimSpace(raw)
+	if filepath.IsAbs(p) {
+		if rel, err := storage.RelativeToRoot(projectRoot, p); err == nil && !strings.HasPrefix(rel, "..") {
+			if rel == "." {
+				return ""
+			}
+			return rel
+		}
+		return p
+	}
+	p = filepath.ToSlash(p)
+	for strings.HasPrefix(p, "./") {
+		p = strings.TrimPrefix(p, "./")
+	}
+	return p
+}
+
 func looksLikePath(token string) bool {
 	return strings.Contains(token, "/") || strings.HasPrefix(token, ".")
 }
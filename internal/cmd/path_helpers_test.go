@@ -1,6 +1,12 @@
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
 
 func TestNormalizePaths(t *testing.T) {
 	tests := []struct {
@@ -30,3 +36,69 @@ func TestNormalizePaths(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		in       string
+		wantPath string
+		wantLine int
+		wantErr  bool
+	}{
+		{"src/auth.go:42", "src/auth.go", 42, false},
+		{" internal/api/server.go:7 ", "internal/api/server.go", 7, false},
+		{"C:/code/main.go:3", "C:/code/main.go", 3, false},
+		{"src/auth.go", "", 0, true},
+		{"src/auth.go:", "", 0, true},
+		{":42", "", 0, true},
+		{"src/auth.go:0", "", 0, true},
+		{"src/auth.go:abc", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseLocation(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Path != tt.wantPath || got.Line != tt.wantLine {
+				t.Fatalf("got %+v want %s:%d", got, tt.wantPath, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestSplitPathLocations(t *testing.T) {
+	paths, locs := splitPathLocations([]string{"src/auth.go:42", "docs", "C:/code/main.go", "src/auth.go:7", "notes:+3", "odd:"})
+	if want := []string{"src/auth.go", "docs", "C:/code/main.go", "notes:+3", "odd:"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	want := []types.Location{{Path: "src/auth.go", Line: 42}, {Path: "src/auth.go", Line: 7}}
+	if !reflect.DeepEqual(locs, want) {
+		t.Fatalf("locations = %v, want %v", locs, want)
+	}
+
+	ctx := types.Context{Paths: paths[:2], Locations: locs}
+	if got := formatTodoPaths(ctx); got != "src/auth.go:42, src/auth.go:7, docs" {
+		t.Fatalf("formatTodoPaths = %q", got)
+	}
+}
+
+func TestAppendAndRemovePaths(t *testing.T) {
+	got := appendPaths([]string{"src", "docs"}, []string{"internal/api,src/", "README.md"})
+	want := []string{"src", "docs", "internal/api", "README.md"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("appendPaths = %v, want %v", got, want)
+	}
+
+	got = removePaths(got, []string{"docs,./README.md"})
+	want = []string{"src", "internal/api"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("removePaths = %v, want %v", got, want)
+	}
+}
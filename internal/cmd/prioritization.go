@@ -42,6 +42,19 @@ func sortTodosForExecution(todos []types.Todo, now time.Time) {
 	})
 }
 
+// partitionDueSoon moves overdue and due-soon todos to the front, keeping the
+// relative order within both parts, and returns how many are due soon.
+func partitionDueSoon(todos []types.Todo, now time.Time) int {
+	sort.SliceStable(todos, func(i, j int) bool {
+		return isDueSoon(todos[i].DueAt, now) && !isDueSoon(todos[j].DueAt, now)
+	})
+	n := 0
+	for n < len(todos) && isDueSoon(todos[n].DueAt, now) {
+		n++
+	}
+	return n
+}
+
 func priorityWeight(p types.Priority) int {
 	if !p.IsValid() {
 		return types.PriorityMedium.PriorityWeight()
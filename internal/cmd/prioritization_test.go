@@ -61,3 +61,37 @@ func TestNextReason(t *testing.T) {
 		t.Fatalf("expected 'high priority' in reason, got: %q", reason)
 	}
 }
+
+func TestPartitionDueSoon(t *testing.T) {
+	now := time.Date(2026, 2, 18, 10, 0, 0, 0, time.UTC)
+	overdue := now.Add(-time.Hour)
+	tonight := now.Add(8 * time.Hour)
+	tomorrow := now.Add(20 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	todos := []types.Todo{
+		{ID: "undated"},
+		{ID: "next-week", DueAt: &nextWeek},
+		{ID: "tomorrow", DueAt: &tomorrow},
+		{ID: "overdue", DueAt: &overdue},
+		{ID: "tonight", DueAt: &tonight},
+	}
+	n := partitionDueSoon(todos, now)
+	if n != 3 {
+		t.Fatalf("expected 3 due soon, got %d", n)
+	}
+	var ids []string
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+	}
+	if got, want := strings.Join(ids, ","), "tomorrow,overdue,tonight,undated,next-week"; got != want {
+		t.Fatalf("order = %s; want %s", got, want)
+	}
+
+	if !isDueToday(&tonight, now) || isDueToday(&tomorrow, now) {
+		t.Fatal("isDueToday should match only the current calendar day")
+	}
+	if partitionDueSoon([]types.Todo{{ID: "a"}, {ID: "b"}}, now) != 0 {
+		t.Fatal("expected no due-soon todos without due dates")
+	}
+}
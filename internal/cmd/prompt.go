@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var promptBranch bool
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print a compact todo summary for shell prompts",
+	Long: `Print open and blocked counts for the current project as a short segment
+such as "●3 ✗1", suitable for PS1 or a starship custom module.
+
+Outside a todo project (or when there is nothing open) it prints nothing and
+exits 0, so it is safe to embed unconditionally. Colors stay on even when
+the output is captured; pass --no-color or set NO_COLOR to disable them.
+
+It only reads the todo files: config.json (theme, custom statuses) is
+skipped unless --branch needs it, so the default colors are used.`,
+	Example: `  todo prompt
+  todo prompt --branch
+  todo prompt --no-color`,
+	Args: cobra.NoArgs,
+	RunE: runPrompt,
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+	promptCmd.Flags().BoolVar(&promptBranch, "branch", false, "Only count todos for the current git branch (slower: reads config and git)")
+}
+
+func runPrompt(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return nil
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		Verbosef("prompt: %v", err)
+		return nil
+	}
+
+	if promptBranch {
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			Verbosef("prompt: %v", err)
+			return nil
+		}
+		todos = filterTodosForBranch(todos, currentFocusBranch(projectRoot, config))
+	}
+
+	// Prompt output is usually captured by the shell, so only the explicit
+	// opt-outs disable color here.
+	_, noColorEnv := os.LookupEnv("NO_COLOR")
+	terminal.SetColorsEnabled(!noColor && !noColorEnv)
+
+	if segment := promptSegment(todos); segment != "" {
+		fmt.Fprintln(cmd.OutOrStdout(), segment)
+	}
+	return nil
+}
+
+// promptSegment renders the open/blocked counts, omitting zero counts.
+func promptSegment(todos []types.Todo) string {
+	open, blocked := 0, 0
+	for _, t := range todos {
+		switch t.Status {
+		case types.StatusOpen:
+			open++
+		case types.StatusBlocked:
+			blocked++
+		}
+	}
+
+	var parts []string
+	if open > 0 {
+		parts = append(parts, fmt.Sprintf("%s●%d%s", terminal.StatusColor(string(types.StatusOpen)), open, terminal.Reset))
+	}
+	if blocked > 0 {
+		parts = append(parts, fmt.Sprintf("%s✗%d%s", terminal.StatusColor(string(types.StatusBlocked)), blocked, terminal.Reset))
+	}
+	return strings.Join(parts, " ")
+}
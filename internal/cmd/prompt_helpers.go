@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+)
+
+// confirmPrompt asks a yes/no question on stdin and reports whether the user
+// answered yes. It returns false without prompting when stdin is not a terminal
+// or interaction is turned off (--no-interactive, TODO_NONINTERACTIVE).
+func confirmPrompt(question string) bool {
+	if !terminal.ShouldInteract() {
+		return false
+	}
+
+	fmt.Printf("  %s %s[y/N]%s ", question, terminal.Dim, terminal.Reset)
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// choosePrompt lists numbered options on stderr and reads a choice from stdin.
+// It returns the 0-based index, or false when stdin is not a terminal,
+// interaction is turned off, or the answer is not a valid option. Prompting
+// on stderr keeps stdout clean for commands whose output is captured by the
+// shell.
+func choosePrompt(question string, options []string) (int, bool) {
+	if !terminal.ShouldInteract() {
+		return 0, false
+	}
+
+	fmt.Fprintf(os.Stderr, "  %s\n", question)
+	for i, opt := range options {
+		fmt.Fprintf(os.Stderr, "    %s%d.%s %s\n", terminal.BrightCyan, i+1, terminal.Reset, opt)
+	}
+	fmt.Fprintf(os.Stderr, "  %s[1-%d]%s ", terminal.Dim, len(options), terminal.Reset)
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(options) {
+		return 0, false
+	}
+	return n - 1, true
+}
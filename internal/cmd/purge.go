@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeOrphanedPaths bool
+	purgeDryRun        bool
+	purgeJSON          bool
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge --orphaned-paths",
+	Short: "Strip paths that no longer exist from todos",
+	Long: `Remove references to files and directories that no longer exist from every
+todo, leaving the todos themselves alone. Unlike todo doctor --fix, nothing
+else changes: empty and duplicate todos are kept.
+
+Paths matched by .todosignore are kept, as in todo doctor. --dry-run lists
+what would be removed without saving.`,
+	Example: `  todo purge --orphaned-paths --dry-run
+  todo purge --orphaned-paths`,
+	Args: cobra.NoArgs,
+	RunE: runPurge,
+}
+
+func init() {
+	rootCmd.AddCommand(purgeCmd)
+	purgeCmd.Flags().BoolVar(&purgeOrphanedPaths, "orphaned-paths", false, "Remove paths that no longer exist")
+	purgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "List what would be removed without saving")
+	purgeCmd.Flags().BoolVar(&purgeJSON, "json", false, "Output the removed paths as JSON")
+}
+
+// purgedTodo is one todo that lost paths to a purge.
+type purgedTodo struct {
+	ID    string   `json:"id"`
+	Text  string   `json:"text"`
+	Paths []string `json:"paths"`
+}
+
+func runPurge(cmd *cobra.Command, args []string) error {
+	if !purgeOrphanedPaths {
+		return fmt.Errorf("nothing to purge; pass --orphaned-paths")
+	}
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	ignore, err := storage.LoadIgnorePatterns(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", storage.IgnoreFile, err)
+	}
+
+	var purged []purgedTodo
+	err = storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		purged = purgeOrphanedTodoPaths(todos, projectRoot, ignore, time.Now())
+		if len(purged) == 0 || purgeDryRun {
+			return nil
+		}
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, p := range purged {
+		removed += len(p.Paths)
+	}
+
+	if purgeJSON {
+		if purged == nil {
+			purged = []purgedTodo{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"todos":   purged,
+			"removed": removed,
+			"dryRun":  purgeDryRun,
+		})
+	}
+
+	if len(purged) == 0 {
+		terminal.PrintSuccess("No orphaned paths found")
+		terminal.PrintBlank()
+		return nil
+	}
+	for _, p := range purged {
+		fmt.Printf("  %s%s%s %s\n", terminal.BrightCyan, shortTodoID(p.ID), terminal.Reset, p.Text)
+		for _, path := range p.Paths {
+			fmt.Printf("    %s− %s%s\n", terminal.Red, path, terminal.Reset)
+		}
+	}
+	fmt.Println()
+	if purgeDryRun {
+		terminal.PrintInfo(fmt.Sprintf("Would remove %d orphaned path(s) from %d todo(s); run without --dry-run to apply", removed, len(purged)))
+	} else {
+		terminal.PrintSuccess(fmt.Sprintf("Removed %d orphaned path(s) from %d todo(s)", removed, len(purged)))
+	}
+	terminal.PrintBlank()
+	return nil
+}
+
+// purgeOrphanedTodoPaths strips the paths checkOrphanedPaths reports from
+// todos in place and returns what each affected todo lost, in list order.
+func purgeOrphanedTodoPaths(todos []types.Todo, projectRoot string, ignore *storage.IgnoreMatcher, now time.Time) []purgedTodo {
+	orphaned, _, _ := checkOrphanedPaths(todos, projectRoot, ignore)
+	if len(orphaned) == 0 {
+		return nil
+	}
+	affected := make(map[string]bool, len(orphaned))
+	for _, todo := range orphaned {
+		affected[todo.ID] = true
+	}
+
+	var purged []purgedTodo
+	for i := range todos {
+		todo := &todos[i]
+		if !affected[todo.ID] {
+			continue
+		}
+		kept := []string{}
+		var removed []string
+		for _, path := range todo.Context.Paths {
+			if isOrphanedPath(projectRoot, path, ignore) {
+				removed = append(removed, path)
+			} else {
+				kept = append(kept, path)
+			}
+		}
+		if len(removed) == 0 {
+			continue
+		}
+		todo.Context.Paths = kept
+		todo.UpdatedAt = now
+		purged = append(purged, purgedTodo{ID: todo.ID, Text: todo.Text, Paths: removed})
+	}
+	return purged
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestPurgeOrphanedPaths(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		purgeOrphanedPaths, purgeDryRun, purgeJSON = false, false, false
+		rootCmd.SetOut(nil)
+	})
+	if err := os.WriteFile(filepath.Join(dir, "keep.go"), []byte("package x"), 0644); err != nil {
+		t.Fatalf("setup file: %v", err)
+	}
+
+	todos := []types.Todo{
+		*types.NewTodo("aaa111", "mixed paths"),
+		*types.NewTodo("bbb222", "duplicate"),
+		*types.NewTodo("ccc333", "duplicate"),
+		*types.NewTodo("ddd444", ""),
+	}
+	todos[0].Context.Paths = []string{"keep.go", "gone.go", "gone/dir"}
+	todos[2].Context.Paths = []string{"missing.go"}
+	for i := range todos {
+		todos[i].CreatedBy = "test-user"
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	purge := func(args ...string) (result struct {
+		Todos   []purgedTodo `json:"todos"`
+		Removed int          `json:"removed"`
+	}) {
+		t.Helper()
+		purgeOrphanedPaths, purgeDryRun, purgeJSON = false, false, false
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"purge", "--orphaned-paths", "--json"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("purge %v: %v", args, err)
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("parse: %v\n%s", err, buf.String())
+		}
+		return result
+	}
+
+	dry := purge("--dry-run")
+	if dry.Removed != 3 || len(dry.Todos) != 2 {
+		t.Fatalf("dry run = %+v", dry)
+	}
+	if !reflect.DeepEqual(dry.Todos[0].Paths, []string{"gone.go", "gone/dir"}) || dry.Todos[1].ID != "ccc333" {
+		t.Fatalf("dry run todos = %+v", dry.Todos)
+	}
+	loaded, err := storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded[0].Context.Paths) != 3 {
+		t.Fatalf("--dry-run should not save, paths = %v", loaded[0].Context.Paths)
+	}
+
+	if got := purge(); got.Removed != 3 {
+		t.Fatalf("purge = %+v", got)
+	}
+	loaded, err = storage.LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 4 {
+		t.Fatalf("purge must keep every todo, got %d", len(loaded))
+	}
+	if !reflect.DeepEqual(loaded[0].Context.Paths, []string{"keep.go"}) || len(loaded[2].Context.Paths) != 0 {
+		t.Fatalf("paths after purge = %v, %v", loaded[0].Context.Paths, loaded[2].Context.Paths)
+	}
+	if got := purge(); got.Removed != 0 || len(got.Todos) != 0 {
+		t.Fatalf("second purge = %+v, want nothing left", got)
+	}
+
+	purgeOrphanedPaths = false
+	rootCmd.SetArgs([]string{"purge"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected purge without --orphaned-paths to fail")
+	}
+}
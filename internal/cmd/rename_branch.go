@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renameBranchCurrent bool
+	renameBranchDryRun  bool
+)
+
+var renameBranchCmd = &cobra.Command{
+	Use:   "rename-branch <old> [new]",
+	Short: "Move todos from a renamed git branch to its new name",
+	Long: `Rewrite the branch context of every todo recorded on <old> to [new], so
+they show up again in focus and next after a git branch rename.
+
+With --current the new name is the branch you have checked out.`,
+	Example: `  todo rename-branch feature/auth feature/login
+  git branch -m feat-x feature/x && todo rename-branch feat-x --current
+  todo rename-branch old-name new-name --dry-run`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if renameBranchCurrent {
+			if len(args) != 1 {
+				return fmt.Errorf("--current takes only the old branch name")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: runRenameBranch,
+}
+
+func init() {
+	rootCmd.AddCommand(renameBranchCmd)
+	renameBranchCmd.Flags().BoolVar(&renameBranchCurrent, "current", false, "Use the current git branch as the new name")
+	renameBranchCmd.Flags().BoolVar(&renameBranchDryRun, "dry-run", false, "Show how many todos would change without saving")
+}
+
+// rebranch points every todo on branch from at branch to and returns how many
+// todos changed.
+func rebranch(todos []types.Todo, from, to string) int {
+	now := time.Now()
+	changed := 0
+	for i := range todos {
+		if todos[i].Context.Branch != from {
+			continue
+		}
+		todos[i].Context.Branch = to
+		todos[i].UpdatedAt = now
+		changed++
+	}
+	return changed
+}
+
+func runRenameBranch(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	from := strings.TrimSpace(args[0])
+	var to string
+	if renameBranchCurrent {
+		branch, err := git.GetCurrentBranch(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to read the current git branch: %w", err)
+		}
+		to = branch
+	} else {
+		to = strings.TrimSpace(args[1])
+	}
+	if from == "" || to == "" {
+		return fmt.Errorf("branch names cannot be empty")
+	}
+	if from == to {
+		return fmt.Errorf("old and new branch are the same: %s", from)
+	}
+
+	return storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		changed := rebranch(todos, from, to)
+		if changed == 0 {
+			terminal.PrintInfo(fmt.Sprintf("No todos on branch %s", from))
+			fmt.Println()
+			return nil
+		}
+
+		if renameBranchDryRun {
+			terminal.PrintInfo(fmt.Sprintf("Would move %d todo(s) from %s → %s (dry run)", changed, from, to))
+			fmt.Println()
+			return nil
+		}
+
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		terminal.PrintSuccess(fmt.Sprintf("Moved %d todo(s) from %s → %s", changed, from, to))
+		fmt.Println()
+		return nil
+	})
+}
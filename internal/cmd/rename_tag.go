@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var renameTagDryRun bool
+
+var renameTagCmd = &cobra.Command{
+	Use:   "rename-tag <old> <new>",
+	Short: "Rename a tag across all todos",
+	Long: `Replace a tag with another on every todo that has it.
+
+Todos that already carry the new tag keep a single copy. Both names are
+normalized like any other tag (lowercased, trimmed).`,
+	Example: `  todo rename-tag ui frontend
+  todo rename-tag bugs bug --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameTag,
+}
+
+func init() {
+	rootCmd.AddCommand(renameTagCmd)
+	renameTagCmd.Flags().BoolVar(&renameTagDryRun, "dry-run", false, "Show how many todos would change without saving")
+}
+
+// singleTag normalizes a tag argument, rejecting empty or comma-separated input.
+func singleTag(raw string) (string, error) {
+	tags := storage.NormalizeTags([]string{raw})
+	if len(tags) != 1 {
+		return "", fmt.Errorf("invalid tag %q: expected a single tag name", raw)
+	}
+	return tags[0], nil
+}
+
+// retag replaces (or, when to is empty, removes) tag from on every todo and
+// returns how many todos changed.
+func retag(todos []types.Todo, from, to string) int {
+	now := time.Now()
+	changed := 0
+	for i := range todos {
+		if !hasTag(todos[i].Tags, from) {
+			continue
+		}
+		tags := removeTags(todos[i].Tags, []string{from})
+		if to != "" {
+			tags = mergeTags(tags, []string{to})
+		}
+		todos[i].Tags = tags
+		todos[i].UpdatedAt = now
+		changed++
+	}
+	return changed
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func runRenameTag(cmd *cobra.Command, args []string) error {
+	from, err := singleTag(args[0])
+	if err != nil {
+		return err
+	}
+	to, err := singleTag(args[1])
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("old and new tag are the same: %s", from)
+	}
+
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	return storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		changed := retag(todos, from, to)
+		if changed == 0 {
+			terminal.PrintInfo(fmt.Sprintf("No todos tagged #%s", from))
+			fmt.Println()
+			return nil
+		}
+
+		if renameTagDryRun {
+			terminal.PrintInfo(fmt.Sprintf("Would rename #%s → #%s on %d todo(s) (dry run)", from, to, changed))
+			fmt.Println()
+			return nil
+		}
+
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		terminal.PrintSuccess(fmt.Sprintf("Renamed #%s → #%s on %d todo(s)", from, to, changed))
+		fmt.Println()
+		return nil
+	})
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// warnUnresolved prints a one-line warning for an argument that did not
+// resolve to a single todo. Ambiguous prefixes list each candidate so the
+// user can pick a longer prefix.
+func warnUnresolved(todos []types.Todo, idOrIndex string, err error) {
+	var ambiguous *types.AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		terminal.PrintWarning(fmt.Sprintf("Not found: %s", idOrIndex))
+		return
+	}
+	terminal.PrintWarning(fmt.Sprintf("Ambiguous: %s matches %d todos — use a longer ID", idOrIndex, len(ambiguous.Matches)))
+	for _, id := range ambiguous.Matches {
+		text := ""
+		if todo, _ := storage.FindTodoByID(todos, id); todo != nil {
+			text = todo.Text
+		}
+		fmt.Printf("     %s%s%s  %s\n", terminal.BrightCyan, id, terminal.Reset, text)
+	}
+}
+
+// shortTodoID trims an ID to the 8 characters shown in lists.
+func shortTodoID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
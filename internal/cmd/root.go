@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +18,14 @@ var (
 )
 
 // Global flags
-var verbose bool
+var (
+	verbose       bool
+	noHyperlinks  bool
+	noColor       bool
+	quiet         bool
+	noInteractive bool
+	projectDir    string
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -71,11 +81,103 @@ func Execute() {
 func init() {
 	rootCmd.SetVersionTemplate(versionTemplate())
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().BoolVar(&noHyperlinks, "no-hyperlinks", false, "Print commit hashes as plain text instead of terminal hyperlinks")
+
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Print only essentials (e.g. the new todo's ID); errors still go to stderr")
+	rootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false, "Never prompt, open an editor, or start the interactive list (also honors TODO_NONINTERACTIVE=1)")
+	rootCmd.PersistentFlags().StringVar(&projectDir, "project", "", "Use the todo project containing this directory instead of the working directory")
+	registerDirFlagCompletion(rootCmd, "project")
+
+	cobra.OnInitialize(func() {
+		terminal.HyperlinksEnabled = !noHyperlinks
+		terminal.SetColorsEnabled(!noColor && terminal.ShouldUseColor())
+		terminal.SetQuiet(quiet)
+		terminal.SetNonInteractive(noInteractive)
+	})
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		loadProjectSettings(cmd)
+	}
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.BashCompletionFunction = bashCompletionFallback
 }
 
+// resolveProjectRoot finds the project a command works on: the one containing
+// --project when it is set, else the one containing the working directory.
+func resolveProjectRoot() (string, error) {
+	if projectDir == "" {
+		return storage.FindProjectRoot(".")
+	}
+	info, err := os.Stat(projectDir)
+	if err != nil {
+		return "", fmt.Errorf("--project %s: %w", projectDir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--project %s is not a directory", projectDir)
+	}
+	root, err := storage.FindProjectRoot(projectDir)
+	if err != nil {
+		return "", fmt.Errorf("no todo project at or above %s (run 'todo init' there first)", projectDir)
+	}
+	return root, nil
+}
+
+// loadProjectSettings applies the theme and custom statuses from the
+// project's config.json before a command runs. todo prompt runs on every
+// shell prompt, so it skips config.json and keeps the defaults.
+func loadProjectSettings(cmd *cobra.Command) {
+	if cmd == promptCmd {
+		_ = terminal.SetTheme("")
+		types.SetCustomStatuses(nil)
+		return
+	}
+	loadTheme(cmd)
+	loadCustomStatuses()
+}
+
+// loadCustomStatuses registers the current project's custom statuses so
+// validation, colors, and icons know about them. Outside a project only the
+// built-ins exist; an invalid set is reported by 'todo doctor'.
+func loadCustomStatuses() {
+	types.SetCustomStatuses(nil)
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return
+	}
+	if err := storage.RegisterCustomStatuses(projectRoot); err != nil {
+		Verbosef("ignoring custom statuses: %v", err)
+	}
+}
+
+// loadTheme applies the project's color theme. --no-color and NO_COLOR still
+// win, since SetColorsEnabled blanks whatever palette is active.
+func loadTheme(cmd *cobra.Command) {
+	theme := ""
+	if projectRoot, err := resolveProjectRoot(); err == nil {
+		if cfg, err := storage.LoadConfig(projectRoot); err == nil {
+			theme = cfg.Theme
+			if msg := storage.ConfigWarning(cfg); msg != "" {
+				warnConfig(cmd, msg)
+			}
+		}
+	}
+	if err := terminal.SetTheme(theme); err != nil {
+		Verbosef("ignoring theme: %v", err)
+		_ = terminal.SetTheme("")
+	}
+}
+
+// warnConfig prints a config.json warning. JSON output goes to stdout, so
+// with --json the warning goes to stderr to keep the output parseable.
+func warnConfig(cmd *cobra.Command, msg string) {
+	if f := cmd.Flags().Lookup("json"); f != nil && f.Value.String() == "true" {
+		fmt.Fprintf(os.Stderr, "%s⚠ %s%s\n", terminal.BrightYellow, msg, terminal.Reset)
+		return
+	}
+	terminal.PrintWarning(msg)
+}
+
 // IsVerbose returns whether verbose mode is enabled
 func IsVerbose() bool {
 	return verbose
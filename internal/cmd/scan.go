@@ -27,7 +27,7 @@ var scanCmd = &cobra.Command{
 	Long: `Scan source files for TODO and FIXME comments and create todos from them.
 
 Skips binary files, .git directories, node_modules, and vendor folders.
-Each comment becomes a todo with the file path attached as context.
+Each comment becomes a todo with the file path and line attached as context.
 Duplicate text+path combinations are skipped.`,
 	Example: `  todo scan                         # Scan current directory
   todo scan src/                    # Scan specific directory
@@ -54,7 +54,7 @@ type scanResult struct {
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -86,8 +86,8 @@ func runScan(cmd *cobra.Command, args []string) error {
 				return nil
 			}
 
-			relPath, _ := filepath.Rel(projectRoot, path)
-			if relPath == "" {
+			relPath, err := storage.RelativeToRoot(projectRoot, path)
+			if err != nil || relPath == "" {
 				relPath = path
 			}
 
@@ -152,8 +152,8 @@ func runScan(cmd *cobra.Command, args []string) error {
 			if err := storage.ApplyCreator(todo); err != nil {
 				return err
 			}
-			todo.SetPaths([]string{r.File})
-			todo.Meta.Source = "scan"
+			todo.AddLocation(types.Location{Path: r.File, Line: r.Line})
+			todo.Meta.Source = types.SourceScan
 			if scanTag != "" {
 				todo.Tags = []string{strings.ToLower(strings.TrimSpace(scanTag))}
 			}
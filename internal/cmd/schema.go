@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of todo files",
+	Long: `Print a JSON Schema (draft 2020-12) describing the todo file format: the
+files under .todos/users/, archive.json, and snapshots. Use it to validate
+todo files produced by other tools before committing them.
+
+Statuses are the built-in ones or any lowercase custom status name; the
+schema can't know which custom statuses a project declares.`,
+	Example: `  todo schema > todo-file.schema.json
+  check-jsonschema --schemafile todo-file.schema.json .todos/users/*.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(types.TodoFileSchema())
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -41,6 +41,7 @@ func init() {
 	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output as JSON")
 
 	registerPathFlagCompletion(searchCmd, "path")
+	registerStatusFlagCompletion(searchCmd, "status")
 }
 
 func matchesQuery(todo types.Todo, query string) bool {
@@ -66,7 +67,7 @@ func matchesQuery(todo types.Todo, query string) bool {
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -97,10 +98,10 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		results = storage.FilterTodosByStatus(results, status)
 	}
 	if searchPath != "" {
-		results = storage.FilterTodosByPath(results, searchPath)
+		results = storage.FilterTodosByPath(results, normalizePathFilter(projectRoot, searchPath))
 	}
 	if len(searchTags) > 0 {
-		results = storage.FilterTodosByTags(results, normalizeTags(searchTags))
+		results = storage.FilterTodosByTags(results, storage.NormalizeTags(searchTags))
 	}
 
 	storage.SortTodosByPriority(results)
@@ -8,7 +8,6 @@ import (
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
-	"github.com/bagadi-alnour/todo-cli/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -21,8 +20,9 @@ var showCmd = &cobra.Command{
 	Example: `  todo show 1
   todo show abc123
   todo show 1 --json`,
-	Args: cobra.ExactArgs(1),
-	RunE: runShow,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSingleTodoArg,
+	RunE:              runShow,
 }
 
 func init() {
@@ -31,7 +31,7 @@ func init() {
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -41,9 +41,9 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load todos: %w", err)
 	}
 
-	todo, _ := storage.FindTodoByIDOrIndex(todos, args[0])
-	if todo == nil {
-		return &types.TodoNotFoundError{ID: args[0]}
+	todo, _, err := storage.ResolveTodo(todos, args[0])
+	if err != nil {
+		return err
 	}
 
 	if showJSON {
@@ -68,10 +68,22 @@ func runShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  %sID:%s       %s\n", terminal.Dim, terminal.Reset, shortID)
 	fmt.Printf("  %sStatus:%s   %s\n", terminal.Dim, terminal.Reset, todo.Status)
 	fmt.Printf("  %sPriority:%s %s\n", terminal.Dim, terminal.Reset, todo.Priority)
+	if todo.Estimate > 0 {
+		fmt.Printf("  %sEstimate:%s %d pts\n", terminal.Dim, terminal.Reset, todo.Estimate)
+	}
 
+	if todo.Assignee != "" {
+		fmt.Printf("  %sAssignee:%s %s\n", terminal.Dim, terminal.Reset, formatAssigneeLabel(projectRoot, todo.Assignee))
+	}
+	if todo.Author != "" {
+		fmt.Printf("  %sAuthor:%s   %s\n", terminal.Dim, terminal.Reset, todo.Author)
+	}
 	if todo.Notes != "" {
 		fmt.Printf("  %sNotes:%s    %s\n", terminal.Dim, terminal.Reset, todo.Notes)
 	}
+	if todo.Meta.AIHint != "" {
+		fmt.Printf("  %sAI hint:%s  %s\n", terminal.Dim, terminal.Reset, todo.Meta.AIHint)
+	}
 	if len(todo.Tags) > 0 {
 		fmt.Printf("  %sTags:%s     %s\n", terminal.Dim, terminal.Reset, strings.Join(todo.Tags, ", "))
 	}
@@ -85,11 +97,14 @@ func runShow(cmd *cobra.Command, args []string) error {
 	if len(todo.Context.Paths) > 0 {
 		fmt.Printf("  %sPaths:%s    %s\n", terminal.Dim, terminal.Reset, strings.Join(todo.Context.Paths, ", "))
 	}
+	if len(todo.Context.Locations) > 0 {
+		fmt.Printf("  %sAt:%s       %s\n", terminal.Dim, terminal.Reset, formatLocations(todo.Context.Locations))
+	}
 	if todo.Context.Branch != "" {
 		fmt.Printf("  %sBranch:%s   %s\n", terminal.Dim, terminal.Reset, todo.Context.Branch)
 	}
 	if todo.Context.Commit != "" {
-		fmt.Printf("  %sCommit:%s   %s\n", terminal.Dim, terminal.Reset, todo.Context.Commit)
+		fmt.Printf("  %sCommit:%s   %s\n", terminal.Dim, terminal.Reset, formatCommitLink(projectRoot, todo.Context.Commit))
 	}
 	if len(todo.BlockedBy) > 0 {
 		fmt.Printf("  %sBlocked by:%s %s\n", terminal.Dim, terminal.Reset, strings.Join(todo.BlockedBy, ", "))
@@ -100,6 +115,9 @@ func runShow(cmd *cobra.Command, args []string) error {
 	if todo.Recur != "" {
 		fmt.Printf("  %sRecur:%s    %s\n", terminal.Dim, terminal.Reset, todo.Recur)
 	}
+	if label := trackedTimeLabel(*todo, now); label != "" {
+		fmt.Printf("  %sTime:%s     %s\n", terminal.Dim, terminal.Reset, label)
+	}
 
 	fmt.Printf("  %sCreated:%s  %s\n", terminal.Dim, terminal.Reset, todo.CreatedAt.Format(time.RFC3339))
 	fmt.Printf("  %sUpdated:%s  %s\n", terminal.Dim, terminal.Reset, todo.UpdatedAt.Format(time.RFC3339))
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotList  bool
+	snapshotForce bool
+	snapshotJSON  bool
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <name>",
+	Short: "Save the todo list as a named checkpoint",
+	Long: `Save every todo as a named checkpoint in .todos/snapshots/<name>.json, e.g.
+before a bulk edit or import. todo restore <name> brings the list back.
+
+Names use letters, digits, '-', '_', and '.'. An existing snapshot is only
+replaced with --force. --list shows the saved snapshots, oldest first.
+
+Only the newest historyLimit snapshots (config.json, default 20) are kept;
+older ones are deleted after each save. A historyLimit of 0 turns snapshots
+off.`,
+	Example: `  todo snapshot before-import
+  todo snapshot --list
+  todo restore before-import`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if snapshotList {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeSnapshotNames,
+	RunE:              runSnapshot,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Replace the todo list with a named snapshot",
+	Long: `Replace every todo with the ones saved by todo snapshot <name>.
+
+The current list is saved as the "before-restore" snapshot first, so
+todo restore before-restore undoes the last restore, unless historyLimit is 0
+in config.json.`,
+	Example: `  todo restore before-import
+  todo restore before-restore   # undo the last restore`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSnapshotNames,
+	RunE:              runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(restoreCmd)
+	snapshotCmd.Flags().BoolVar(&snapshotList, "list", false, "List saved snapshots")
+	snapshotCmd.Flags().BoolVar(&snapshotForce, "force", false, "Replace an existing snapshot of the same name")
+	snapshotCmd.Flags().BoolVar(&snapshotJSON, "json", false, "With --list, output snapshots as JSON")
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	if snapshotList {
+		return listSnapshots(cmd, projectRoot)
+	}
+
+	name := args[0]
+	if err := storage.ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	count, limit := 0, 0
+	var pruned []string
+	err = storage.WithLock(projectRoot, func() error {
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if limit = storage.HistoryLimit(config); limit == 0 {
+			return fmt.Errorf("snapshots are turned off (historyLimit is 0); enable them with: todo config --set historyLimit=%d", types.DefaultHistoryLimit)
+		}
+		if _, err := os.Stat(storage.GetSnapshotPath(projectRoot, name)); err == nil && !snapshotForce {
+			return fmt.Errorf("snapshot %q already exists (use --force to replace it)", name)
+		}
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		count = len(todos)
+		pruned, err = saveSnapshot(projectRoot, name, todos, limit)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	terminal.PrintSuccess(fmt.Sprintf("Saved snapshot %s: %d todo(s) at %s", name, count, formatSnapshotTime(time.Now())))
+	terminal.PrintDim(fmt.Sprintf("Restore it with: todo restore %s", name))
+	printPrunedSnapshots(pruned, limit)
+	terminal.PrintBlank()
+	return nil
+}
+
+// saveSnapshot saves todos as the named snapshot, then deletes the oldest
+// snapshots beyond limit and returns their names.
+func saveSnapshot(projectRoot, name string, todos []types.Todo, limit int) ([]string, error) {
+	if err := storage.SaveSnapshot(projectRoot, name, todos); err != nil {
+		return nil, err
+	}
+	return storage.PruneSnapshots(projectRoot, limit)
+}
+
+func printPrunedSnapshots(pruned []string, limit int) {
+	if len(pruned) > 0 {
+		terminal.PrintDim(fmt.Sprintf("Pruned %d old snapshot(s) to keep %d: %s", len(pruned), limit, strings.Join(pruned, ", ")))
+	}
+}
+
+func listSnapshots(cmd *cobra.Command, projectRoot string) error {
+	snapshots, err := storage.ListSnapshots(projectRoot)
+	if err != nil {
+		return err
+	}
+	if snapshotJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"snapshots": snapshots,
+			"count":     len(snapshots),
+		})
+	}
+
+	if len(snapshots) == 0 {
+		terminal.PrintInfo("No snapshots yet")
+		terminal.PrintDim("Save one with: todo snapshot <name>")
+		terminal.PrintBlank()
+		return nil
+	}
+	for _, s := range snapshots {
+		fmt.Printf("  %s%-24s%s %s%s%s  %d todo(s)\n", terminal.BrightCyan, s.Name, terminal.Reset,
+			terminal.Dim, formatSnapshotTime(s.CreatedAt), terminal.Reset, s.Count)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+
+	var restored, replaced, limit int
+	var takenAt time.Time
+	var pruned []string
+	err = storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadSnapshot(projectRoot, name)
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(storage.GetSnapshotPath(projectRoot, name)); err == nil {
+			takenAt = info.ModTime()
+		}
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		current, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+		if limit = storage.HistoryLimit(config); limit > 0 {
+			if pruned, err = saveSnapshot(projectRoot, storage.AutoSnapshotName, current, limit); err != nil {
+				return fmt.Errorf("failed to save %s snapshot: %w", storage.AutoSnapshotName, err)
+			}
+		}
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		restored, replaced = len(todos), len(current)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	terminal.PrintSuccess(fmt.Sprintf("Restored %d todo(s) from snapshot %s (taken %s)", restored, name, formatSnapshotTime(takenAt)))
+	if limit > 0 {
+		terminal.PrintDim(fmt.Sprintf("The previous %d todo(s) were saved as: todo restore %s", replaced, storage.AutoSnapshotName))
+		printPrunedSnapshots(pruned, limit)
+	} else {
+		terminal.PrintDim(fmt.Sprintf("The previous %d todo(s) weren't saved: snapshots are turned off (historyLimit is 0)", replaced))
+	}
+	terminal.PrintBlank()
+	return nil
+}
+
+func formatSnapshotTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func completeSnapshotNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snapshots, err := storage.ListSnapshots(projectRoot)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, len(snapshots))
+	for i, s := range snapshots {
+		names[i] = s.Name
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestSnapshotAndRestore(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		snapshotList, snapshotForce, snapshotJSON = false, false, false
+	})
+	run := func(args ...string) error {
+		t.Helper()
+		snapshotList, snapshotForce, snapshotJSON = false, false, false
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+	texts := func() []string {
+		t.Helper()
+		todos, err := storage.LoadTodos(dir)
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		out := make([]string, len(todos))
+		for i, todo := range todos {
+			out[i] = todo.Text
+		}
+		return out
+	}
+
+	original := []types.Todo{*types.NewTodo("aaa111", "keep me"), *types.NewTodo("bbb222", "and me")}
+	for i := range original {
+		original[i].CreatedBy = "test-user"
+	}
+	if err := storage.SaveTodos(dir, original); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := run("snapshot", "checkpoint"); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if err := run("snapshot", "checkpoint"); err == nil {
+		t.Fatal("expected an error when the snapshot exists")
+	}
+	if err := run("snapshot", "checkpoint", "--force"); err != nil {
+		t.Fatalf("snapshot --force: %v", err)
+	}
+	if err := run("snapshot", "../escape"); err == nil {
+		t.Fatal("expected an invalid name to be rejected")
+	}
+
+	bulk := *types.NewTodo("ccc333", "bulk edit")
+	bulk.CreatedBy = "test-user"
+	if err := storage.SaveTodos(dir, []types.Todo{bulk}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := run("restore", "checkpoint"); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if got := texts(); len(got) != 2 || got[0] != "keep me" || got[1] != "and me" {
+		t.Fatalf("after restore = %v", got)
+	}
+
+	if err := run("restore", storage.AutoSnapshotName); err != nil {
+		t.Fatalf("restore %s: %v", storage.AutoSnapshotName, err)
+	}
+	if got := texts(); len(got) != 1 || got[0] != "bulk edit" {
+		t.Fatalf("undoing the restore = %v", got)
+	}
+
+	if err := run("restore", "missing"); err == nil {
+		t.Fatal("expected an error for an unknown snapshot")
+	}
+}
+
+func TestSnapshotHistoryLimit(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	t.Cleanup(func() {
+		snapshotList, snapshotForce, snapshotJSON = false, false, false
+		historyPrune = false
+	})
+	run := func(args ...string) error {
+		t.Helper()
+		snapshotList, snapshotForce, snapshotJSON, historyPrune = false, false, false, false
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+	setLimit := func(limit int) {
+		t.Helper()
+		config, err := storage.LoadConfig(dir)
+		if err != nil {
+			t.Fatalf("load config: %v", err)
+		}
+		config.HistoryLimit = &limit
+		if err := storage.SaveConfig(dir, config); err != nil {
+			t.Fatalf("save config: %v", err)
+		}
+	}
+	count := func() int {
+		t.Helper()
+		snapshots, err := storage.ListSnapshots(dir)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		return len(snapshots)
+	}
+
+	setLimit(3)
+	for i := 1; i <= 5; i++ {
+		if err := run("snapshot", fmt.Sprintf("s%d", i)); err != nil {
+			t.Fatalf("snapshot s%d: %v", i, err)
+		}
+	}
+	if got := count(); got != 3 {
+		t.Fatalf("expected historyLimit to cap snapshots at 3, got %d", got)
+	}
+
+	setLimit(1)
+	if err := run("history", "--prune"); err != nil {
+		t.Fatalf("history --prune: %v", err)
+	}
+	if got := count(); got != 1 {
+		t.Fatalf("expected --prune to leave 1 snapshot, got %d", got)
+	}
+	if err := run("history", "--prune", "1"); err == nil {
+		t.Fatal("expected --prune with a todo argument to fail")
+	}
+
+	setLimit(0)
+	if err := run("snapshot", "off"); err == nil {
+		t.Fatal("expected snapshot to fail with historyLimit 0")
+	}
+	snapshots, err := storage.ListSnapshots(dir)
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("list = %+v, %v", snapshots, err)
+	}
+	if err := run("restore", snapshots[0].Name); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if _, err := os.Stat(storage.GetSnapshotPath(dir, storage.AutoSnapshotName)); !os.IsNotExist(err) {
+		t.Fatalf("expected no %s snapshot with historyLimit 0 (stat: %v)", storage.AutoSnapshotName, err)
+	}
+}
@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/contributors"
+	"github.com/bagadi-alnour/todo-cli/internal/stats"
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
@@ -15,8 +15,8 @@ import (
 )
 
 var (
-	statsJSON        bool
-	statsByAssignee  bool
+	statsJSON       bool
+	statsByAssignee bool
 )
 
 var statsCmd = &cobra.Command{
@@ -35,67 +35,8 @@ func init() {
 	statsCmd.Flags().BoolVar(&statsByAssignee, "by-assignee", false, "Include breakdown by assignee")
 }
 
-type statsReport struct {
-	Total              int            `json:"total"`
-	ByStatus           map[string]int `json:"byStatus"`
-	ByPriority         map[string]int `json:"byPriority"`
-	ByTag              map[string]int `json:"byTag"`
-	ByAssignee         map[string]int `json:"byAssignee,omitempty"`
-	CompletionRate     float64        `json:"completionRate"`
-	AvgAgeDays         float64        `json:"avgAgeDaysOpen"`
-	AvgCompletionHours float64        `json:"avgCompletionHours"`
-	Overdue            int            `json:"overdue"`
-}
-
-func computeStats(todos []types.Todo, now time.Time) statsReport {
-	r := statsReport{
-		Total:      len(todos),
-		ByStatus:   countByStatus(todos),
-		ByPriority: map[string]int{"high": 0, "medium": 0, "low": 0},
-		ByTag:      map[string]int{},
-		ByAssignee: map[string]int{},
-	}
-
-	var openAgeSum float64
-	openCount := 0
-	var completionSum float64
-	doneCount := 0
-	for _, t := range todos {
-		r.ByPriority[string(t.Priority)]++
-		for _, tag := range t.Tags {
-			r.ByTag[strings.ToLower(tag)]++
-		}
-		if t.Assignee != "" {
-			r.ByAssignee[t.Assignee]++
-		}
-		if t.Status == types.StatusOpen {
-			openCount++
-			openAgeSum += now.Sub(t.CreatedAt).Hours() / 24.0
-		}
-		if t.Status == types.StatusDone && t.CompletedAt != nil {
-			doneCount++
-			completionSum += t.CompletedAt.Sub(t.CreatedAt).Hours()
-		}
-		if t.Status == types.StatusOpen && t.DueAt != nil && t.DueAt.Before(now) {
-			r.Overdue++
-		}
-	}
-
-	if r.Total > 0 {
-		r.CompletionRate = float64(r.ByStatus["done"]) / float64(r.Total) * 100
-	}
-	if openCount > 0 {
-		r.AvgAgeDays = openAgeSum / float64(openCount)
-	}
-	if doneCount > 0 {
-		r.AvgCompletionHours = completionSum / float64(doneCount)
-	}
-
-	return r
-}
-
 func runStats(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -108,7 +49,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 	Verbosef("loaded %d todo(s)", len(todos))
 
 	now := time.Now()
-	report := computeStats(todos, now)
+	report := stats.Compute(todos, now)
 
 	if statsJSON {
 		enc := json.NewEncoder(cmd.OutOrStdout())
@@ -131,6 +72,9 @@ func runStats(cmd *cobra.Command, args []string) error {
 	fmt.Printf("    %s●%s Blocked    %s%d%s\n", terminal.Red, terminal.Reset, terminal.Bold, report.ByStatus["blocked"], terminal.Reset)
 	fmt.Printf("    %s●%s Waiting    %s%d%s\n", terminal.Magenta, terminal.Reset, terminal.Bold, report.ByStatus["waiting"], terminal.Reset)
 	fmt.Printf("    %s●%s Tech Debt  %s%d%s\n", terminal.Yellow, terminal.Reset, terminal.Bold, report.ByStatus["tech-debt"], terminal.Reset)
+	for _, cs := range types.CustomStatuses() {
+		fmt.Printf("    %s●%s %-10s %s%d%s\n", terminal.StatusColor(cs.Name), terminal.Reset, cs.Name, terminal.Bold, report.ByStatus[cs.Name], terminal.Reset)
+	}
 	fmt.Println()
 
 	// Priority breakdown
@@ -189,6 +133,12 @@ func runStats(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("    Overdue:           %s0%s\n", terminal.Bold, terminal.Reset)
 	}
+	if report.TimeSpentSeconds > 0 {
+		fmt.Printf("    Time tracked:      %s%s%s\n", terminal.Bold, formatTrackedTime(time.Duration(report.TimeSpentSeconds)*time.Second), terminal.Reset)
+	}
+	if report.OpenEstimate > 0 {
+		fmt.Printf("    Open estimate:     %s%d pts%s\n", terminal.Bold, report.OpenEstimate, terminal.Reset)
+	}
 	fmt.Printf("    Total:             %s%d%s\n", terminal.Bold, report.Total, terminal.Reset)
 	fmt.Println()
 
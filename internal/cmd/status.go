@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/terminal"
@@ -11,6 +10,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var statusForce bool
+
 var statusCmd = &cobra.Command{
 	Use:     "status <id|index> [id|index...] <status>",
 	Aliases: []string{"set-status"},
@@ -18,15 +19,33 @@ var statusCmd = &cobra.Command{
 	Long: `Set the status of todos without opening the interactive list.
 The last argument is the target status. All preceding arguments are todo IDs or indices.
 
-Valid statuses: open, done, blocked, waiting, tech-debt.`,
+Valid statuses: open, done, blocked, waiting, tech-debt, plus any
+customStatuses defined in .todos/config.json. When config.json has
+statusTransitions, changes it doesn't list are refused unless --force is
+given; nothing is changed if any target is refused.`,
 	Example: `  todo status 1 blocked       # Set todo #1 to blocked
-  todo status 1 2 3 done      # Set multiple todos to done`,
-	Args: cobra.MinimumNArgs(2),
-	RunE: runStatus,
+  todo status 1 2 3 done      # Set multiple todos to done
+  todo status 4 open --force  # Reopen even if statusTransitions forbids it`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeStatusArgs,
+	RunE:              runStatus,
 }
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusForce, "force", false, "Allow changes that statusTransitions in config.json forbids")
+}
+
+// checkStatusTransition applies the project's statusTransitions to moving
+// todo to status, unless force is set.
+func checkStatusTransition(config *types.Config, todo types.Todo, status types.Status, force bool) error {
+	if force {
+		return nil
+	}
+	if err := types.ValidateTransition(todo.Status, status, config); err != nil {
+		return fmt.Errorf("%s: %w\n\nUse --force to change it anyway.", todo.Text, err)
+	}
+	return nil
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -35,7 +54,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return &types.InvalidStatusError{Status: args[len(args)-1]}
 	}
 
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
@@ -46,45 +65,83 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to load todos: %w", err)
 		}
 
+		config, err := storage.LoadConfig(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
 		targets := args[:len(args)-1]
-		updated := 0
+		for _, idOrIndex := range targets {
+			if target, _, err := storage.ResolveTodo(todos, idOrIndex); err == nil {
+				if err := checkStatusTransition(config, *target, newStatus, statusForce); err != nil {
+					return err
+				}
+			}
+		}
+		updated, unchanged, missing := 0, 0, 0
+		var recurring []types.Todo
 
 		for _, idOrIndex := range targets {
-			target, idx := storage.FindTodoByIDOrIndex(todos, idOrIndex)
-			if target == nil {
-				terminal.PrintWarning(fmt.Sprintf("Not found: %s", idOrIndex))
+			target, idx, err := storage.ResolveTodo(todos, idOrIndex)
+			if err != nil {
+				warnUnresolved(todos, idOrIndex, err)
+				missing++
 				continue
 			}
 			if target.Status == newStatus {
 				terminal.PrintInfo(fmt.Sprintf("Already %s: %s", newStatus, target.Text))
+				unchanged++
 				continue
 			}
 
 			switch newStatus {
 			case types.StatusDone:
-				todos[idx].MarkDone()
-			case types.StatusOpen:
-				todos[idx].MarkOpen()
+				next, err := completeTodo(&todos[idx])
+				if err != nil {
+					terminal.PrintWarning(fmt.Sprintf("Failed to create recurring copy: %v", err))
+				} else if next != nil {
+					recurring = append(recurring, *next)
+				}
 			default:
-				todos[idx].Status = newStatus
-				todos[idx].CompletedAt = nil
-				todos[idx].UpdatedAt = time.Now()
+				todos[idx].SetStatus(newStatus)
 			}
 
 			terminal.PrintSuccess(fmt.Sprintf("Status set to %s: %s", newStatus, target.Text))
 			updated++
 		}
 
+		if len(targets) > 1 {
+			terminal.PrintBlank()
+			terminal.PrintSuccess(statusSummary(newStatus, updated, unchanged, missing))
+		}
 		if updated == 0 {
-			fmt.Println()
+			terminal.PrintBlank()
 			return nil
 		}
+		todos = append(todos, recurring...)
 
 		if err := storage.SaveTodos(projectRoot, todos); err != nil {
 			return fmt.Errorf("failed to save todos: %w", err)
 		}
 
-		fmt.Println()
+		terminal.PrintBlank()
 		return nil
 	})
 }
+
+// statusSummary totals a multi-target status change, e.g. "Set 2 of 4
+// todo(s) to blocked (1 already blocked, 1 not found)".
+func statusSummary(status types.Status, updated, unchanged, missing int) string {
+	summary := fmt.Sprintf("Set %d of %d todo(s) to %s", updated, updated+unchanged+missing, status)
+	var skipped []string
+	if unchanged > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d already %s", unchanged, status))
+	}
+	if missing > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d not found", missing))
+	}
+	if len(skipped) > 0 {
+		summary += " (" + strings.Join(skipped, ", ") + ")"
+	}
+	return summary
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var tagsJSON bool
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List all tags with counts",
+	Long: `List every distinct tag with the number of unfinished and total todos using it,
+most frequent first.
+
+Tags that look like near-duplicates (bug/bugs, front-end/frontend,
+fronted/frontend) are listed as merge suggestions.`,
+	Example: `  todo tags
+  todo tags --json`,
+	Args: cobra.NoArgs,
+	RunE: runTags,
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.Flags().BoolVar(&tagsJSON, "json", false, "Output as JSON")
+}
+
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Open  int    `json:"open"`
+	Total int    `json:"total"`
+}
+
+// countTags tallies tag usage. Open counts todos that are not done.
+func countTags(todos []types.Todo) []tagCount {
+	byTag := make(map[string]*tagCount)
+	for _, t := range todos {
+		for _, tag := range t.Tags {
+			c, ok := byTag[tag]
+			if !ok {
+				c = &tagCount{Tag: tag}
+				byTag[tag] = c
+			}
+			c.Total++
+			if t.Status != types.StatusDone {
+				c.Open++
+			}
+		}
+	}
+
+	counts := make([]tagCount, 0, len(byTag))
+	for _, c := range byTag {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total != counts[j].Total {
+			return counts[i].Total > counts[j].Total
+		}
+		return counts[i].Tag < counts[j].Tag
+	})
+	return counts
+}
+
+// similarTags returns pairs of tags that are probably the same word: equal once
+// separators and a plural "s" are stripped, or one edit apart.
+func similarTags(tags []string) [][2]string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+
+	var pairs [][2]string
+	for i := 0; i < len(sorted); i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			a, b := sorted[i], sorted[j]
+			if tagKey(a) == tagKey(b) || (len(a) >= 4 && len(b) >= 4 && editDistance(a, b) == 1) {
+				pairs = append(pairs, [2]string{a, b})
+			}
+		}
+	}
+	return pairs
+}
+
+func tagKey(tag string) string {
+	key := strings.NewReplacer("-", "", "_", "", ".", "", " ", "").Replace(tag)
+	if len(key) > 3 {
+		key = strings.TrimSuffix(key, "s")
+	}
+	return key
+}
+
+// editDistance computes the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func runTags(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load todos: %w", err)
+	}
+
+	counts := countTags(todos)
+	names := make([]string, 0, len(counts))
+	for _, c := range counts {
+		names = append(names, c.Tag)
+	}
+	similar := similarTags(names)
+
+	if tagsJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{"tags": counts, "count": len(counts), "similar": similar})
+	}
+
+	terminal.PrintHeader("TAGS", "🏷️")
+
+	if len(counts) == 0 {
+		terminal.PrintInfo("No tags yet. Add some with: todo add \"Task\" --tag backend")
+		fmt.Println()
+		return nil
+	}
+
+	width := 0
+	for _, c := range counts {
+		if len(c.Tag) > width {
+			width = len(c.Tag)
+		}
+	}
+	for _, c := range counts {
+		fmt.Printf("  %s#%-*s%s  %s%d open%s %s/ %d total%s\n",
+			terminal.Cyan, width, c.Tag, terminal.Reset,
+			terminal.Bold, c.Open, terminal.Reset,
+			terminal.Dim, c.Total, terminal.Reset)
+	}
+	fmt.Println()
+
+	if len(similar) > 0 {
+		fmt.Printf("  %sSimilar tags (consider merging):%s\n", terminal.Yellow, terminal.Reset)
+		for _, pair := range similar {
+			fmt.Printf("    %s#%s%s ~ %s#%s%s\n", terminal.Cyan, pair[0], terminal.Reset, terminal.Cyan, pair[1], terminal.Reset)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
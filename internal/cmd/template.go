@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templatePriority string
+	templatePaths    []string
+	templateTags     []string
+	templateNotes    string
+	templateEstimate int
+	templateRecur    string
+	templateForce    bool
+	templateJSON     bool
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage reusable defaults for kinds of todos",
+	Long: `Templates hold defaults for recurring kinds of todos — a bug, a feature,
+a chore — as .todos/templates/<name>.json. todo add --template <name> starts
+from a template: its priority, notes, estimate, and recurrence apply unless
+the matching flag is given, and its paths and tags are combined with the ones
+on the command line.`,
+	Example: `  todo template save bug --priority high --tag bug --path src/
+  todo template list
+  todo add --template bug "Login fails on Safari"
+  todo template delete bug`,
+}
+
+var templateSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a template from flags",
+	Long: `Save a template from the given flags. Names use letters, digits, '-', '_',
+and '.'. An existing template is only replaced with --force.`,
+	Example: `  todo template save bug --priority high --tag bug
+  todo template save chore --priority low --tag chore --estimate 1`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: cobra.NoFileCompletions,
+	RunE:              runTemplateSave,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the project's templates",
+	Args:    cobra.NoArgs,
+	RunE:    runTemplateList,
+}
+
+var templateDeleteCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Aliases:           []string{"rm"},
+	Short:             "Delete a template",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTemplateNames,
+	RunE:              runTemplateDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateSaveCmd, templateListCmd, templateDeleteCmd)
+
+	templateSaveCmd.Flags().StringVar(&templatePriority, "priority", "", "Default priority: low, medium, high (or l, m, h)")
+	templateSaveCmd.Flags().StringArrayVarP(&templatePaths, "path", "p", []string{}, "Default paths (repeat or comma-separate)")
+	templateSaveCmd.Flags().StringArrayVarP(&templateTags, "tag", "t", []string{}, "Default tags (repeat or comma-separate)")
+	templateSaveCmd.Flags().StringVar(&templateNotes, "notes", "", "Default notes")
+	templateSaveCmd.Flags().IntVar(&templateEstimate, "estimate", 0, "Default estimate in story points")
+	templateSaveCmd.Flags().StringVar(&templateRecur, "recur", "", "Default recurrence: daily, weekly, monthly")
+	templateSaveCmd.Flags().BoolVar(&templateForce, "force", false, "Replace an existing template of the same name")
+	registerPathFlagCompletion(templateSaveCmd, "path")
+	registerPriorityFlagCompletion(templateSaveCmd, "priority")
+
+	templateListCmd.Flags().BoolVar(&templateJSON, "json", false, "Output templates as JSON")
+}
+
+func runTemplateSave(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if err := storage.ValidateTemplateName(name); err != nil {
+		return err
+	}
+
+	tmpl := types.TodoTemplate{
+		Paths:    normalizePaths(templatePaths),
+		Tags:     storage.NormalizeTags(templateTags),
+		Notes:    templateNotes,
+		Estimate: templateEstimate,
+		Recur:    types.Recurrence(strings.ToLower(templateRecur)),
+	}
+	if templatePriority != "" {
+		if tmpl.Priority, err = types.ParsePriority(templatePriority); err != nil {
+			return err
+		}
+	}
+	if tmpl.Priority == "" && len(tmpl.Paths) == 0 && len(tmpl.Tags) == 0 && tmpl.Notes == "" && tmpl.Estimate == 0 && tmpl.Recur == "" {
+		return fmt.Errorf("template %q would be empty; set at least one of --priority, --path, --tag, --notes, --estimate, --recur", name)
+	}
+
+	err = storage.WithLock(projectRoot, func() error {
+		if _, err := os.Stat(storage.GetTemplatePath(projectRoot, name)); err == nil && !templateForce {
+			return fmt.Errorf("template %q already exists (use --force to replace it)", name)
+		}
+		return storage.SaveTemplate(projectRoot, name, tmpl)
+	})
+	if err != nil {
+		return err
+	}
+
+	terminal.PrintSuccess(fmt.Sprintf("Saved template %s: %s", name, describeTemplate(tmpl)))
+	terminal.PrintDim(fmt.Sprintf("Use it with: todo add --template %s \"Your task\"", name))
+	terminal.PrintBlank()
+	return nil
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	names, err := storage.ListTemplates(projectRoot)
+	if err != nil {
+		return err
+	}
+	templates := make(map[string]types.TodoTemplate, len(names))
+	for _, name := range names {
+		tmpl, err := storage.LoadTemplate(projectRoot, name)
+		if err != nil {
+			return err
+		}
+		templates[name] = tmpl
+	}
+
+	if templateJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]interface{}{
+			"templates": templates,
+			"count":     len(templates),
+		})
+	}
+
+	if len(names) == 0 {
+		terminal.PrintInfo("No templates yet")
+		terminal.PrintDim("Save one with: todo template save <name> --priority high --tag bug")
+		terminal.PrintBlank()
+		return nil
+	}
+	for _, name := range names {
+		fmt.Printf("  %s%-16s%s %s%s%s\n", terminal.BrightCyan, name, terminal.Reset,
+			terminal.Dim, describeTemplate(templates[name]), terminal.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runTemplateDelete(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	err = storage.WithLock(projectRoot, func() error {
+		return storage.DeleteTemplate(projectRoot, args[0])
+	})
+	if err != nil {
+		return err
+	}
+	terminal.PrintSuccess(fmt.Sprintf("Deleted template %s", args[0]))
+	terminal.PrintBlank()
+	return nil
+}
+
+// applyTodoTemplate fills the add flags from tmpl. Flags given on the command
+// line win for single values; paths and tags are combined, template first.
+func applyTodoTemplate(cmd *cobra.Command, tmpl types.TodoTemplate) {
+	flags := cmd.Flags()
+	if tmpl.Priority != "" && !flags.Changed("priority") {
+		addPriority = string(tmpl.Priority)
+	}
+	if tmpl.Notes != "" && !flags.Changed("notes") {
+		addNotes = tmpl.Notes
+	}
+	if tmpl.Estimate != 0 && !flags.Changed("estimate") {
+		addEstimate = tmpl.Estimate
+	}
+	if tmpl.Recur != "" && !flags.Changed("recur") {
+		addRecur = string(tmpl.Recur)
+	}
+	addPaths = append(append([]string{}, tmpl.Paths...), addPaths...)
+	addTags = append(append([]string{}, tmpl.Tags...), addTags...)
+}
+
+// describeTemplate summarizes a template on one line.
+func describeTemplate(tmpl types.TodoTemplate) string {
+	var parts []string
+	if tmpl.Priority != "" {
+		parts = append(parts, "priority "+string(tmpl.Priority))
+	}
+	if len(tmpl.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(tmpl.Tags, ", "))
+	}
+	if len(tmpl.Paths) > 0 {
+		parts = append(parts, "paths "+strings.Join(tmpl.Paths, ", "))
+	}
+	if tmpl.Estimate > 0 {
+		parts = append(parts, fmt.Sprintf("estimate %d", tmpl.Estimate))
+	}
+	if tmpl.Recur != "" {
+		parts = append(parts, "recurs "+string(tmpl.Recur))
+	}
+	if tmpl.Notes != "" {
+		parts = append(parts, "notes")
+	}
+	return strings.Join(parts, " · ")
+}
+
+func completeTemplateNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, err := storage.ListTemplates(projectRoot)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
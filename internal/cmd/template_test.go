@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestAddFromTemplate(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	reset := func() {
+		addPaths, addTags, addJSON, addPriority, addNotes, addEstimate = []string{}, []string{}, false, "medium", "", 0
+		addTemplateName = ""
+		templatePriority, templatePaths, templateTags, templateForce = "", []string{}, []string{}, false
+		for _, name := range []string{"priority", "path", "tag", "estimate", "notes"} {
+			if f := addCmd.Flags().Lookup(name); f != nil {
+				f.Changed = false
+			}
+		}
+	}
+	reset()
+	t.Cleanup(func() {
+		reset()
+		rootCmd.SetOut(nil)
+	})
+
+	rootCmd.SetArgs([]string{"template", "save", "bug", "--priority", "h", "--tag", "bug", "--path", "src/", "--estimate", "2"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("template save: %v", err)
+	}
+	tmpl, err := storage.LoadTemplate(dir, "bug")
+	if err != nil {
+		t.Fatalf("load template: %v", err)
+	}
+	want := types.TodoTemplate{Priority: types.PriorityHigh, Paths: []string{"src/"}, Tags: []string{"bug"}, Estimate: 2}
+	if !reflect.DeepEqual(tmpl, want) {
+		t.Fatalf("saved template = %+v, want %+v", tmpl, want)
+	}
+
+	// Hand-written templates accept the same shorthands as --priority.
+	if err := os.WriteFile(storage.GetTemplatePath(dir, "chore"), []byte(`{"priority": "L", "tags": ["chore"]}`), 0644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	if tmpl, err := storage.LoadTemplate(dir, "chore"); err != nil || tmpl.Priority != types.PriorityLow {
+		t.Fatalf("load hand-written template = %+v, %v", tmpl, err)
+	}
+	if err := storage.DeleteTemplate(dir, "chore"); err != nil {
+		t.Fatalf("delete template: %v", err)
+	}
+
+	reset()
+	rootCmd.SetArgs([]string{"template", "save", "bug", "--priority", "low"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an error when the template exists")
+	}
+
+	add := func(args ...string) types.Todo {
+		t.Helper()
+		reset()
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetArgs(append([]string{"add", "--json", "--no-git", "--force"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("add %v: %v", args, err)
+		}
+		var todo types.Todo
+		if err := json.Unmarshal(buf.Bytes(), &todo); err != nil {
+			t.Fatalf("parse: %v\n%s", err, buf.String())
+		}
+		return todo
+	}
+
+	todo := add("--template", "bug", "Login fails")
+	if todo.Text != "Login fails" || todo.Priority != types.PriorityHigh || todo.Estimate != 2 ||
+		!reflect.DeepEqual(todo.Tags, []string{"bug"}) || !reflect.DeepEqual(todo.Context.Paths, []string{"src/"}) {
+		t.Fatalf("todo from template = %+v", todo)
+	}
+
+	todo = add("--template", "bug", "Crash on save", "--priority", "low", "--tag", "urgent", "--path", "docs")
+	if todo.Text != "Crash on save" || todo.Priority != types.PriorityLow {
+		t.Fatalf("flags should override the template: %+v", todo)
+	}
+	if !reflect.DeepEqual(todo.Tags, []string{"bug", "urgent"}) || !reflect.DeepEqual(todo.Context.Paths, []string{"src/", "docs"}) {
+		t.Fatalf("tags %v and paths %v should combine template and flags", todo.Tags, todo.Context.Paths)
+	}
+
+	reset()
+	rootCmd.SetArgs([]string{"add", "--template", "missing", "text"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+
+	reset()
+	rootCmd.SetArgs([]string{"template", "delete", "bug"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("template delete: %v", err)
+	}
+	if names, err := storage.ListTemplates(dir); err != nil || len(names) != 0 {
+		t.Fatalf("templates after delete = %v, %v", names, err)
+	}
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <id|index>",
+	Short: "Start timing work on a todo",
+	Long: `Start a timer on a todo. Only one of your timers runs at a time: starting
+another todo stops the one you started first, while timers teammates started
+keep running. Stop it with todo stop; completing the todo stops it too. Time
+from finished sessions adds up in the todo's tracked time.`,
+	Example: `  todo start 1
+  todo start abc123`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeUndoneTodoArgs(cmd, args, toComplete)
+	},
+	RunE: runStart,
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [id|index]",
+	Short: "Stop the running timer",
+	Long: `Stop the timer you started and add the session to the todo's tracked time.
+With an ID or index, stop that todo's timer, whoever started it.`,
+	Example: `  todo stop
+  todo stop 1`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		running := func(t types.Todo) bool { return t.StartedAt != nil }
+		return todoCandidates(args, toComplete, running), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: runStop,
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+}
+
+// stoppedTimer describes a finished timing session for output.
+type stoppedTimer struct {
+	todo    types.Todo
+	session time.Duration
+}
+
+func runStart(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	owner, _ := storage.CurrentUserSlug()
+	now := time.Now()
+	var started types.Todo
+	var stopped []stoppedTimer
+	alreadyRunning := false
+	err = storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		_, idx, err := storage.ResolveTodo(todos, args[0])
+		if err != nil {
+			return err
+		}
+		if todos[idx].Status == types.StatusDone {
+			return fmt.Errorf("todo %s is done; reopen it before starting a timer", shortTodoID(todos[idx].ID))
+		}
+		if todos[idx].StartedAt != nil {
+			alreadyRunning = true
+			started = todos[idx]
+			return nil
+		}
+
+		stopped = stopTimers(todos, owner, now)
+		todos[idx].StartTimer(now, owner)
+		started = todos[idx]
+		return storage.SaveTodos(projectRoot, todos)
+	})
+	if err != nil {
+		return err
+	}
+
+	if alreadyRunning {
+		terminal.PrintInfo(fmt.Sprintf("Timer already running on %s since %s", started.Text, started.StartedAt.Local().Format("15:04")))
+		fmt.Println()
+		return nil
+	}
+	for _, s := range stopped {
+		printStoppedTimer(s, now)
+	}
+	terminal.PrintSuccess(fmt.Sprintf("Started: %s", started.Text))
+	if started.TimeSpent > 0 {
+		fmt.Printf("  %s⏱ %s tracked so far%s\n", terminal.Dim, formatTrackedTime(time.Duration(started.TimeSpent)), terminal.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	owner, _ := storage.CurrentUserSlug()
+	now := time.Now()
+	var stopped []stoppedTimer
+	var target *types.Todo
+	err = storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		if len(args) == 1 {
+			_, idx, err := storage.ResolveTodo(todos, args[0])
+			if err != nil {
+				return err
+			}
+			target = &todos[idx]
+			if session, ok := todos[idx].StopTimer(now); ok {
+				stopped = append(stopped, stoppedTimer{todo: todos[idx], session: session})
+			}
+		} else {
+			stopped = stopTimers(todos, owner, now)
+		}
+
+		if len(stopped) == 0 {
+			return nil
+		}
+		return storage.SaveTodos(projectRoot, todos)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(stopped) == 0 {
+		if target != nil {
+			terminal.PrintWarning(fmt.Sprintf("No timer running on %s", target.Text))
+		} else {
+			terminal.PrintWarning("No timer running. Start one with: todo start <id>")
+		}
+		fmt.Println()
+		return nil
+	}
+	for _, s := range stopped {
+		printStoppedTimer(s, now)
+	}
+	fmt.Println()
+	return nil
+}
+
+// stopTimers stops the running timers owner started, or every running timer
+// when owner is empty because the current user isn't known.
+func stopTimers(todos []types.Todo, owner string, now time.Time) []stoppedTimer {
+	var stopped []stoppedTimer
+	for i := range todos {
+		if owner != "" && todos[i].StartedBy != owner {
+			continue
+		}
+		if session, ok := todos[i].StopTimer(now); ok {
+			stopped = append(stopped, stoppedTimer{todo: todos[i], session: session})
+		}
+	}
+	return stopped
+}
+
+func printStoppedTimer(s stoppedTimer, now time.Time) {
+	terminal.PrintSuccess(fmt.Sprintf("Stopped: %s", s.todo.Text))
+	fmt.Printf("  %s⏱ +%s, %s total%s\n", terminal.Dim,
+		formatTrackedTime(s.session), formatTrackedTime(s.todo.TrackedTime(now)), terminal.Reset)
+}
+
+// formatTrackedTime renders a duration compactly: 45s, 12m, 3h 05m.
+func formatTrackedTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// trackedTimeLabel is the tracked time of a todo for detail views, or "" when
+// nothing has been tracked.
+func trackedTimeLabel(todo types.Todo, now time.Time) string {
+	if todo.TimeSpent == 0 && todo.StartedAt == nil {
+		return ""
+	}
+	label := formatTrackedTime(todo.TrackedTime(now))
+	if todo.StartedAt != nil {
+		label += " " + terminal.Green + "(running)" + terminal.Reset
+	}
+	return label
+}
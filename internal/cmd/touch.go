@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var touchCmd = &cobra.Command{
+	Use:   "touch <id|index> [id|index...]",
+	Short: "Mark todos as reviewed and still relevant",
+	Long: `Record that you looked at a todo and it's still relevant, without changing
+it. Touching sets updatedAt and lastReviewed to now, so todo doctor stops
+reporting the todo as stale for another 30 days.`,
+	Example: `  todo touch 3
+  todo touch abc123 def456`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeUndoneTodoArgs,
+	RunE:              runTouch,
+}
+
+func init() {
+	rootCmd.AddCommand(touchCmd)
+}
+
+func runTouch(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	return storage.WithLock(projectRoot, func() error {
+		todos, err := storage.LoadTodos(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load todos: %w", err)
+		}
+
+		touched := 0
+		for _, idOrIndex := range args {
+			target, idx, err := storage.ResolveTodo(todos, idOrIndex)
+			if err != nil {
+				warnUnresolved(todos, idOrIndex, err)
+				continue
+			}
+			todos[idx].Touch()
+			terminal.PrintSuccess(fmt.Sprintf("Reviewed: %s", target.Text))
+			touched++
+		}
+
+		if touched == 0 {
+			terminal.PrintBlank()
+			return nil
+		}
+		if err := storage.SaveTodos(projectRoot, todos); err != nil {
+			return fmt.Errorf("failed to save todos: %w", err)
+		}
+		terminal.PrintBlank()
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,7 +15,13 @@ import (
 )
 
 var (
-	uiPort int
+	uiPort     int
+	uiBind     string
+	uiToken    string
+	uiOrigin   string
+	uiOpen     bool
+	uiReadOnly bool
+	uiSocket   string
 )
 
 const defaultUIPort = 17887
@@ -28,9 +35,29 @@ The web UI provides:
   - A modern dark-themed interface
   - Add, edit, and delete todos
   - Filter by status
-  - Keyboard navigation`,
+  - Keyboard navigation
+
+The server listens on 127.0.0.1 by default. Use --bind to choose another
+interface. With --token, every /api/* request must send
+"Authorization: Bearer <token>". Binding to a non-loopback address without
+--token generates a random token and prints it at startup.
+
+With --read-only, the API answers requests that would change todos with 403
+and the page hides its add, edit, delete, and toggle controls.
+
+With --socket, the server listens on a Unix domain socket instead of a TCP
+port, readable only by you, for editor plugins and other local tools:
+
+  curl --unix-socket /tmp/todo.sock http://localhost/api/todos
+
+The socket file is removed on shutdown.`,
 	Example: `  todo ui            # Start on default port 17887
-  todo ui --port 3000 # Start on custom port`,
+  todo ui --port 3000 # Start on custom port
+  todo ui --bind 0.0.0.0             # Reachable from other hosts (token auto-generated)
+  todo ui --token s3cret             # Require a bearer token on /api/*
+  todo ui --open                     # Open the UI in the default browser
+  todo ui --read-only                # Share the view without allowing edits
+  todo ui --socket /tmp/todo.sock    # No TCP port; serve on a Unix socket`,
 	RunE: runUI,
 }
 
@@ -38,44 +65,157 @@ func init() {
 	rootCmd.AddCommand(uiCmd)
 
 	uiCmd.Flags().IntVarP(&uiPort, "port", "p", defaultUIPort, "Port to run the server on")
+	uiCmd.Flags().StringVar(&uiBind, "bind", "127.0.0.1", "Interface address to listen on")
+	uiCmd.Flags().StringVar(&uiToken, "token", "", "Require this bearer token on /api/* requests")
+	uiCmd.Flags().StringVar(&uiOrigin, "origin", "", "Allowed CORS origin when a token is set (default: the server URL)")
+	uiCmd.Flags().BoolVar(&uiReadOnly, "read-only", false, "Serve todos without allowing changes")
+	uiCmd.Flags().BoolVar(&uiOpen, "open", false, "Open the UI in the default browser once the server is listening")
+	uiCmd.Flags().StringVar(&uiSocket, "socket", "", "Listen on this Unix domain socket instead of a TCP port")
+	uiCmd.MarkFlagsMutuallyExclusive("socket", "port")
+	uiCmd.MarkFlagsMutuallyExclusive("socket", "bind")
+	uiCmd.MarkFlagsMutuallyExclusive("socket", "open")
+	_ = uiCmd.MarkFlagFilename("socket")
 }
 
 func runUI(cmd *cobra.Command, args []string) error {
 	// Find project root
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
+	token := uiToken
+	if token == "" && uiSocket == "" && !isLoopbackHost(uiBind) {
+		token, err = storage.GenerateID()
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+	}
+
+	host := uiBind
+	if isLoopbackHost(host) || host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	baseURL := fmt.Sprintf("http://%s", net.JoinHostPort(host, fmt.Sprint(uiPort)))
+
 	// Create server
 	server := ui.NewServer(projectRoot, uiPort)
+	if token != "" {
+		origin := uiOrigin
+		if origin == "" {
+			origin = baseURL
+		}
+		server.SetToken(token, origin)
+	}
+	server.SetReadOnly(uiReadOnly)
 
 	// Create HTTP server
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", uiPort),
+		Addr:    net.JoinHostPort(uiBind, fmt.Sprint(uiPort)),
 		Handler: server.Handler(),
 	}
 
-	// Start server in goroutine
-	go func() {
-		terminal.PrintHeader("TODO UI SERVER", "🚀")
-		fmt.Printf("  %s●%s Running at %s%shttp://localhost:%d%s\n",
+	openURL := baseURL
+	if token != "" {
+		openURL = baseURL + "/?token=" + token
+	}
+
+	// Bind before announcing the URL so a busy port fails here, and --open
+	// only launches a browser once the server can answer.
+	var listener net.Listener
+	if uiSocket != "" {
+		listener, err = listenUnixSocket(uiSocket)
+	} else {
+		listener, err = net.Listen("tcp", httpServer.Addr)
+		if err != nil {
+			err = fmt.Errorf("failed to listen on %s: %w", httpServer.Addr, err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	terminal.PrintHeader("TODO UI SERVER", "🚀")
+	if uiSocket != "" {
+		fmt.Printf("  %s●%s Listening on unix socket %s%s%s\n",
+			terminal.Green, terminal.Reset, terminal.BrightCyan, uiSocket, terminal.Reset)
+		fmt.Printf("  %s●%s Try: curl --unix-socket %s http://localhost/api/todos\n",
+			terminal.Cyan, terminal.Reset, uiSocket)
+	} else {
+		fmt.Printf("  %s●%s Running at %s%s%s%s\n",
 			terminal.Green, terminal.Reset,
-			terminal.Bold+terminal.Underline, terminal.BrightCyan, uiPort, terminal.Reset)
-		fmt.Printf("  %s●%s Press %sCtrl+C%s to stop\n\n",
-			terminal.Yellow, terminal.Reset,
-			terminal.Bold, terminal.Reset)
+			terminal.Bold+terminal.Underline, terminal.BrightCyan, openURL, terminal.Reset)
+		fmt.Printf("  %s●%s Listening on %s\n",
+			terminal.Cyan, terminal.Reset, httpServer.Addr)
+	}
+	if uiReadOnly {
+		fmt.Printf("  %s●%s Read-only: changes are rejected\n",
+			terminal.Blue, terminal.Reset)
+	}
+	if token != "" {
+		fmt.Printf("  %s●%s API token: %s%s%s\n",
+			terminal.Magenta, terminal.Reset, terminal.Bold, token, terminal.Reset)
+	}
+	fmt.Printf("  %s●%s Press %sCtrl+C%s to stop\n\n",
+		terminal.Yellow, terminal.Reset,
+		terminal.Bold, terminal.Reset)
 
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	// Start server in goroutine
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 			fmt.Printf("%sServer error: %v%s\n", terminal.Red, err, terminal.Reset)
 		}
 	}()
 
+	if uiOpen {
+		if err := ui.OpenBrowser(openURL); err != nil {
+			Verbosef("ui: %v", err)
+			fmt.Printf("  %sCouldn't open a browser; visit %s%s\n", terminal.Dim, openURL, terminal.Reset)
+		}
+	}
+
 	// Wait for interrupt
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	fmt.Printf("\n%sShutting down server...%s\n", terminal.Yellow, terminal.Reset)
+	// Closing the server closes the listener, which removes the socket file.
 	return httpServer.Close()
 }
+
+// listenUnixSocket listens on a Unix domain socket at path, readable and
+// writable only by the current user. A socket file left behind by a server
+// that is gone is replaced; a live one or any other file is an error.
+func listenUnixSocket(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is already in use by another server", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// isLoopbackHost reports whether host refers to the local machine only.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestListenUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not used on windows")
+	}
+	// Socket paths are limited to ~100 bytes, too short for some TempDirs.
+	dir, err := os.MkdirTemp("", "todo-sock")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "ui.sock")
+
+	listener, err := listenUnixSocket(path)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("socket mode = %v, %v; want 0600", info, err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})}
+	go server.Serve(listener)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://localhost/api/todos")
+	if err != nil {
+		t.Fatalf("request over socket: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Fatalf("body = %q", body)
+	}
+
+	if _, err := listenUnixSocket(path); err == nil {
+		t.Fatal("expected an error while another server holds the socket")
+	}
+	server.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("socket file should be removed on shutdown, stat err = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := listenUnixSocket(path); err == nil {
+		t.Fatal("expected an error for a regular file at the socket path")
+	}
+}
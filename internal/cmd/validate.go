@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var validateJSON bool
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the todo files for errors (for CI)",
+	Long: `Check every todo file — .todos/users/*.json and a legacy .todos/todos.json —
+without changing anything, and exit 1 if any has a problem:
+
+  - JSON that doesn't parse (with line and column) or a file version newer
+    than this build reads
+  - Todos with no ID, or an ID used more than once across the files
+  - Unknown statuses (custom ones from config.json count) and priorities
+  - Missing createdAt or updatedAt, or updatedAt before createdAt
+
+Unlike todo doctor, which looks at how useful the list is (stale, orphaned,
+duplicate text), validate only checks that the files are well-formed, so it
+suits a CI step. todo config --validate does the same for config.json.`,
+	Example: `  todo validate
+  todo validate --json   # {"valid": false, "problems": [...]}`,
+	Args: cobra.NoArgs,
+	// Problems in the files aren't usage errors; keep CI logs to the findings.
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "Output the problems as JSON")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+	problems, err := storage.ValidateTodoFiles(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if validateJSON {
+		if problems == nil {
+			problems = []storage.TodoProblem{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{
+			"valid":    len(problems) == 0,
+			"problems": problems,
+			"count":    len(problems),
+		}); err != nil {
+			return err
+		}
+	} else if len(problems) == 0 {
+		terminal.PrintSuccess("Todo files are valid")
+		terminal.PrintBlank()
+	} else {
+		terminal.PrintWarning(fmt.Sprintf("Found %d problem(s) in the todo files", len(problems)))
+		for _, p := range problems {
+			fmt.Printf("    %s%s:%s %s\n", terminal.BrightCyan, p.Location(), terminal.Reset, p.Message)
+		}
+		fmt.Println()
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid todo files: %d problem(s)", len(problems))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// registerStatusFlagCompletion completes a flag with the built-in and custom statuses.
+func registerStatusFlagCompletion(command *cobra.Command, flagName string) {
+	_ = command.RegisterFlagCompletionFunc(flagName, completeStatus)
+}
+
+// registerPriorityFlagCompletion completes a flag with the priority levels.
+func registerPriorityFlagCompletion(command *cobra.Command, flagName string) {
+	_ = command.RegisterFlagCompletionFunc(flagName, completePriority)
+}
+
+func completeStatus(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return statusCandidates(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func statusCandidates(toComplete string) []string {
+	var out []string
+	for _, s := range types.ValidStatuses() {
+		if strings.HasPrefix(string(s), toComplete) {
+			out = append(out, string(s))
+		}
+	}
+	return out
+}
+
+func completePriority(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var out []string
+	for _, p := range []types.Priority{types.PriorityHigh, types.PriorityMedium, types.PriorityLow} {
+		if strings.HasPrefix(string(p), strings.ToLower(toComplete)) {
+			out = append(out, string(p))
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTodoArgs completes <id|index> arguments with each todo's index and
+// short ID, described by its text. Todos already named in args are skipped.
+func completeTodoArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return todoCandidates(args, toComplete, nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeUndoneTodoArgs is completeTodoArgs without todos that are already done.
+func completeUndoneTodoArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	undone := func(t types.Todo) bool { return t.Status != types.StatusDone }
+	return todoCandidates(args, toComplete, undone), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSingleTodoArg is completeTodoArgs for commands taking exactly one todo.
+func completeSingleTodoArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTodoArgs(cmd, args, toComplete)
+}
+
+// completeStatusArgs completes `todo status`: todos first, then either more
+// todos or the target status.
+func completeStatusArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeTodoArgs(cmd, args, toComplete)
+	}
+	out := statusCandidates(toComplete)
+	out = append(out, todoCandidates(args, toComplete, nil)...)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// todoCandidates returns "index<TAB>text" and "shortID<TAB>text" entries
+// matching toComplete, limited to todos keep accepts (all when nil). Outside a
+// project it returns nothing.
+func todoCandidates(args []string, toComplete string, keep func(types.Todo) bool) []string {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return nil
+	}
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		return nil
+	}
+
+	used := make(map[string]struct{}, len(args))
+	for _, arg := range args {
+		if _, idx, err := storage.ResolveTodo(todos, arg); err == nil {
+			used[todos[idx].ID] = struct{}{}
+		}
+	}
+
+	var out []string
+	for i, todo := range todos {
+		if _, ok := used[todo.ID]; ok {
+			continue
+		}
+		if keep != nil && !keep(todo) {
+			continue
+		}
+		desc := strings.Join(strings.Fields(todo.Text), " ")
+		for _, candidate := range []string{fmt.Sprint(i + 1), shortTodoID(todo.ID)} {
+			if strings.HasPrefix(candidate, toComplete) {
+				out = append(out, candidate+"\t"+desc)
+			}
+		}
+	}
+	return out
+}
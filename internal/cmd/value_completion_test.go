@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func complete(t *testing.T, args ...string) []string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs(append([]string{"__complete"}, args...))
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("__complete %v: %v", args, err)
+	}
+	// The last line is cobra's ":<directive>" marker.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	return lines[:len(lines)-1]
+}
+
+func TestCompletionValues(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	todos := []types.Todo{
+		*types.NewTodo("aaaa1111bbbb", "Fix login"),
+		*types.NewTodo("cccc2222dddd", "Write docs"),
+	}
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if got := complete(t, "list", "--status", "b"); len(got) != 1 || got[0] != "blocked" {
+		t.Fatalf("--status completion = %v", got)
+	}
+	if got := complete(t, "add", "x", "--priority", ""); strings.Join(got, ",") != "high,medium,low" {
+		t.Fatalf("--priority completion = %v", got)
+	}
+
+	got := complete(t, "edit", "")
+	want := []string{"1\tFix login", "aaaa1111\tFix login", "2\tWrite docs", "cccc2222\tWrite docs"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("edit completion = %q; want %q", got, want)
+	}
+	if got := complete(t, "edit", "1", ""); len(got) != 0 {
+		t.Fatalf("edit takes one todo, got %v", got)
+	}
+
+	got = complete(t, "done", "1", "")
+	if strings.Join(got, "|") != "2\tWrite docs|cccc2222\tWrite docs" {
+		t.Fatalf("done should skip todos already listed, got %q", got)
+	}
+
+	got = complete(t, "status", "cccc", "wa")
+	if len(got) != 1 || got[0] != "waiting" {
+		t.Fatalf("status second arg completion = %q", got)
+	}
+}
+
+func TestTodoIDCompletion(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	todos := []types.Todo{
+		*types.NewTodo("aaaa1111bbbb", "Shipped"),
+		*types.NewTodo("cccc2222dddd", "Still open"),
+	}
+	todos[0].Status = types.StatusDone
+	if err := storage.SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if got := complete(t, "done", ""); strings.Join(got, "|") != "2\tStill open|cccc2222\tStill open" {
+		t.Fatalf("done should only offer undone todos, got %q", got)
+	}
+	if got := complete(t, "show", "aaaa"); len(got) != 1 || got[0] != "aaaa1111\tShipped" {
+		t.Fatalf("show completion = %q", got)
+	}
+
+	chdir(t, t.TempDir())
+	if got := complete(t, "show", ""); len(got) != 0 {
+		t.Fatalf("expected no candidates outside a project, got %q", got)
+	}
+}
+
+func TestDirCompletion(t *testing.T) {
+	dir := setupTestProject(t)
+	chdir(t, dir)
+	if err := os.MkdirAll(filepath.Join(dir, "src", "api"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "main.go"), nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	sep := string(os.PathSeparator)
+
+	if got := complete(t, "list", "--project", "src"+sep); strings.Join(got, ",") != filepath.Join("src", "api")+sep {
+		t.Fatalf("--project should complete directories only, got %v", got)
+	}
+	if got := complete(t, "list", "--path", "src"+sep); len(got) != 2 {
+		t.Fatalf("--path should still complete files and directories, got %v", got)
+	}
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"time"
 
@@ -33,12 +32,12 @@ func init() {
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
-	projectRoot, err := storage.FindProjectRoot(".")
+	projectRoot, err := resolveProjectRoot()
 	if err != nil {
 		return err
 	}
 
-	todosPath := filepath.Join(projectRoot, storage.TodosDir, storage.TodosFile)
+	todosDir := filepath.Join(projectRoot, storage.TodosDir)
 	ticker := time.NewTicker(time.Duration(watchInterval) * time.Second)
 	defer ticker.Stop()
 
@@ -74,21 +73,16 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load todos: %w", err)
 	}
 	lastCount = len(todos)
-	if info, err := os.Stat(todosPath); err == nil {
-		lastMod = info.ModTime()
-	}
+	lastMod = storage.TodosModTime(projectRoot)
 	emit(todos, "init")
-	terminal.PrintInfo(fmt.Sprintf("Watching %s (every %ds, Ctrl+C to stop)", todosPath, watchInterval))
+	terminal.PrintInfo(fmt.Sprintf("Watching %s (every %ds, Ctrl+C to stop)", todosDir, watchInterval))
 
 	for range ticker.C {
-		info, err := os.Stat(todosPath)
-		if err != nil {
-			continue
-		}
-		if !info.ModTime().After(lastMod) {
+		mod := storage.TodosModTime(projectRoot)
+		if !mod.After(lastMod) {
 			continue
 		}
-		lastMod = info.ModTime()
+		lastMod = mod
 
 		todos, err := storage.LoadTodos(projectRoot)
 		if err != nil {
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/storage"
+	"github.com/bagadi-alnour/todo-cli/internal/terminal"
+	"github.com/spf13/cobra"
+)
+
+var whichJSON bool
+
+var whichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Show which project and storage files commands will use",
+	Long: `Print the project root found from the current directory, the storage
+paths under .todos/, and the git branch. Exits non-zero when no project is found.`,
+	Example: `  todo which
+  todo which --json`,
+	Args: cobra.NoArgs,
+	RunE: runWhich,
+}
+
+func init() {
+	rootCmd.AddCommand(whichCmd)
+	whichCmd.Flags().BoolVar(&whichJSON, "json", false, "Output as JSON")
+}
+
+// whichInfo describes where commands run from the current directory read and
+// write their data.
+type whichInfo struct {
+	ProjectRoot string `json:"projectRoot"`
+	TodosFile   string `json:"todosFile"`
+	UsersDir    string `json:"usersDir"`
+	UserFile    string `json:"userFile,omitempty"`
+	ConfigFile  string `json:"configFile"`
+	ArchiveFile string `json:"archiveFile"`
+	GitRepo     bool   `json:"gitRepo"`
+	Branch      string `json:"branch,omitempty"`
+}
+
+func runWhich(cmd *cobra.Command, args []string) error {
+	projectRoot, err := resolveProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	info := whichInfo{
+		ProjectRoot: projectRoot,
+		TodosFile:   storage.GetTodosPath(projectRoot),
+		UsersDir:    storage.GetUsersDir(projectRoot),
+		ConfigFile:  storage.GetConfigPath(projectRoot),
+		ArchiveFile: storage.GetArchivePath(projectRoot),
+		GitRepo:     git.IsGitRepoAt(projectRoot),
+	}
+	if slug, err := storage.CurrentUserSlug(); err == nil {
+		info.UserFile = storage.GetUserTodosPath(projectRoot, slug)
+	}
+	if info.GitRepo {
+		info.Branch, _ = git.GetCurrentBranch(projectRoot)
+	}
+
+	if whichJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	printPath := func(label, path string) {
+		marker := ""
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			marker = fmt.Sprintf(" %s(missing)%s", terminal.Dim, terminal.Reset)
+		}
+		fmt.Printf("  %s%-9s%s %s%s\n", terminal.Dim, label, terminal.Reset, path, marker)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %sProject:%s  %s%s%s\n", terminal.Dim, terminal.Reset, terminal.BrightCyan, info.ProjectRoot, terminal.Reset)
+	printPath("Users:", info.UsersDir)
+	if info.UserFile != "" {
+		printPath("You:", info.UserFile)
+	}
+	printPath("Legacy:", info.TodosFile)
+	printPath("Config:", info.ConfigFile)
+	printPath("Archive:", info.ArchiveFile)
+	if info.GitRepo {
+		fmt.Printf("  %sGit:%s      branch %s%s%s\n", terminal.Dim, terminal.Reset, terminal.Green, info.Branch, terminal.Reset)
+	} else {
+		fmt.Printf("  %sGit:%s      %snot a git repository%s\n", terminal.Dim, terminal.Reset, terminal.Dim, terminal.Reset)
+	}
+	fmt.Println()
+	return nil
+}
@@ -75,7 +75,7 @@ func Save(projectRoot string, f *File) error {
 
 // RefreshFromGit rebuilds the contributor list from git shortlog.
 func RefreshFromGit(projectRoot string) (*File, error) {
-	if !git.IsGitRepo() {
+	if !git.IsGitRepoAt(projectRoot) {
 		return nil, fmt.Errorf("not a git repository")
 	}
 	list, err := listFromGit(projectRoot)
@@ -95,7 +95,7 @@ func EnsureLoaded(projectRoot string) (*File, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(f.Contributors) == 0 && git.IsGitRepo() {
+	if len(f.Contributors) == 0 && git.IsGitRepoAt(projectRoot) {
 		return RefreshFromGit(projectRoot)
 	}
 	return f, nil
@@ -284,7 +284,7 @@ func LookupName(projectRoot, email string) string {
 
 // SuggestFromBlame returns top contributors for the given paths via git blame.
 func SuggestFromBlame(projectRoot string, paths []string) ([]Contributor, error) {
-	if !git.IsGitRepo() || len(paths) == 0 {
+	if !git.IsGitRepoAt(projectRoot) || len(paths) == 0 {
 		return nil, nil
 	}
 	counts := map[string]int{}
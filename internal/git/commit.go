@@ -0,0 +1,39 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CommitFile stages path (a file or directory, relative to dir) and commits
+// just that path with message, leaving anything else already staged out of
+// the commit. committed is false, with no error, when dir isn't inside a git
+// work tree or path has no changes.
+func CommitFile(dir, path, message string) (committed bool, err error) {
+	if !IsGitRepoAt(dir) {
+		return false, nil
+	}
+
+	add := exec.Command("git", "add", "--all", "--", path)
+	add.Dir = dir
+	if out, err := add.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("git add %s: %s", path, strings.TrimSpace(string(out)))
+	}
+
+	// diff --quiet exits 1 when there are staged changes under path.
+	diff := exec.Command("git", "diff", "--cached", "--quiet", "--", path)
+	diff.Dir = dir
+	if err := diff.Run(); err == nil {
+		return false, nil
+	} else if _, ok := err.(*exec.ExitError); !ok {
+		return false, fmt.Errorf("git diff: %w", err)
+	}
+
+	commit := exec.Command("git", "commit", "--quiet", "-m", message, "--", path)
+	commit.Dir = dir
+	if out, err := commit.CombinedOutput(); err != nil {
+		return false, fmt.Errorf("git commit: %s", strings.TrimSpace(string(out)))
+	}
+	return true, nil
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	if committed, err := CommitFile(t.TempDir(), ".todos", "todo: add"); committed || err != nil {
+		t.Fatalf("outside a repo: committed=%v err=%v", committed, err)
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	if err := os.MkdirAll(filepath.Join(dir, ".todos", "users"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write(".todos/users/test.json", "one")
+	write("staged.go", "package main")
+	runGit(t, dir, "add", "staged.go")
+
+	committed, err := CommitFile(dir, ".todos", "todo: add one")
+	if err != nil || !committed {
+		t.Fatalf("expected a commit, got committed=%v err=%v", committed, err)
+	}
+	commits, err := GetPathHistory(dir, ".todos", 5)
+	if err != nil || len(commits) != 1 || commits[0].Subject != "todo: add one" {
+		t.Fatalf("unexpected history %+v (%v)", commits, err)
+	}
+	files, err := exec.Command("git", "-C", dir, "show", "--name-only", "--format=", "HEAD").Output()
+	if err != nil || strings.TrimSpace(string(files)) != ".todos/users/test.json" {
+		t.Fatalf("expected only the todo file in the commit, got %q (%v)", files, err)
+	}
+	staged, _ := exec.Command("git", "-C", dir, "diff", "--cached", "--name-only").Output()
+	if strings.TrimSpace(string(staged)) != "staged.go" {
+		t.Fatalf("other staged files should stay staged, got %q", staged)
+	}
+
+	if committed, err := CommitFile(dir, ".todos", "todo: nothing"); committed || err != nil {
+		t.Fatalf("unchanged path: committed=%v err=%v", committed, err)
+	}
+}
@@ -5,19 +5,11 @@ import (
 	"strings"
 )
 
-// IsGitRepo checks if the current directory is inside a git repository
-func IsGitRepo() bool {
-	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	return strings.TrimSpace(string(output)) == "true"
-}
-
-// GetCurrentBranch returns the current git branch name
-func GetCurrentBranch() (string, error) {
+// GetCurrentBranch returns the current branch name of the repository
+// containing dir
+func GetCurrentBranch(dir string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -25,9 +17,11 @@ func GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetCurrentCommit returns the current git commit hash (short version)
-func GetCurrentCommit() (string, error) {
+// GetCurrentCommit returns the current commit hash (short version) of the
+// repository containing dir
+func GetCurrentCommit(dir string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -45,6 +39,18 @@ func GetCurrentCommitFull() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// CommitExists reports whether hash (full or abbreviated) names a commit in
+// the repository containing dir. Commits rebased away stay reported until git
+// garbage-collects them.
+func CommitExists(dir, hash string) bool {
+	if hash == "" || strings.HasPrefix(hash, "-") {
+		return false
+	}
+	cmd := exec.Command("git", "cat-file", "-e", hash+"^{commit}")
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
 // GetRepoRoot returns the root directory of the git repository
 func GetRepoRoot() (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
@@ -55,18 +61,19 @@ func GetRepoRoot() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetGitContext returns both branch and commit in one call
-func GetGitContext() (branch string, commit string, err error) {
-	if !IsGitRepo() {
+// GetGitContext returns both branch and commit of the repository containing
+// dir in one call
+func GetGitContext(dir string) (branch string, commit string, err error) {
+	if !IsGitRepoAt(dir) {
 		return "", "", nil
 	}
 
-	branch, err = GetCurrentBranch()
+	branch, err = GetCurrentBranch(dir)
 	if err != nil {
 		return "", "", err
 	}
 
-	commit, err = GetCurrentCommit()
+	commit, err = GetCurrentCommit(dir)
 	if err != nil {
 		return branch, "", err
 	}
@@ -84,9 +91,11 @@ func HasUncommittedChanges() bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
-// GetRemoteURL returns the URL of the origin remote
-func GetRemoteURL() (string, error) {
+// GetRemoteURL returns the URL of the origin remote of the repository
+// containing dir
+func GetRemoteURL(dir string) (string, error) {
 	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PathCommit is one entry of a path's git history.
+type PathCommit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// GetPathHistory returns up to limit of the most recent commits touching path,
+// newest first. path is resolved relative to dir, which must be inside the
+// repository.
+func GetPathHistory(dir, path string, limit int) ([]PathCommit, error) {
+	cmd := exec.Command("git", "log", "-n", strconv.Itoa(limit), "--format=%h%x09%cI%x09%s", "--", path)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []PathCommit
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, parts[1])
+		commits = append(commits, PathCommit{Hash: parts[0], Date: date, Subject: parts[2]})
+	}
+	return commits, nil
+}
+
+// IsTracked reports whether path (a file, or a directory containing tracked
+// files) is tracked by git, resolved relative to dir.
+func IsTracked(dir, path string) bool {
+	cmd := exec.Command("git", "ls-files", "--error-unmatch", "--", path)
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// IsGitRepoAt reports whether dir is inside a git work tree.
+func IsGitRepoAt(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// HasHEAD reports whether the repository containing dir has at least one
+// commit.
+func HasHEAD(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD")
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// ListHEADFiles returns the files under path in the HEAD commit. Both path
+// and the returned names are relative to dir.
+func ListHEADFiles(dir, path string) ([]string, error) {
+	cmd := exec.Command("git", "ls-tree", "-r", "--name-only", "HEAD", "--", path)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// ShowHEADFile returns the contents of path, relative to dir, as committed in
+// HEAD.
+func ShowHEADFile(dir, path string) ([]byte, error) {
+	cmd := exec.Command("git", "show", "HEAD:./"+filepath.ToSlash(path))
+	cmd.Dir = dir
+	return cmd.Output()
+}
@@ -0,0 +1,103 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestGetPathHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	for i, subject := range []string{"add auth", "fix auth", "touch other"} {
+		name := "auth.go"
+		if i == 2 {
+			name = "other.go"
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(subject), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		runGit(t, dir, "add", name)
+		runGit(t, dir, "commit", "-q", "-m", subject)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "scratch.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	commits, err := GetPathHistory(dir, "auth.go", 5)
+	if err != nil {
+		t.Fatalf("GetPathHistory: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "fix auth" || commits[1].Subject != "add auth" {
+		t.Fatalf("unexpected history: %+v", commits)
+	}
+	if commits[0].Hash == "" || commits[0].Date.IsZero() {
+		t.Fatalf("expected hash and date, got %+v", commits[0])
+	}
+
+	if commits, _ := GetPathHistory(dir, "auth.go", 1); len(commits) != 1 {
+		t.Fatalf("limit not applied: %+v", commits)
+	}
+	if !IsTracked(dir, "auth.go") || IsTracked(dir, "scratch.txt") || IsTracked(dir, "missing.go") {
+		t.Fatal("IsTracked reported the wrong files")
+	}
+}
+
+func TestHEADFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	if HasHEAD(dir) {
+		t.Fatal("HasHEAD true before the first commit")
+	}
+
+	sub := filepath.Join(dir, "app", "data")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "a.json"), []byte("committed"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+	if err := os.WriteFile(filepath.Join(sub, "a.json"), []byte("working"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !HasHEAD(dir) {
+		t.Fatal("HasHEAD false after a commit")
+	}
+
+	app := filepath.Join(dir, "app")
+	files, err := ListHEADFiles(app, "data")
+	if err != nil {
+		t.Fatalf("ListHEADFiles: %v", err)
+	}
+	if len(files) != 1 || files[0] != "data/a.json" {
+		t.Fatalf("ListHEADFiles = %v, want [data/a.json]", files)
+	}
+	data, err := ShowHEADFile(app, files[0])
+	if err != nil || string(data) != "committed" {
+		t.Fatalf("ShowHEADFile = %q, %v; want the committed contents", data, err)
+	}
+	if _, err := ShowHEADFile(app, "data/missing.json"); err == nil {
+		t.Fatal("ShowHEADFile succeeded for a file not in HEAD")
+	}
+}
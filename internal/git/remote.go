@@ -0,0 +1,70 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WebURL converts a remote URL into the repository's https web URL. It accepts
+// scp-style SSH (git@github.com:org/repo.git), ssh:// and http(s):// forms.
+// The second return value is false when the remote cannot be converted.
+func WebURL(remote string) (string, bool) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return "", false
+	}
+
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil || u.Hostname() == "" {
+			return "", false
+		}
+		switch u.Scheme {
+		case "http", "https", "ssh", "git":
+		default:
+			return "", false
+		}
+		host = u.Hostname()
+		path = u.Path
+	} else {
+		// scp-style: [user@]host:path
+		at := strings.LastIndex(remote, "@")
+		rest := remote[at+1:]
+		h, p, ok := strings.Cut(rest, ":")
+		if !ok || h == "" {
+			return "", false
+		}
+		host, path = h, p
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	if path == "" {
+		return "", false
+	}
+	return "https://" + host + "/" + path, true
+}
+
+// CommitURL returns the web URL for a commit on the given remote, or "" when
+// the remote is not recognized. GitLab and Bitbucket use their own path
+// layouts; every other host gets the GitHub-style /commit/<hash>.
+func CommitURL(remote, commit string) string {
+	commit = strings.TrimSpace(commit)
+	if commit == "" {
+		return ""
+	}
+	base, ok := WebURL(remote)
+	if !ok {
+		return ""
+	}
+	host := strings.TrimPrefix(base, "https://")
+	switch {
+	case strings.HasPrefix(host, "gitlab."):
+		return base + "/-/commit/" + commit
+	case strings.HasPrefix(host, "bitbucket.org"):
+		return base + "/commits/" + commit
+	default:
+		return base + "/commit/" + commit
+	}
+}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"os/exec"
 	"strings"
 )
@@ -24,3 +25,13 @@ func GetUserName() (string, error) {
 	}
 	return strings.TrimSpace(string(out)), nil
 }
+
+// SetConfig sets a key in the local config of the repository containing dir.
+func SetConfig(dir, key, value string) error {
+	cmd := exec.Command("git", "config", "--local", key, value)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git config %s: %s", key, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
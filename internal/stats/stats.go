@@ -0,0 +1,117 @@
+// Package stats computes todo summary counts shared by the CLI and web UI.
+package stats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// Report summarizes a todo list
+type Report struct {
+	Total              int            `json:"total"`
+	ByStatus           map[string]int `json:"byStatus"`
+	ByPriority         map[string]int `json:"byPriority"`
+	ByTag              map[string]int `json:"byTag"`
+	ByAssignee         map[string]int `json:"byAssignee,omitempty"`
+	CompletionRate     float64        `json:"completionRate"`
+	AvgAgeDays         float64        `json:"avgAgeDaysOpen"`
+	AvgCompletionHours float64        `json:"avgCompletionHours"`
+	Overdue            int            `json:"overdue"`
+	OpenEstimate       int            `json:"openEstimate"`
+	TimeSpentSeconds   int64          `json:"timeSpentSeconds"` // includes running timers
+}
+
+// Capacity sums the estimates of outstanding (not done) todos
+type Capacity struct {
+	Points      int            `json:"points"`
+	Todos       int            `json:"todos"`
+	Unestimated int            `json:"unestimated"`
+	ByBranch    map[string]int `json:"byBranch"`
+}
+
+// ComputeCapacity sums outstanding estimates overall and per branch. Todos
+// without a branch are counted under the empty branch name.
+func ComputeCapacity(todos []types.Todo) Capacity {
+	c := Capacity{ByBranch: map[string]int{}}
+	for _, t := range todos {
+		if t.Status == types.StatusDone {
+			continue
+		}
+		c.Todos++
+		if t.Estimate == 0 {
+			c.Unestimated++
+		}
+		c.Points += t.Estimate
+		c.ByBranch[t.Context.Branch] += t.Estimate
+	}
+	return c
+}
+
+// CountByStatus counts todos per status, always including every built-in and
+// registered custom status
+func CountByStatus(todos []types.Todo) map[string]int {
+	counts := make(map[string]int)
+	for _, s := range types.ValidStatuses() {
+		counts[string(s)] = 0
+	}
+	for _, t := range todos {
+		counts[string(t.Status)]++
+	}
+	return counts
+}
+
+// Compute builds a Report for todos as of now
+func Compute(todos []types.Todo, now time.Time) Report {
+	r := Report{
+		Total:      len(todos),
+		ByStatus:   CountByStatus(todos),
+		ByPriority: map[string]int{"high": 0, "medium": 0, "low": 0},
+		ByTag:      map[string]int{},
+		ByAssignee: map[string]int{},
+	}
+
+	var tracked time.Duration
+	var openAgeSum float64
+	openCount := 0
+	var completionSum float64
+	doneCount := 0
+	for _, t := range todos {
+		r.ByPriority[string(t.Priority)]++
+		tracked += t.TrackedTime(now)
+		for _, tag := range t.Tags {
+			r.ByTag[strings.ToLower(tag)]++
+		}
+		if t.Assignee != "" {
+			r.ByAssignee[t.Assignee]++
+		}
+		if t.Status == types.StatusOpen {
+			openCount++
+			openAgeSum += now.Sub(t.CreatedAt).Hours() / 24.0
+		}
+		if t.Status != types.StatusDone {
+			r.OpenEstimate += t.Estimate
+		}
+		if t.Status == types.StatusDone && t.CompletedAt != nil {
+			doneCount++
+			completionSum += t.CompletedAt.Sub(t.CreatedAt).Hours()
+		}
+		if t.Status == types.StatusOpen && t.DueAt != nil && t.DueAt.Before(now) {
+			r.Overdue++
+		}
+	}
+
+	r.TimeSpentSeconds = int64(tracked / time.Second)
+	if r.Total > 0 {
+		r.CompletionRate = float64(r.ByStatus["done"]) / float64(r.Total) * 100
+	}
+	if openCount > 0 {
+		r.AvgAgeDays = openAgeSum / float64(openCount)
+	}
+	if doneCount > 0 {
+		r.AvgCompletionHours = completionSum / float64(doneCount)
+	}
+
+	return r
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-48 * time.Hour)
+	completed := now.Add(-24 * time.Hour)
+	overdue := now.Add(-time.Hour)
+
+	todos := []types.Todo{
+		{ID: "1", Status: types.StatusOpen, Priority: types.PriorityHigh, Tags: []string{"api"}, CreatedAt: created, DueAt: &overdue},
+		{ID: "2", Status: types.StatusOpen, Priority: types.PriorityLow, CreatedAt: created},
+		{ID: "3", Status: types.StatusDone, Priority: types.PriorityMedium, Tags: []string{"API", "ui"}, CreatedAt: created, CompletedAt: &completed},
+		{ID: "4", Status: types.StatusBlocked, Priority: types.PriorityHigh, Assignee: "alice@example.com", CreatedAt: created},
+	}
+
+	r := Compute(todos, now)
+
+	if r.Total != 4 {
+		t.Fatalf("expected total 4, got %d", r.Total)
+	}
+	wantStatus := map[string]int{"open": 2, "done": 1, "blocked": 1, "waiting": 0, "tech-debt": 0}
+	for status, want := range wantStatus {
+		if got, ok := r.ByStatus[status]; !ok || got != want {
+			t.Fatalf("status %s: got %d (present=%v) want %d", status, got, ok, want)
+		}
+	}
+	wantPriority := map[string]int{"high": 2, "medium": 1, "low": 1}
+	for p, want := range wantPriority {
+		if r.ByPriority[p] != want {
+			t.Fatalf("priority %s: got %d want %d", p, r.ByPriority[p], want)
+		}
+	}
+	if r.ByTag["api"] != 2 || r.ByTag["ui"] != 1 {
+		t.Fatalf("unexpected tag counts: %v", r.ByTag)
+	}
+	if r.ByAssignee["alice@example.com"] != 1 {
+		t.Fatalf("unexpected assignee counts: %v", r.ByAssignee)
+	}
+	if r.CompletionRate != 25 {
+		t.Fatalf("expected completion rate 25, got %v", r.CompletionRate)
+	}
+	if r.AvgAgeDays != 2 {
+		t.Fatalf("expected avg open age 2 days, got %v", r.AvgAgeDays)
+	}
+	if r.AvgCompletionHours != 24 {
+		t.Fatalf("expected avg completion 24h, got %v", r.AvgCompletionHours)
+	}
+	if r.Overdue != 1 {
+		t.Fatalf("expected 1 overdue, got %d", r.Overdue)
+	}
+}
+
+func TestComputeTimeSpent(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-10 * time.Minute)
+	todos := []types.Todo{
+		{ID: "1", Status: types.StatusDone, TimeSpent: types.Duration(time.Hour)},
+		{ID: "2", Status: types.StatusOpen, TimeSpent: types.Duration(5 * time.Minute), StartedAt: &started},
+	}
+	if r := Compute(todos, now); r.TimeSpentSeconds != 75*60 {
+		t.Fatalf("expected 4500 seconds tracked, got %d", r.TimeSpentSeconds)
+	}
+}
+
+func TestComputeEmpty(t *testing.T) {
+	r := Compute(nil, time.Now())
+	if r.Total != 0 || r.CompletionRate != 0 {
+		t.Fatalf("expected empty report, got %+v", r)
+	}
+	if len(r.ByStatus) != len(types.ValidStatuses()) {
+		t.Fatalf("expected all statuses present, got %v", r.ByStatus)
+	}
+}
+
+func TestComputeCapacity(t *testing.T) {
+	todos := []types.Todo{
+		{ID: "1", Status: types.StatusOpen, Estimate: 3, Context: types.Context{Branch: "main"}},
+		{ID: "2", Status: types.StatusBlocked, Estimate: 5, Context: types.Context{Branch: "feature/x"}},
+		{ID: "3", Status: types.StatusOpen, Context: types.Context{Branch: "main"}},
+		{ID: "4", Status: types.StatusDone, Estimate: 8, Context: types.Context{Branch: "main"}},
+		{ID: "5", Status: types.StatusWaiting, Estimate: 2},
+	}
+
+	c := ComputeCapacity(todos)
+	if c.Points != 10 || c.Todos != 4 || c.Unestimated != 1 {
+		t.Fatalf("unexpected capacity: %+v", c)
+	}
+	want := map[string]int{"main": 3, "feature/x": 5, "": 2}
+	for branch, points := range want {
+		if c.ByBranch[branch] != points {
+			t.Fatalf("branch %q: got %d want %d (%v)", branch, c.ByBranch[branch], points, c.ByBranch)
+		}
+	}
+
+	if r := Compute(todos, time.Now()); r.OpenEstimate != 10 {
+		t.Fatalf("expected open estimate 10, got %d", r.OpenEstimate)
+	}
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/git"
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// LoadCommittedTodos reads the todos as committed in git HEAD: every
+// .todos/users/*.json plus the legacy .todos/todos.json, merged the way
+// LoadTodos merges the working files. found is false when the repository has
+// no commits yet or HEAD contains none of those files.
+func LoadCommittedTodos(projectRoot string) (todos []types.Todo, found bool, err error) {
+	if !git.IsGitRepoAt(projectRoot) {
+		return nil, false, fmt.Errorf("%s is not inside a git repository", projectRoot)
+	}
+	if !git.HasHEAD(projectRoot) {
+		return []types.Todo{}, false, nil
+	}
+
+	files, err := git.ListHEADFiles(projectRoot, TodosDir)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list committed todo files: %w", err)
+	}
+
+	usersPrefix := path.Join(TodosDir, UsersDir) + "/"
+	legacy := path.Join(TodosDir, TodosFile)
+	var userFiles []string
+	hasLegacy := false
+	for _, name := range files {
+		switch {
+		case name == legacy:
+			hasLegacy = true
+		case strings.HasPrefix(name, usersPrefix) && !strings.Contains(strings.TrimPrefix(name, usersPrefix), "/") &&
+			strings.HasSuffix(name, ".json"):
+			userFiles = append(userFiles, name)
+		}
+	}
+	if len(userFiles) == 0 && !hasLegacy {
+		return []types.Todo{}, false, nil
+	}
+
+	out := []types.Todo{}
+	position := make(map[string]int)
+	add := func(list []types.Todo, owner string) {
+		for _, t := range list {
+			if t.CreatedBy == "" {
+				t.CreatedBy = owner
+			}
+			if i, ok := position[t.ID]; ok {
+				out[i] = t
+				continue
+			}
+			position[t.ID] = len(out)
+			out = append(out, t)
+		}
+	}
+
+	for _, name := range userFiles {
+		list, err := loadCommittedFile(projectRoot, name)
+		if err != nil {
+			return nil, false, err
+		}
+		add(list, ownerSlugFromFilename(path.Base(name)))
+	}
+	// Legacy todos are moved into per-user files on load; anything still
+	// committed there counts only if no user file has claimed it.
+	if hasLegacy {
+		list, err := loadCommittedFile(projectRoot, legacy)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, t := range list {
+			if _, ok := position[t.ID]; !ok {
+				add([]types.Todo{t}, legacyOwnerSlug)
+			}
+		}
+	}
+
+	normalizeTodos(out)
+	return out, true, nil
+}
+
+func loadCommittedFile(projectRoot, name string) ([]types.Todo, error) {
+	data, err := git.ShowHEADFile(projectRoot, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD:%s: %w", name, err)
+	}
+	todos, err := parseTodoData(data)
+	if err != nil {
+		return nil, fmt.Errorf("HEAD:%s: %w", name, err)
+	}
+	return todos, nil
+}
@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// ConfigProblem describes one invalid or unknown entry in config.json
+type ConfigProblem struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// configFieldValidators checks the raw JSON value of each known config key.
+var configFieldValidators = map[string]func(raw json.RawMessage) error{
+	"version": func(raw json.RawMessage) error {
+		var v float64
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		if v != float64(int(v)) || v < 1 || int(v) > types.DefaultConfig().Version {
+			return fmt.Errorf("unsupported version %v", v)
+		}
+		return nil
+	},
+	"autoGit": func(raw json.RawMessage) error {
+		var v bool
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		return nil
+	},
+	"defaultBranch": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		return checkBranchName(v)
+	},
+	"editor": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		return nil
+	},
+	"lastSelected": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		return nil
+	},
+	"theme": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		if !types.IsValidTheme(v) {
+			return fmt.Errorf("unknown theme %q (use %s)", v, strings.Join(types.Themes, ", "))
+		}
+		return nil
+	},
+	"locale": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		if !types.IsValidLocale(v) {
+			return fmt.Errorf("%q is not a language tag like en-US", v)
+		}
+		return nil
+	},
+	"focusScope": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		if !types.IsValidFocusScope(v) {
+			return fmt.Errorf("unknown focus scope %q (use %s)", v, strings.Join(types.FocusScopes, ", "))
+		}
+		return nil
+	},
+	"customStatuses": func(raw json.RawMessage) error {
+		var v []types.CustomStatus
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a list of {name, icon, color}")
+		}
+		return types.ValidateCustomStatuses(v)
+	},
+	"autoCommit": func(raw json.RawMessage) error {
+		var v bool
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be true or false")
+		}
+		return nil
+	},
+	"commitMessage": func(raw json.RawMessage) error {
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a string")
+		}
+		return checkCommitMessage(v)
+	},
+	"historyLimit": func(raw json.RawMessage) error {
+		var v float64
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		if v != float64(int(v)) || v < 0 {
+			return fmt.Errorf("must be a whole number of snapshots, 0 or more")
+		}
+		return nil
+	},
+	"statusTransitions": func(raw json.RawMessage) error {
+		var v map[types.Status][]types.Status
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("must map each status to a list of statuses")
+		}
+		// The status names are checked in validateConfigData against the
+		// customStatuses of the same file.
+		return nil
+	},
+}
+
+// checkBranchName rejects names git would never accept for a branch.
+func checkBranchName(name string) error {
+	if strings.ContainsAny(name, " \t\n~^:?*[\\") {
+		return fmt.Errorf("%q is not a valid branch name", name)
+	}
+	return nil
+}
+
+// checkCommitMessage renders a commit message template with sample data.
+func checkCommitMessage(tmpl string) error {
+	_, err := types.RenderCommitMessage(tmpl, types.NewCommitMessageData("done", []string{"Example todo"}))
+	return err
+}
+
+// ConfigWarning returns a warning about a loaded config that still works, or
+// "" when there is nothing to say. A version newer than this build
+// understands only warns, since the known fields still load.
+func ConfigWarning(config *types.Config) string {
+	if supported := types.DefaultConfig().Version; config.Version > supported {
+		return fmt.Sprintf("config.json version %d is newer than this todo build supports (%d); unknown settings are ignored", config.Version, supported)
+	}
+	return ""
+}
+
+// HistoryLimit returns how many snapshots the project keeps: the configured
+// historyLimit, or types.DefaultHistoryLimit when it isn't set.
+func HistoryLimit(config *types.Config) int {
+	if config.HistoryLimit == nil {
+		return types.DefaultHistoryLimit
+	}
+	return *config.HistoryLimit
+}
+
+// checkLoadedConfig validates a decoded config. Invalid values are errors
+// rather than silently wrong behavior; see ConfigWarning for what only warns.
+func checkLoadedConfig(config *types.Config) error {
+	if config.Version < 0 {
+		return fmt.Errorf("version: unsupported version %d", config.Version)
+	}
+	if err := checkBranchName(config.DefaultBranch); err != nil {
+		return fmt.Errorf("defaultBranch: %w", err)
+	}
+	if config.HistoryLimit != nil && *config.HistoryLimit < 0 {
+		return fmt.Errorf("historyLimit: must be 0 or more, got %d", *config.HistoryLimit)
+	}
+	return nil
+}
+
+// ValidateConfig checks config.json for unknown keys and values of the wrong
+// type or out of range. A missing config file has no problems.
+func ValidateConfig(projectRoot string) ([]ConfigProblem, error) {
+	data, err := os.ReadFile(GetConfigPath(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	problems, _ := validateConfigData(data)
+	return problems, nil
+}
+
+// FixConfig validates config.json, drops unknown keys, resets invalid fields
+// to their defaults, and saves the result. It returns the problems it fixed.
+func FixConfig(projectRoot string) ([]ConfigProblem, error) {
+	data, err := os.ReadFile(GetConfigPath(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	problems, fields := validateConfigData(data)
+	if len(problems) == 0 {
+		return nil, nil
+	}
+
+	cfg := types.DefaultConfig()
+	if fields != nil {
+		defaults := make(map[string]json.RawMessage)
+		defaultData, err := json.Marshal(types.DefaultConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		if err := json.Unmarshal(defaultData, &defaults); err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+
+		for _, p := range problems {
+			if _, known := configFieldValidators[p.Key]; !known {
+				delete(fields, p.Key)
+				continue
+			}
+			if def, ok := defaults[p.Key]; ok {
+				fields[p.Key] = def
+			} else {
+				delete(fields, p.Key)
+			}
+		}
+
+		fixed, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		if err := json.Unmarshal(fixed, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	if err := SaveConfig(projectRoot, cfg); err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// validateConfigData returns the problems found in raw config JSON along with
+// the decoded top-level fields (nil when the JSON itself is unreadable).
+func validateConfigData(data []byte) ([]ConfigProblem, map[string]json.RawMessage) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(cleanJSON(data), &fields); err != nil {
+		return []ConfigProblem{{Key: "", Message: fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []ConfigProblem
+	for _, key := range keys {
+		validate, known := configFieldValidators[key]
+		if !known {
+			problems = append(problems, ConfigProblem{Key: key, Message: "unknown key"})
+			continue
+		}
+		if err := validate(fields[key]); err != nil {
+			problems = append(problems, ConfigProblem{Key: key, Message: err.Error()})
+			continue
+		}
+		if key == "statusTransitions" {
+			if err := checkStatusTransitions(fields); err != nil {
+				problems = append(problems, ConfigProblem{Key: key, Message: err.Error()})
+			}
+		}
+	}
+	if _, ok := fields["version"]; !ok {
+		problems = append(problems, ConfigProblem{Key: "version", Message: "missing"})
+	}
+	return problems, fields
+}
+
+// checkStatusTransitions checks that statusTransitions only names built-in
+// statuses and the customStatuses defined next to it, not whatever statuses
+// happen to be registered.
+func checkStatusTransitions(fields map[string]json.RawMessage) error {
+	var transitions map[types.Status][]types.Status
+	if err := json.Unmarshal(fields["statusTransitions"], &transitions); err != nil {
+		return err
+	}
+	var custom []types.CustomStatus
+	if raw, ok := fields["customStatuses"]; ok {
+		// An invalid customStatuses is reported under its own key.
+		_ = json.Unmarshal(raw, &custom)
+	}
+	return types.ValidateStatusTransitions(transitions, custom)
+}
+
+// RegisterCustomStatuses loads the project's custom statuses from config.json
+// and registers them with types.SetCustomStatuses. An invalid set is rejected
+// and only the built-ins stay registered.
+func RegisterCustomStatuses(projectRoot string) error {
+	types.SetCustomStatuses(nil)
+	config, err := LoadConfig(projectRoot)
+	if err != nil {
+		return err
+	}
+	if err := types.ValidateCustomStatuses(config.CustomStatuses); err != nil {
+		return err
+	}
+	types.SetCustomStatuses(config.CustomStatuses)
+	return nil
+}
@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// ConfigField is a config.json key that `todo config --set/--get/--unset`
+// can address by name. Set parses and validates a string value; Unset
+// restores the default.
+type ConfigField struct {
+	Key   string
+	Help  string
+	Get   func(cfg *types.Config) string
+	Set   func(cfg *types.Config, value string) error
+	Unset func(cfg *types.Config)
+}
+
+// configFields lists the settable keys, named as in config.json. version is
+// managed by the tool and deliberately absent.
+var configFields = []ConfigField{
+	{
+		Key:  "autoGit",
+		Help: "capture branch/commit on add (true/false)",
+		Get:  func(cfg *types.Config) string { return strconv.FormatBool(cfg.AutoGit) },
+		Set: func(cfg *types.Config, value string) error {
+			v, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("must be true or false")
+			}
+			cfg.AutoGit = v
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.AutoGit = types.DefaultConfig().AutoGit },
+	},
+	{
+		Key:  "defaultBranch",
+		Help: "branch recorded when git context is unavailable",
+		Get:  func(cfg *types.Config) string { return cfg.DefaultBranch },
+		Set: func(cfg *types.Config, value string) error {
+			value = strings.TrimSpace(value)
+			if err := checkBranchName(value); err != nil {
+				return err
+			}
+			cfg.DefaultBranch = value
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.DefaultBranch = "" },
+	},
+	{
+		Key:  "editor",
+		Help: "editor command for todo open and add --edit",
+		Get:  func(cfg *types.Config) string { return cfg.Editor },
+		Set: func(cfg *types.Config, value string) error {
+			cfg.Editor = strings.TrimSpace(value)
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.Editor = "" },
+	},
+	{
+		Key:  "theme",
+		Help: "terminal colors: " + strings.Join(types.Themes, ", "),
+		Get: func(cfg *types.Config) string {
+			if cfg.Theme == "" {
+				return "default"
+			}
+			return cfg.Theme
+		},
+		Set: func(cfg *types.Config, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if !types.IsValidTheme(value) {
+				return fmt.Errorf("must be one of %s", strings.Join(types.Themes, ", "))
+			}
+			if value == "default" {
+				value = ""
+			}
+			cfg.Theme = value
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.Theme = "" },
+	},
+	{
+		Key:  "locale",
+		Help: "language tag for dates in the web UI, e.g. " + types.DefaultLocale,
+		Get: func(cfg *types.Config) string {
+			if cfg.Locale == "" {
+				return types.DefaultLocale
+			}
+			return cfg.Locale
+		},
+		Set: func(cfg *types.Config, value string) error {
+			value = strings.TrimSpace(value)
+			if !types.IsValidLocale(value) {
+				return fmt.Errorf("%q is not a language tag like %s", value, types.DefaultLocale)
+			}
+			if value == types.DefaultLocale {
+				value = ""
+			}
+			cfg.Locale = value
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.Locale = "" },
+	},
+	{
+		Key:  "focusScope",
+		Help: "what todo focus shows by default: " + strings.Join(types.FocusScopes, ", "),
+		Get: func(cfg *types.Config) string {
+			if cfg.FocusScope == "" {
+				return "branch"
+			}
+			return cfg.FocusScope
+		},
+		Set: func(cfg *types.Config, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if !types.IsValidFocusScope(value) {
+				return fmt.Errorf("must be one of %s", strings.Join(types.FocusScopes, ", "))
+			}
+			if value == "branch" {
+				value = ""
+			}
+			cfg.FocusScope = value
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.FocusScope = "" },
+	},
+	{
+		Key:  "autoCommit",
+		Help: "commit the todo files after add, done, and delete (true/false)",
+		Get:  func(cfg *types.Config) string { return strconv.FormatBool(cfg.AutoCommit) },
+		Set: func(cfg *types.Config, value string) error {
+			v, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("must be true or false")
+			}
+			cfg.AutoCommit = v
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.AutoCommit = false },
+	},
+	{
+		Key:  "commitMessage",
+		Help: "Go template for those commits, e.g. " + types.DefaultCommitMessage,
+		Get: func(cfg *types.Config) string {
+			if cfg.CommitMessage == "" {
+				return types.DefaultCommitMessage
+			}
+			return cfg.CommitMessage
+		},
+		Set: func(cfg *types.Config, value string) error {
+			if err := checkCommitMessage(value); err != nil {
+				return err
+			}
+			if value == types.DefaultCommitMessage {
+				value = ""
+			}
+			cfg.CommitMessage = value
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.CommitMessage = "" },
+	},
+	{
+		Key:  "historyLimit",
+		Help: fmt.Sprintf("snapshots kept in .todos/snapshots (default %d, 0 disables them)", types.DefaultHistoryLimit),
+		Get:  func(cfg *types.Config) string { return strconv.Itoa(HistoryLimit(cfg)) },
+		Set: func(cfg *types.Config, value string) error {
+			v, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || v < 0 {
+				return fmt.Errorf("must be a whole number of snapshots, 0 or more")
+			}
+			if v == types.DefaultHistoryLimit {
+				cfg.HistoryLimit = nil
+				return nil
+			}
+			cfg.HistoryLimit = &v
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.HistoryLimit = nil },
+	},
+	{
+		Key:  "lastSelected",
+		Help: "todo ID the interactive list opens on",
+		Get:  func(cfg *types.Config) string { return cfg.LastSelected },
+		Set: func(cfg *types.Config, value string) error {
+			cfg.LastSelected = strings.TrimSpace(value)
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.LastSelected = "" },
+	},
+	{
+		Key:  "customStatuses",
+		Help: `JSON list of {"name", "icon", "color"}`,
+		Get: func(cfg *types.Config) string {
+			if len(cfg.CustomStatuses) == 0 {
+				return "[]"
+			}
+			data, _ := json.Marshal(cfg.CustomStatuses)
+			return string(data)
+		},
+		Set: func(cfg *types.Config, value string) error {
+			var statuses []types.CustomStatus
+			if err := json.Unmarshal([]byte(value), &statuses); err != nil {
+				return fmt.Errorf("must be a JSON list of {name, icon, color}")
+			}
+			if err := types.ValidateCustomStatuses(statuses); err != nil {
+				return err
+			}
+			cfg.CustomStatuses = statuses
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.CustomStatuses = nil },
+	},
+	{
+		Key:  "statusTransitions",
+		Help: `JSON object mapping a status to the statuses it may move to`,
+		Get: func(cfg *types.Config) string {
+			if len(cfg.StatusTransitions) == 0 {
+				return "{}"
+			}
+			data, _ := json.Marshal(cfg.StatusTransitions)
+			return string(data)
+		},
+		Set: func(cfg *types.Config, value string) error {
+			var transitions map[types.Status][]types.Status
+			if err := json.Unmarshal([]byte(value), &transitions); err != nil {
+				return fmt.Errorf(`must be a JSON object like {"done": ["open"]}`)
+			}
+			if err := types.ValidateStatusTransitions(transitions, cfg.CustomStatuses); err != nil {
+				return err
+			}
+			if len(transitions) == 0 {
+				transitions = nil
+			}
+			cfg.StatusTransitions = transitions
+			return nil
+		},
+		Unset: func(cfg *types.Config) { cfg.StatusTransitions = nil },
+	},
+}
+
+// ConfigFields returns the keys settable with `todo config --set`.
+func ConfigFields() []ConfigField {
+	return configFields
+}
+
+// LookupConfigField finds a settable config key. Matching ignores case and
+// underscores or dashes, so default_branch and default-branch both name
+// defaultBranch. Unknown keys produce an error listing the valid ones.
+func LookupConfigField(key string) (ConfigField, error) {
+	want := configKeyFold(key)
+	keys := make([]string, 0, len(configFields))
+	for _, f := range configFields {
+		if configKeyFold(f.Key) == want {
+			return f, nil
+		}
+		keys = append(keys, f.Key)
+	}
+	return ConfigField{}, fmt.Errorf("unknown config key: %q\n\nValid keys:\n  %s", key, strings.Join(keys, ", "))
+}
+
+func configKeyFold(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(strings.TrimSpace(key)))
+}
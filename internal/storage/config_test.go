@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestValidateAndFixConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	problems, err := ValidateConfig(dir)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected fresh config to be valid, got %+v", problems)
+	}
+
+	bad := `{"version": 1, "autoGit": "yes", "defaultBranch": "main", "colour": "blue"}`
+	if err := os.WriteFile(GetConfigPath(dir), []byte(bad), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	problems, err = ValidateConfig(dir)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %+v", problems)
+	}
+	keys := map[string]bool{}
+	for _, p := range problems {
+		keys[p.Key] = true
+	}
+	if !keys["autoGit"] || !keys["colour"] {
+		t.Fatalf("expected autoGit and colour problems, got %+v", problems)
+	}
+
+	fixed, err := FixConfig(dir)
+	if err != nil {
+		t.Fatalf("fix: %v", err)
+	}
+	if len(fixed) != 2 {
+		t.Fatalf("expected 2 fixed problems, got %+v", fixed)
+	}
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.AutoGit {
+		t.Fatal("expected autoGit reset to default true")
+	}
+	if cfg.DefaultBranch != "main" {
+		t.Fatalf("expected valid defaultBranch to be kept, got %q", cfg.DefaultBranch)
+	}
+
+	problems, err = ValidateConfig(dir)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected config to be valid after fix, got %+v", problems)
+	}
+}
+
+func TestValidateConfigStatusTransitionsUsesFileStatuses(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	// A status registered by another project must not make this file valid.
+	types.SetCustomStatuses([]types.CustomStatus{{Name: "in-qa"}})
+	t.Cleanup(func() { types.SetCustomStatuses(nil) })
+
+	for _, tc := range []struct {
+		config string
+		valid  bool
+	}{
+		{`{"version": 1, "customStatuses": [{"name": "in-review"}], "statusTransitions": {"open": ["in-review"], "in-review": ["done"]}}`, true},
+		{`{"version": 1, "statusTransitions": {"open": ["in-qa"]}}`, false},
+		{`{"version": 1, "customStatuses": [{"name": "in-review"}], "statusTransitions": {"in-qa": ["done"]}}`, false},
+	} {
+		if err := os.WriteFile(GetConfigPath(dir), []byte(tc.config), 0644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		problems, err := ValidateConfig(dir)
+		if err != nil {
+			t.Fatalf("validate: %v", err)
+		}
+		if tc.valid && len(problems) != 0 {
+			t.Errorf("%s: expected no problems, got %+v", tc.config, problems)
+		}
+		if !tc.valid && (len(problems) != 1 || problems[0].Key != "statusTransitions") {
+			t.Errorf("%s: expected a statusTransitions problem, got %+v", tc.config, problems)
+		}
+	}
+}
+
+func TestFixConfigInvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	if err := os.WriteFile(GetConfigPath(dir), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	problems, err := ValidateConfig(dir)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem for invalid JSON, got %+v", problems)
+	}
+
+	if _, err := FixConfig(dir); err != nil {
+		t.Fatalf("fix: %v", err)
+	}
+	if _, err := LoadConfig(dir); err != nil {
+		t.Fatalf("expected config to load after fix: %v", err)
+	}
+}
+
+func TestRegisterCustomStatuses(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	t.Cleanup(func() { types.SetCustomStatuses(nil) })
+
+	cfg := types.DefaultConfig()
+	cfg.CustomStatuses = []types.CustomStatus{{Name: "in-review", Icon: "👀", Color: "cyan"}}
+	if err := SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	if err := RegisterCustomStatuses(dir); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if !types.Status("in-review").IsValid() || types.Status("in-review").IsBuiltin() {
+		t.Fatal("expected in-review to be a valid custom status")
+	}
+	if types.Status("in-qa").IsValid() {
+		t.Fatal("unregistered status should stay invalid")
+	}
+
+	for _, bad := range [][]types.CustomStatus{
+		{{Name: "done"}},
+		{{Name: "In Review"}},
+		{{Name: "review"}, {Name: "review"}},
+		{{Name: "review", Color: "mauve"}},
+	} {
+		cfg.CustomStatuses = bad
+		if err := SaveConfig(dir, cfg); err != nil {
+			t.Fatalf("save config: %v", err)
+		}
+		if err := RegisterCustomStatuses(dir); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+		if len(types.CustomStatuses()) != 0 {
+			t.Errorf("rejected set %+v should leave only built-ins", bad)
+		}
+		problems, err := ValidateConfig(dir)
+		if err != nil || len(problems) != 1 || problems[0].Key != "customStatuses" {
+			t.Errorf("expected a customStatuses problem for %+v, got %+v (%v)", bad, problems, err)
+		}
+	}
+}
+
+func TestSaveConfigIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	before, err := os.ReadFile(GetConfigPath(dir))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	// A reader holding the old file must keep seeing complete old contents;
+	// an in-place write would truncate it under them.
+	old, err := os.Open(GetConfigPath(dir))
+	if err != nil {
+		t.Fatalf("open config: %v", err)
+	}
+	defer old.Close()
+
+	cfg := types.DefaultConfig()
+	cfg.Editor = "nvim"
+	if err := SaveConfig(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	held, err := io.ReadAll(old)
+	if err != nil {
+		t.Fatalf("read old handle: %v", err)
+	}
+	if string(held) != string(before) {
+		t.Fatalf("old handle saw %q, want the previous contents", held)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, TodosDir))
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".tmp-") {
+			t.Fatalf("temp file left behind: %s", e.Name())
+		}
+	}
+	if loaded, err := LoadConfig(dir); err != nil || loaded.Editor != "nvim" {
+		t.Fatalf("LoadConfig = %+v, %v", loaded, err)
+	}
+}
+
+func TestLoadConfigChecksVersionAndBranch(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(GetConfigPath(dir), []byte(content), 0644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	write(`{"version": -1, "autoGit": true}`)
+	if _, err := LoadConfig(dir); err == nil {
+		t.Fatal("expected an error for a negative version")
+	}
+
+	write(`{"version": 1, "autoGit": true, "defaultBranch": "my branch"}`)
+	if _, err := LoadConfig(dir); err == nil {
+		t.Fatal("expected an error for a branch name with spaces")
+	}
+
+	// A newer version only warns: the fields this build knows still load.
+	write(`{"version": 99, "autoGit": false}`)
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("newer version should load with a warning: %v", err)
+	}
+	if cfg.AutoGit {
+		t.Fatal("expected autoGit false from the newer config")
+	}
+	if msg := ConfigWarning(cfg); !strings.Contains(msg, "version 99") {
+		t.Fatalf("expected a version warning, got %q", msg)
+	}
+	if msg := ConfigWarning(types.DefaultConfig()); msg != "" {
+		t.Fatalf("expected no warning for the default config, got %q", msg)
+	}
+}
+
+func TestConfigFieldsRoundTrip(t *testing.T) {
+	cfg := types.DefaultConfig()
+	values := map[string]string{
+		"autoGit":            "false",
+		"default_branch":     "develop",
+		"EDITOR":             "nvim -f",
+		"last-selected":      "abc123",
+		"focus_scope":        "all",
+		"customStatuses":     `[{"name":"review","icon":"R","color":"cyan"}]`,
+		"status_transitions": `{"done":["open"]}`,
+		"auto_commit":        "true",
+		"commitMessage":      "todo {{.Action}}",
+		"locale":             "de-DE",
+		"history_limit":      "5",
+	}
+	for key, value := range values {
+		field, err := LookupConfigField(key)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", key, err)
+		}
+		if err := field.Set(cfg, value); err != nil {
+			t.Fatalf("set %s=%s: %v", key, value, err)
+		}
+	}
+	if cfg.AutoGit || cfg.DefaultBranch != "develop" || cfg.Editor != "nvim -f" || cfg.LastSelected != "abc123" || cfg.FocusScope != "all" || len(cfg.CustomStatuses) != 1 || len(cfg.StatusTransitions) != 1 || !cfg.AutoCommit || cfg.CommitMessage != "todo {{.Action}}" || cfg.Locale != "de-DE" || HistoryLimit(cfg) != 5 {
+		t.Fatalf("unexpected config after set: %+v", cfg)
+	}
+
+	for _, f := range ConfigFields() {
+		got := f.Get(cfg)
+		if err := f.Set(cfg, got); err != nil {
+			t.Fatalf("%s: setting the value from Get (%q) failed: %v", f.Key, got, err)
+		}
+		f.Unset(cfg)
+		if _, known := configFieldValidators[f.Key]; !known {
+			t.Fatalf("%s has no validator in configFieldValidators", f.Key)
+		}
+	}
+	if got, want := *cfg, *types.DefaultConfig(); got.AutoGit != want.AutoGit || got.DefaultBranch != "" || got.Editor != "" || got.LastSelected != "" || got.FocusScope != "" || got.CustomStatuses != nil || got.StatusTransitions != nil || got.AutoCommit || got.CommitMessage != "" || got.Locale != "" || got.HistoryLimit != nil {
+		t.Fatalf("unset should restore defaults, got %+v", got)
+	}
+
+	field, _ := LookupConfigField("autoGit")
+	if err := field.Set(cfg, "maybe"); err == nil {
+		t.Fatal("expected an error for a non-boolean autoGit")
+	}
+	field, _ = LookupConfigField("defaultBranch")
+	if err := field.Set(cfg, "bad branch"); err == nil {
+		t.Fatal("expected an error for an invalid branch name")
+	}
+	field, _ = LookupConfigField("focusScope")
+	if err := field.Set(cfg, "team"); err == nil {
+		t.Fatal("expected an error for an unknown focus scope")
+	}
+	field, _ = LookupConfigField("locale")
+	if err := field.Set(cfg, "en_US"); err == nil {
+		t.Fatal("expected an error for a locale that isn't a language tag")
+	}
+	field, _ = LookupConfigField("statusTransitions")
+	if err := field.Set(cfg, `{"done":["shipped"]}`); err == nil {
+		t.Fatal("expected an error for an unknown status in statusTransitions")
+	}
+	if _, err := LookupConfigField("stale_days"); err == nil || !strings.Contains(err.Error(), "defaultBranch") {
+		t.Fatalf("expected an unknown key error listing valid keys, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// utf8BOM is the byte order mark some Windows editors prepend to UTF-8 files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// cleanJSON strips a leading byte order mark and surrounding whitespace,
+// neither of which encoding/json accepts.
+func cleanJSON(data []byte) []byte {
+	return bytes.TrimSpace(bytes.TrimPrefix(data, utf8BOM))
+}
+
+// decodeTodoFile parses a todo file in either the versioned {version, todos}
+// shape or the pre-versioning bare array; bare reports the latter.
+func decodeTodoFile(raw []byte) (todoFile types.TodoFile, bare bool, err error) {
+	data := cleanJSON(raw)
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &todoFile.Todos); err != nil {
+			return todoFile, true, describeJSONError(raw, err)
+		}
+		return todoFile, true, nil
+	}
+	if err := json.Unmarshal(data, &todoFile); err != nil {
+		return todoFile, false, describeJSONError(raw, err)
+	}
+	return todoFile, false, nil
+}
+
+// describeJSONError adds the line and column of syntax and type errors so the
+// problem can be found in the file. raw is the file as read: err comes from
+// decoding cleanJSON(raw), so the bytes cleanJSON dropped at the start are
+// added back. Columns count characters, not bytes, and a byte order mark
+// counts toward the byte offset but not the column, since editors don't show
+// it.
+func describeJSONError(raw []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	text := bytes.TrimPrefix(raw, utf8BOM)
+	bom := int64(len(raw) - len(text))
+	offset += int64(len(text) - len(bytes.TrimLeftFunc(text, unicode.IsSpace)))
+
+	// Offset counts the bytes read including the offending one.
+	before := text[:min(max(offset-1, 0), int64(len(text)))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return fmt.Errorf("%w (line %d, column %d, byte %d)", err, line, col, offset+bom)
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestLoadTodosStripsBOM(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	content := fmt.Sprintf("\ufeff\n  {\"version\": %d, \"todos\": [{\"id\": \"w1\", \"text\": \"from windows\", \"status\": \"open\"}]}\r\n", types.TodoFileVersion)
+	path := writeUserFile(t, dir, "alice-example.json", content)
+
+	todos, err := LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Text != "from windows" {
+		t.Fatalf("unexpected todos: %+v", todos)
+	}
+
+	data, _ := os.ReadFile(path)
+	if bytes.HasPrefix(data, utf8BOM) {
+		t.Fatal("expected the BOM to be removed when the file was repaired")
+	}
+}
+
+func TestLoadTodosReportsErrorPosition(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	writeUserFile(t, dir, "alice-example.json", "{\n  \"version\": 2,\n  \"todos\": [}\n}")
+
+	_, err := LoadTodos(dir)
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if !strings.Contains(err.Error(), "line 3, column 13") {
+		t.Fatalf("expected the error position, got %v", err)
+	}
+
+	// Leading blank lines and a byte order mark are trimmed before decoding
+	// but still count toward the position in the file.
+	writeUserFile(t, dir, "alice-example.json", "\ufeff\n\n{\n  \"version\": 2,\n  \"todos\": [}\n}")
+	_, err = LoadTodos(dir)
+	if err == nil || !strings.Contains(err.Error(), "line 5, column 13, byte 36)") {
+		t.Fatalf("expected the position in the original file, got %v", err)
+	}
+
+	// Multi-byte characters before the error count as one column each.
+	writeUserFile(t, dir, "alice-example.json", "{\n  \"version\": 2,\n  \"todos\": [{\"text\": \"café ☕\" }}]\n}")
+	_, err = LoadTodos(dir)
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 32") {
+		t.Fatalf("expected a column counted in characters, got %v", err)
+	}
+}
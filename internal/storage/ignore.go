@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFile lists gitignore-style globs for paths doctor should not report
+// as orphaned (build output, generated code, ...).
+const IgnoreFile = ".todosignore"
+
+// GetIgnorePath returns the full path to the .todosignore file
+func GetIgnorePath(projectRoot string) string {
+	return filepath.Join(projectRoot, TodosDir, IgnoreFile)
+}
+
+type ignorePattern struct {
+	segments []string
+	negate   bool
+}
+
+// IgnoreMatcher holds compiled .todosignore patterns. A nil or empty matcher
+// ignores nothing.
+type IgnoreMatcher struct {
+	patterns []ignorePattern
+}
+
+// LoadIgnorePatterns reads and compiles .todos/.todosignore. A missing file
+// yields an empty matcher.
+func LoadIgnorePatterns(projectRoot string) (*IgnoreMatcher, error) {
+	f, err := os.Open(GetIgnorePath(projectRoot))
+	if os.IsNotExist(err) {
+		return &IgnoreMatcher{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+	return CompileIgnorePatterns(lines)
+}
+
+// CompileIgnorePatterns compiles gitignore-style lines. Blank lines and lines
+// starting with # are skipped, ! negates, a leading / anchors to the project
+// root, a pattern without / matches at any depth, and ** spans directories.
+// Matching a directory also matches everything below it.
+func CompileIgnorePatterns(lines []string) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p := ignorePattern{}
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimSuffix(line, "/")
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+		for _, seg := range strings.Split(line, "/") {
+			if _, err := path.Match(seg, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", IgnoreFile, line, err)
+			}
+			p.segments = append(p.segments, seg)
+		}
+		if !anchored {
+			p.segments = append([]string{"**"}, p.segments...)
+		}
+		m.patterns = append(m.patterns, p)
+	}
+	return m, nil
+}
+
+// Match reports whether a project-relative path is ignored. The last matching
+// pattern wins, so a later !pattern re-includes a path.
+func (m *IgnoreMatcher) Match(relPath string) bool {
+	if m == nil || len(m.patterns) == 0 {
+		return false
+	}
+	clean := path.Clean(filepath.ToSlash(relPath))
+	clean = strings.TrimPrefix(clean, "./")
+	segs := strings.Split(strings.TrimPrefix(clean, "/"), "/")
+
+	ignored := false
+	for _, p := range m.patterns {
+		if matchSegmentPrefix(p.segments, segs) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// matchSegmentPrefix reports whether pattern matches segs or a leading
+// directory of segs.
+func matchSegmentPrefix(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegmentPrefix(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+		return false
+	}
+	return matchSegmentPrefix(pattern[1:], segs[1:])
+}
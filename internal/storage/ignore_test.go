@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	m, err := CompileIgnorePatterns([]string{
+		"# build output",
+		"",
+		"dist/",
+		"/gen",
+		"*.pb.go",
+		"docs/**/draft",
+		"vendor",
+		"!vendor/keep",
+	})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	cases := map[string]bool{
+		"dist":                 true,
+		"dist/app.js":          true,
+		"web/dist/bundle.js":   true,
+		"gen/api.go":           true,
+		"src/gen/api.go":       false,
+		"api/user.pb.go":       true,
+		"api/user.go":          false,
+		"docs/draft":           true,
+		"docs/a/b/draft/x.md":  true,
+		"docs/final":           false,
+		"./vendor/lib":         true,
+		"vendor/keep":          false,
+		"vendor/keep/file.go":  false,
+		"distribution/main.go": false,
+	}
+	for path, want := range cases {
+		if got := m.Match(path); got != want {
+			t.Errorf("Match(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	var nilMatcher *IgnoreMatcher
+	if nilMatcher.Match("dist") {
+		t.Error("nil matcher should ignore nothing")
+	}
+}
+
+func TestLoadIgnorePatterns(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	m, err := LoadIgnorePatterns(dir)
+	if err != nil || m.Match("anything") {
+		t.Fatalf("missing ignore file should match nothing, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, TodosDir, IgnoreFile), []byte("build/\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	m, err = LoadIgnorePatterns(dir)
+	if err != nil || !m.Match("build/out.bin") {
+		t.Fatalf("expected build/ ignored, err=%v", err)
+	}
+
+	if _, err := CompileIgnorePatterns([]string{"[bad"}); err == nil {
+		t.Fatal("expected malformed pattern error")
+	}
+}
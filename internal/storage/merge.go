@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// MergeResult records how MergeTodos combined two sides, by todo ID.
+type MergeResult struct {
+	Added  []string `json:"added"`  // only theirs had the todo
+	Theirs []string `json:"theirs"` // both had it, differently; theirs was newer
+	Ours   []string `json:"ours"`   // both had it, differently; ours was newer or equally new
+}
+
+// Conflicts is the number of todos both sides changed.
+func (r MergeResult) Conflicts() int {
+	return len(r.Theirs) + len(r.Ours)
+}
+
+// MergeTodos unions ours and theirs by ID. A todo on one side only is kept;
+// a todo on both sides that differs resolves to the one with the later
+// UpdatedAt, ours on a tie. The result keeps ours' order, followed by the
+// todos only theirs had, in their order.
+func MergeTodos(ours, theirs []types.Todo) ([]types.Todo, MergeResult) {
+	result := MergeResult{Added: []string{}, Theirs: []string{}, Ours: []string{}}
+	merged := make([]types.Todo, len(ours), len(ours)+len(theirs))
+	copy(merged, ours)
+	position := make(map[string]int, len(ours))
+	for i, t := range merged {
+		position[t.ID] = i
+	}
+
+	for _, t := range theirs {
+		i, ok := position[t.ID]
+		if !ok {
+			position[t.ID] = len(merged)
+			merged = append(merged, t)
+			result.Added = append(result.Added, t.ID)
+			continue
+		}
+		if sameTodo(merged[i], t) {
+			continue
+		}
+		if t.UpdatedAt.After(merged[i].UpdatedAt) {
+			merged[i] = t
+			result.Theirs = append(result.Theirs, t.ID)
+		} else {
+			result.Ours = append(result.Ours, t.ID)
+		}
+	}
+	return merged, result
+}
+
+func sameTodo(a, b types.Todo) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(da) == string(db)
+}
+
+// ReadTodoFile reads a todo file outside the project's own storage, such as
+// the other side of a merge, in either the versioned or the bare-array shape.
+// Todos are returned as stored, and unlike loading project files the file is
+// never rewritten.
+func ReadTodoFile(path string) ([]types.Todo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	todos, err := parseTodoData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return todos, nil
+}
+
+// WriteTodoFile writes todos to path in the current versioned format.
+func WriteTodoFile(path string, todos []types.Todo) error {
+	return saveTodosFile(path, todos)
+}
+
+// parseTodoData decodes and migrates the contents of a todo file in memory.
+// Callers normalize once owners are filled in, as normalizing an empty
+// createdBy would turn it into "unknown".
+func parseTodoData(data []byte) ([]types.Todo, error) {
+	if len(cleanJSON(data)) == 0 {
+		return []types.Todo{}, nil
+	}
+	todoFile, bare, err := decodeTodoFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	if !bare {
+		if _, err := migrateTodoFile(&todoFile); err != nil {
+			return nil, err
+		}
+	}
+	if todoFile.Todos == nil {
+		todoFile.Todos = []types.Todo{}
+	}
+	return todoFile.Todos, nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestMergeTodos(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	todo := func(id, text string, updated time.Time) types.Todo {
+		return types.Todo{ID: id, Text: text, Status: types.StatusOpen, UpdatedAt: updated}
+	}
+
+	ours := []types.Todo{
+		todo("same", "unchanged", base),
+		todo("newer-theirs", "ours old", base),
+		todo("newer-ours", "ours new", base.Add(time.Hour)),
+		todo("tie", "ours tie", base),
+		todo("mine", "only ours", base),
+	}
+	theirs := []types.Todo{
+		todo("new", "only theirs", base),
+		todo("same", "unchanged", base),
+		todo("newer-theirs", "theirs new", base.Add(time.Hour)),
+		todo("newer-ours", "theirs old", base),
+		todo("tie", "theirs tie", base),
+	}
+
+	merged, result := MergeTodos(ours, theirs)
+
+	var texts []string
+	for _, t := range merged {
+		texts = append(texts, t.Text)
+	}
+	want := []string{"unchanged", "theirs new", "ours new", "ours tie", "only ours", "only theirs"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("merged = %v, want %v", texts, want)
+	}
+	if !reflect.DeepEqual(result.Added, []string{"new"}) ||
+		!reflect.DeepEqual(result.Theirs, []string{"newer-theirs"}) ||
+		!reflect.DeepEqual(result.Ours, []string{"newer-ours", "tie"}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Conflicts() != 3 {
+		t.Fatalf("Conflicts() = %d, want 3", result.Conflicts())
+	}
+}
+
+func TestReadTodoFileShapes(t *testing.T) {
+	dir := t.TempDir()
+	bare := filepath.Join(dir, "bare.json")
+	if err := os.WriteFile(bare, []byte(`[{"id":"a1","text":"bare","status":"open"}]`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	todos, err := ReadTodoFile(bare)
+	if err != nil || len(todos) != 1 || todos[0].Text != "bare" {
+		t.Fatalf("ReadTodoFile(bare) = %+v, %v", todos, err)
+	}
+	if data, _ := os.ReadFile(bare); string(data[0]) != "[" {
+		t.Fatal("ReadTodoFile must not rewrite the file")
+	}
+
+	out := filepath.Join(dir, "out.json")
+	if err := WriteTodoFile(out, todos); err != nil {
+		t.Fatalf("WriteTodoFile: %v", err)
+	}
+	again, err := ReadTodoFile(out)
+	if err != nil || len(again) != 1 || again[0].ID != "a1" {
+		t.Fatalf("round trip = %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// todoFileMigrations upgrade a todo file from the keyed version to the next
+// one. Add an entry here (and bump types.TodoFileVersion) whenever the schema
+// changes in a way older files need rewriting for.
+var todoFileMigrations = map[int]func(*types.TodoFile) error{
+	// v1 → v2: no schema change; establishes the migration path.
+	1: func(*types.TodoFile) error { return nil },
+}
+
+// migrateTodoFile applies migrations in order until the file reaches
+// types.TodoFileVersion. It reports whether anything changed. Files written by
+// a newer build are rejected rather than silently downgraded.
+func migrateTodoFile(f *types.TodoFile) (bool, error) {
+	if f.Version < 1 {
+		f.Version = 1
+	}
+	if f.Version > types.TodoFileVersion {
+		return false, fmt.Errorf("todo file version %d is newer than this build supports (%d); upgrade todo", f.Version, types.TodoFileVersion)
+	}
+	migrated := false
+	for f.Version < types.TodoFileVersion {
+		migrate, ok := todoFileMigrations[f.Version]
+		if !ok {
+			return false, fmt.Errorf("no migration from todo file version %d", f.Version)
+		}
+		if err := migrate(f); err != nil {
+			return false, fmt.Errorf("migrate todo file from version %d: %w", f.Version, err)
+		}
+		f.Version++
+		migrated = true
+	}
+	return migrated, nil
+}
+
+// OutdatedTodoFile is a todo file on disk older than types.TodoFileVersion.
+type OutdatedTodoFile struct {
+	Path    string `json:"path"`
+	Version int    `json:"version"`
+}
+
+// FindOutdatedTodoFiles lists per-user todo files whose on-disk version is
+// older than this build writes. They are upgraded the next time todos load.
+func FindOutdatedTodoFiles(projectRoot string) ([]OutdatedTodoFile, error) {
+	entries, err := os.ReadDir(usersDir(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var outdated []OutdatedTodoFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(usersDir(projectRoot), entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var header struct {
+			Version int `json:"version"`
+		}
+		if err := json.Unmarshal(cleanJSON(data), &header); err != nil {
+			// Bare arrays predate versioning entirely.
+			header.Version = 0
+		}
+		if header.Version < types.TodoFileVersion {
+			outdated = append(outdated, OutdatedTodoFile{Path: path, Version: header.Version})
+		}
+	}
+	return outdated, nil
+}
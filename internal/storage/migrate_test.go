@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func writeUserFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, TodosDir, UsersDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestLoadTodosMigratesOldFileVersion(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	path := writeUserFile(t, dir, "alice-example.json",
+		`{"version": 1, "todos": [{"id": "v1", "text": "old", "status": "open"}]}`)
+
+	outdated, err := FindOutdatedTodoFiles(dir)
+	if err != nil || len(outdated) != 1 || outdated[0].Version != 1 {
+		t.Fatalf("FindOutdatedTodoFiles = %+v, %v", outdated, err)
+	}
+
+	todos, err := LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Text != "old" {
+		t.Fatalf("unexpected todos after migration: %+v", todos)
+	}
+
+	data, _ := os.ReadFile(path)
+	var file types.TodoFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("parse rewritten file: %v", err)
+	}
+	if file.Version != types.TodoFileVersion {
+		t.Fatalf("file version = %d, want %d", file.Version, types.TodoFileVersion)
+	}
+	if outdated, _ := FindOutdatedTodoFiles(dir); len(outdated) != 0 {
+		t.Fatalf("expected no outdated files after load, got %+v", outdated)
+	}
+}
+
+func TestLoadTodosRejectsNewerFileVersion(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	writeUserFile(t, dir, "alice-example.json", `{"version": 99, "todos": []}`)
+
+	if _, err := LoadTodos(dir); err == nil {
+		t.Fatal("expected an error for a file written by a newer version")
+	}
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// SnapshotsDir holds named checkpoints of the todo list, one file per name.
+const SnapshotsDir = "snapshots"
+
+// AutoSnapshotName is the checkpoint restore takes before replacing the todo
+// list, so a restore can itself be undone.
+const AutoSnapshotName = "before-restore"
+
+// maxFileNameLength keeps snapshot and template names short enough to be
+// file names anywhere.
+const maxFileNameLength = 64
+
+// Snapshot describes a saved checkpoint.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetSnapshotPath returns the file a named snapshot is stored in.
+func GetSnapshotPath(projectRoot, name string) string {
+	return filepath.Join(projectRoot, TodosDir, SnapshotsDir, name+".json")
+}
+
+// ValidateSnapshotName accepts names made of letters, digits, '-', '_', and
+// '.', not starting with '.' or '-', so every name is a safe file name.
+func ValidateSnapshotName(name string) error {
+	return validateFileName("snapshot", name)
+}
+
+// validateFileName checks a user-chosen name that becomes a file name under
+// .todos; kind names the thing in errors.
+func validateFileName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s name is empty", kind)
+	}
+	if len(name) > maxFileNameLength {
+		return fmt.Errorf("%s name is longer than %d characters", kind, maxFileNameLength)
+	}
+	if name[0] == '.' || name[0] == '-' {
+		return fmt.Errorf("invalid %s name %q: can't start with %q", kind, name, name[0])
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return fmt.Errorf("invalid %s name %q: use letters, digits, '-', '_', or '.'", kind, name)
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot writes todos to the named snapshot, replacing any snapshot of
+// that name.
+func SaveSnapshot(projectRoot, name string, todos []types.Todo) error {
+	if err := ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(projectRoot, TodosDir, SnapshotsDir), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+	return saveTodosFile(GetSnapshotPath(projectRoot, name), todos)
+}
+
+// LoadSnapshot reads the todos saved in the named snapshot.
+func LoadSnapshot(projectRoot, name string) ([]types.Todo, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(GetSnapshotPath(projectRoot, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q not found (see todo snapshot --list)", name)
+		}
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", name, err)
+	}
+	todos, err := parseTodoData(data)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %q: %w", name, err)
+	}
+	return todos, nil
+}
+
+// ListSnapshots returns the saved snapshots, oldest first. CreatedAt is the
+// time the snapshot file was last written.
+func ListSnapshots(projectRoot string) ([]Snapshot, error) {
+	snapshots, err := snapshotFiles(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots {
+		todos, err := LoadSnapshot(projectRoot, snapshots[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[i].Count = len(todos)
+	}
+	return snapshots, nil
+}
+
+// PruneSnapshots deletes the oldest snapshots until at most limit are left
+// and returns the names it deleted, oldest first.
+func PruneSnapshots(projectRoot string, limit int) ([]string, error) {
+	snapshots, err := snapshotFiles(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []string
+	for len(snapshots) > max(limit, 0) {
+		name := snapshots[0].Name
+		if err := os.Remove(GetSnapshotPath(projectRoot, name)); err != nil && !os.IsNotExist(err) {
+			return pruned, fmt.Errorf("failed to remove snapshot %q: %w", name, err)
+		}
+		pruned = append(pruned, name)
+		snapshots = snapshots[1:]
+	}
+	return pruned, nil
+}
+
+// snapshotFiles lists the snapshots by name and file time, oldest first,
+// without reading them.
+func snapshotFiles(projectRoot string) ([]Snapshot, error) {
+	dir := filepath.Join(projectRoot, TodosDir, SnapshotsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+
+	snapshots := []Snapshot{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || ValidateSnapshotName(name) != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, Snapshot{Name: name, CreatedAt: info.ModTime()})
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
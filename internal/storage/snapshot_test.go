@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestValidateSnapshotName(t *testing.T) {
+	for _, name := range []string{"before-import", "v1.2", "Sprint_3"} {
+		if err := ValidateSnapshotName(name); err != nil {
+			t.Fatalf("%q: unexpected error %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".hidden", "-flag", "../escape", "a/b", "with space", "ünïcode"} {
+		if err := ValidateSnapshotName(name); err == nil {
+			t.Fatalf("%q: expected an error", name)
+		}
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	if snapshots, err := ListSnapshots(dir); err != nil || len(snapshots) != 0 {
+		t.Fatalf("ListSnapshots before any = %v, %v", snapshots, err)
+	}
+
+	todos := []types.Todo{*types.NewTodo("id1", "first"), *types.NewTodo("id2", "second")}
+	if err := SaveSnapshot(dir, "checkpoint", todos); err != nil {
+		t.Fatalf("save snapshot: %v", err)
+	}
+	loaded, err := LoadSnapshot(dir, "checkpoint")
+	if err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].Text != "second" {
+		t.Fatalf("loaded = %+v", loaded)
+	}
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Name != "checkpoint" || snapshots[0].Count != 2 || snapshots[0].CreatedAt.IsZero() {
+		t.Fatalf("snapshots = %+v", snapshots)
+	}
+	if _, err := LoadSnapshot(dir, "missing"); err == nil {
+		t.Fatal("expected an error for a missing snapshot")
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	todos := []types.Todo{*types.NewTodo("id1", "first")}
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < types.DefaultHistoryLimit+5; i++ {
+		name := fmt.Sprintf("snap%02d", i)
+		if err := SaveSnapshot(dir, name, todos); err != nil {
+			t.Fatalf("save snapshot: %v", err)
+		}
+		at := start.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(GetSnapshotPath(dir, name), at, at); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	pruned, err := PruneSnapshots(dir, types.DefaultHistoryLimit)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if len(pruned) != 5 || pruned[0] != "snap00" || pruned[4] != "snap04" {
+		t.Fatalf("pruned = %v; want the five oldest", pruned)
+	}
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(snapshots) != types.DefaultHistoryLimit || snapshots[0].Name != "snap05" {
+		t.Fatalf("expected %d snapshots from snap05 on, got %+v", types.DefaultHistoryLimit, snapshots)
+	}
+
+	if pruned, err := PruneSnapshots(dir, 0); err != nil || len(pruned) != types.DefaultHistoryLimit {
+		t.Fatalf("prune to 0 = %v, %v; want every snapshot removed", pruned, err)
+	}
+}
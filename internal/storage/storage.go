@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,9 +46,56 @@ func GenerateID() (string, error) {
 	return fmt.Sprintf("%x", bytes), nil
 }
 
-// FindProjectRoot walks up the directory tree to find a .todos directory
+// CanonicalPath returns an absolute path with symlinks resolved. Parts of the
+// path that do not exist yet are kept as-is on top of the resolved ancestor,
+// and any resolution failure falls back to the plain absolute path.
+func CanonicalPath(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	current := absPath
+	for {
+		if resolved, err := filepath.EvalSymlinks(current); err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				resolved = filepath.Join(resolved, missing[i])
+			}
+			return resolved, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return absPath, nil
+		}
+		missing = append(missing, filepath.Base(current))
+		current = parent
+	}
+}
+
+// RelativeToRoot returns path relative to projectRoot, comparing canonical
+// (symlink-resolved) forms so a symlinked working directory still maps into
+// the project. The result uses forward slashes, matching stored todo paths.
+func RelativeToRoot(projectRoot, path string) (string, error) {
+	root, err := CanonicalPath(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	target, err := CanonicalPath(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// FindProjectRoot walks up the directory tree to find a .todos directory.
+// The returned root has symlinks resolved.
 func FindProjectRoot(startPath string) (string, error) {
-	absPath, err := filepath.Abs(startPath)
+	absPath, err := CanonicalPath(startPath)
 	if err != nil {
 		return "", err
 	}
@@ -141,10 +190,24 @@ func SaveTodos(projectRoot string, todos []types.Todo) error {
 	return saveTodosByOwner(projectRoot, todos)
 }
 
+// marshalFile encodes v the way every file under .todos is stored: indented
+// JSON ending in a newline, so editors and git diffs don't flag the last line.
+func marshalFile(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
 // atomicWriteFile writes data to a temp file in the same directory, fsyncs
 // it, then renames it to the target path. This prevents corruption if the
-// process is interrupted mid-write.
+// process is interrupted mid-write. perm applies to new files; an existing
+// file keeps its mode, so stricter permissions on a shared file survive.
 func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
 	dir := filepath.Dir(path)
 	tmp, err := os.CreateTemp(dir, ".tmp-*.json")
 	if err != nil {
@@ -176,7 +239,7 @@ func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
 func saveTodoFile(projectRoot string, todoFile *types.TodoFile) error {
 	todosPath := GetTodosPath(projectRoot)
 
-	data, err := json.MarshalIndent(todoFile, "", "  ")
+	data, err := marshalFile(todoFile)
 	if err != nil {
 		return fmt.Errorf("failed to marshal todos: %w", err)
 	}
@@ -201,8 +264,11 @@ func LoadConfig(projectRoot string) (*types.Config, error) {
 	}
 
 	var config types.Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	if err := json.Unmarshal(cleanJSON(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", describeJSONError(data, err))
+	}
+	if err := checkLoadedConfig(&config); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w\n\nRun 'todo config --fix' to reset invalid values.", err)
 	}
 
 	return &config, nil
@@ -212,7 +278,7 @@ func LoadConfig(projectRoot string) (*types.Config, error) {
 func SaveConfig(projectRoot string, config *types.Config) error {
 	configPath := GetConfigPath(projectRoot)
 
-	data, err := json.MarshalIndent(config, "", "  ")
+	data, err := marshalFile(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -240,13 +306,18 @@ func LoadArchive(projectRoot string) ([]types.Todo, error) {
 		return nil, fmt.Errorf("failed to read archive file: %w", err)
 	}
 
-	var todoFile types.TodoFile
-	if err := json.Unmarshal(data, &todoFile); err != nil {
-		var todos []types.Todo
-		if err := json.Unmarshal(data, &todos); err != nil {
-			return nil, fmt.Errorf("failed to parse archive file: %w", err)
+	todoFile, bare, err := decodeTodoFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse archive file: %w", err)
+	}
+	if bare {
+		if todoFile.Todos == nil {
+			return []types.Todo{}, nil
 		}
-		return todos, nil
+		return todoFile.Todos, nil
+	}
+	if _, err := migrateTodoFile(&todoFile); err != nil {
+		return nil, fmt.Errorf("archive file: %w", err)
 	}
 	return todoFile.Todos, nil
 }
@@ -255,10 +326,10 @@ func LoadArchive(projectRoot string) ([]types.Todo, error) {
 func SaveArchive(projectRoot string, todos []types.Todo) error {
 	archivePath := GetArchivePath(projectRoot)
 	todoFile := &types.TodoFile{
-		Version: 1,
+		Version: types.TodoFileVersion,
 		Todos:   todos,
 	}
-	data, err := json.MarshalIndent(todoFile, "", "  ")
+	data, err := marshalFile(todoFile)
 	if err != nil {
 		return fmt.Errorf("failed to marshal archive: %w", err)
 	}
@@ -287,24 +358,56 @@ func FindTodoByIndex(todos []types.Todo, index int) (*types.Todo, int) {
 	return nil, -1
 }
 
-// FindTodoByIDOrIndex finds a todo by ID or 1-based index
+// minIDPrefix is the shortest ID prefix accepted for partial matches.
+const minIDPrefix = 4
+
+// FindTodoByIDOrIndex finds a todo by ID or 1-based index. It returns nil when
+// nothing matches or when an ID prefix is ambiguous; use ResolveTodo to tell
+// the two apart.
 func FindTodoByIDOrIndex(todos []types.Todo, idOrIndex string) (*types.Todo, int) {
-	// First try as index
-	var index int
-	if _, err := fmt.Sscanf(idOrIndex, "%d", &index); err == nil {
+	todo, idx, err := ResolveTodo(todos, idOrIndex)
+	if err != nil {
+		return nil, -1
+	}
+	return todo, idx
+}
+
+// ResolveTodo finds a todo by 1-based index, exact ID, or unique ID prefix (at
+// least 4 characters). An all-digit argument that is not a valid index is
+// tried as an ID prefix. It returns *types.TodoNotFoundError when nothing
+// matches and *types.AmbiguousIDError when a prefix matches several todos.
+func ResolveTodo(todos []types.Todo, idOrIndex string) (*types.Todo, int, error) {
+	if index, err := strconv.Atoi(idOrIndex); err == nil {
 		if todo, idx := FindTodoByIndex(todos, index); todo != nil {
-			return todo, idx
+			return todo, idx, nil
 		}
 	}
 
-	// Then try as ID (partial match)
-	for i := range todos {
-		if todos[i].ID == idOrIndex || (len(idOrIndex) >= 4 && len(todos[i].ID) >= len(idOrIndex) && todos[i].ID[:len(idOrIndex)] == idOrIndex) {
-			return &todos[i], i
+	if todo, idx := FindTodoByID(todos, idOrIndex); todo != nil {
+		return todo, idx, nil
+	}
+
+	if len(idOrIndex) >= minIDPrefix {
+		var matches []int
+		for i := range todos {
+			if strings.HasPrefix(todos[i].ID, idOrIndex) {
+				matches = append(matches, i)
+			}
+		}
+		switch len(matches) {
+		case 0:
+		case 1:
+			return &todos[matches[0]], matches[0], nil
+		default:
+			ids := make([]string, len(matches))
+			for i, idx := range matches {
+				ids[i] = todos[idx].ID
+			}
+			return nil, -1, &types.AmbiguousIDError{ID: idOrIndex, Matches: ids}
 		}
 	}
 
-	return nil, -1
+	return nil, -1, &types.TodoNotFoundError{ID: idOrIndex}
 }
 
 // DeleteTodo removes a todo by index and returns the updated slice
@@ -315,6 +418,45 @@ func DeleteTodo(todos []types.Todo, index int) []types.Todo {
 	return append(todos[:index], todos[index+1:]...)
 }
 
+// InsertTodos inserts items at index (clamped to the slice bounds) and returns
+// the updated slice
+func InsertTodos(todos []types.Todo, index int, items ...types.Todo) []types.Todo {
+	if index < 0 {
+		index = 0
+	}
+	if index > len(todos) {
+		index = len(todos)
+	}
+	return slices.Insert(todos, index, items...)
+}
+
+// ReorderTodos returns todos in the order given by ids. Unknown and repeated
+// IDs are ignored, and todos not named in ids keep their relative order after
+// the named ones, so the result always holds the same set of todos.
+func ReorderTodos(todos []types.Todo, ids []string) []types.Todo {
+	position := make(map[string]int, len(todos))
+	for i, t := range todos {
+		position[t.ID] = i
+	}
+
+	placed := make([]bool, len(todos))
+	ordered := make([]types.Todo, 0, len(todos))
+	for _, id := range ids {
+		i, ok := position[id]
+		if !ok || placed[i] {
+			continue
+		}
+		placed[i] = true
+		ordered = append(ordered, todos[i])
+	}
+	for i, t := range todos {
+		if !placed[i] {
+			ordered = append(ordered, t)
+		}
+	}
+	return ordered
+}
+
 // FilterTodosByStatus filters todos by status
 func FilterTodosByStatus(todos []types.Todo, status types.Status) []types.Todo {
 	var filtered []types.Todo
@@ -380,6 +522,18 @@ func FilterTodosByTag(todos []types.Todo, tag string) []types.Todo {
 	return filtered
 }
 
+// FilterTodosBySource filters todos by Meta.Source (case-insensitive).
+func FilterTodosBySource(todos []types.Todo, source string) []types.Todo {
+	var filtered []types.Todo
+	needle := strings.ToLower(strings.TrimSpace(source))
+	for _, t := range todos {
+		if strings.ToLower(t.Meta.Source) == needle {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
 // FilterTodosByAssignee filters todos assigned to any of the given emails (normalized lowercase).
 func FilterTodosByAssignee(todos []types.Todo, emails []string) []types.Todo {
 	if len(emails) == 0 {
@@ -466,6 +620,26 @@ func FilterTodosDueAfter(todos []types.Todo, cutoff time.Time) []types.Todo {
 	return filtered
 }
 
+// FilterTodosInWindow keeps todos whose creation time (or last update, with
+// byUpdated) falls within [since, until]. A zero bound is open.
+func FilterTodosInWindow(todos []types.Todo, since, until time.Time, byUpdated bool) []types.Todo {
+	var filtered []types.Todo
+	for _, t := range todos {
+		at := t.CreatedAt
+		if byUpdated {
+			at = t.UpdatedAt
+		}
+		if !since.IsZero() && at.Before(since) {
+			continue
+		}
+		if !until.IsZero() && at.After(until) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
 // SortTodosByPriority sorts todos in-place with highest priority first, then by creation time
 func SortTodosByPriority(todos []types.Todo) {
 	sort.SliceStable(todos, func(i, j int) bool {
@@ -483,7 +657,7 @@ func normalizeTodos(todos []types.Todo) {
 		if !todos[i].Priority.IsValid() {
 			todos[i].Priority = types.PriorityMedium
 		}
-		todos[i].Tags = normalizeTags(todos[i].Tags)
+		todos[i].Tags = NormalizeTags(todos[i].Tags)
 		todos[i].Assignee = strings.ToLower(strings.TrimSpace(todos[i].Assignee))
 		todos[i].CreatedBy = normalizeOwnerSlug(todos[i].CreatedBy)
 		// Keep completion timestamp consistent with status for mixed historical data.
@@ -502,25 +676,30 @@ func normalizeTodos(todos []types.Todo) {
 	}
 }
 
-func normalizeTags(tags []string) []string {
-	if len(tags) == 0 {
+// NormalizeTags lowercases, trims, splits comma-separated values, drops
+// empties and duplicates, and sorts the result so stored tags diff cleanly.
+func NormalizeTags(raw []string) []string {
+	if len(raw) == 0 {
 		return nil
 	}
-	seen := make(map[string]struct{}, len(tags))
-	out := make([]string, 0, len(tags))
-	for _, tag := range tags {
-		tag = strings.ToLower(strings.TrimSpace(tag))
-		if tag == "" {
-			continue
-		}
-		if _, ok := seen[tag]; ok {
-			continue
+	seen := make(map[string]struct{}, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, value := range raw {
+		for _, token := range strings.Split(value, ",") {
+			tag := strings.ToLower(strings.TrimSpace(token))
+			if tag == "" {
+				continue
+			}
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			tags = append(tags, tag)
 		}
-		seen[tag] = struct{}{}
-		out = append(out, tag)
 	}
-	if len(out) == 0 {
+	if len(tags) == 0 {
 		return nil
 	}
-	return out
+	sort.Strings(tags)
+	return tags
 }
@@ -1,6 +1,11 @@
 package storage
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +49,58 @@ func TestSaveAndLoadTodos(t *testing.T) {
 	}
 }
 
+func TestSaveKeepsNewlineAndMode(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	todo := types.NewTodo("id1", "task")
+	todo.CreatedBy = "test-user"
+	if err := SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	todosPath := GetUserTodosPath(dir, "test-user")
+	configPath := GetConfigPath(dir)
+
+	for _, path := range []string{todosPath, configPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != 0644 {
+			t.Fatalf("%s: new file mode = %v, want 0644", filepath.Base(path), info.Mode().Perm())
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			t.Fatalf("chmod: %v", err)
+		}
+	}
+
+	todo.Text = "renamed"
+	if err := SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	if err := SaveConfig(dir, types.DefaultConfig()); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	for _, path := range []string{todosPath, configPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("%s: mode after save = %v, want 0600 kept", filepath.Base(path), info.Mode().Perm())
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !strings.HasSuffix(string(data), "}\n") || strings.HasSuffix(string(data), "\n\n") {
+			t.Fatalf("%s should end in a single newline, got %q", filepath.Base(path), data[len(data)-3:])
+		}
+	}
+}
+
 func TestFiltersAndFinders(t *testing.T) {
 	todos := []types.Todo{
 		{ID: "a1", Text: "open item", Status: types.StatusOpen, Priority: types.PriorityHigh, Context: types.Context{Paths: []string{"src/pkg"}}},
@@ -76,6 +133,79 @@ func TestFiltersAndFinders(t *testing.T) {
 	}
 }
 
+func TestResolveTodoAmbiguousPrefix(t *testing.T) {
+	todos := []types.Todo{
+		{ID: "abcd1234", Text: "first"},
+		{ID: "abcd5678", Text: "second"},
+		{ID: "12345678", Text: "digits"},
+	}
+
+	_, _, err := ResolveTodo(todos, "abcd")
+	var ambiguous *types.AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected AmbiguousIDError, got %v", err)
+	}
+	if len(ambiguous.Matches) != 2 || ambiguous.Matches[0] != "abcd1234" || ambiguous.Matches[1] != "abcd5678" {
+		t.Fatalf("unexpected candidates: %v", ambiguous.Matches)
+	}
+
+	if todo, idx, err := ResolveTodo(todos, "abcd5"); err != nil || idx != 1 || todo.Text != "second" {
+		t.Fatalf("longer prefix should resolve, got %v at %d (%v)", todo, idx, err)
+	}
+
+	// An all-digit argument that is not a valid index falls back to ID prefix.
+	if todo, _, err := ResolveTodo(todos, "1234"); err != nil || todo.Text != "digits" {
+		t.Fatalf("digit prefix should resolve as ID, got %v (%v)", todo, err)
+	}
+
+	// Trailing characters after digits are not an index.
+	if _, _, err := ResolveTodo(todos, "1abc"); err == nil {
+		t.Fatal("expected not found for 1abc")
+	}
+
+	var notFound *types.TodoNotFoundError
+	if _, _, err := ResolveTodo(todos, "zzzz"); !errors.As(err, &notFound) {
+		t.Fatalf("expected TodoNotFoundError, got %v", err)
+	}
+}
+
+// TestResolveTodoIndexFollowsFileOrder documents that indices count todos in
+// the order LoadTodos returns them, not the priority order list displays.
+// Sorting a copy for display doesn't change what an index resolves to; IDs
+// resolve the same in either order.
+func TestResolveTodoIndexFollowsFileOrder(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	low := types.NewTodo("low00001", "low priority, saved first")
+	low.Priority = types.PriorityLow
+	high := types.NewTodo("high0001", "high priority, saved second")
+	high.Priority = types.PriorityHigh
+	if err := SaveTodos(dir, []types.Todo{*low, *high}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	loaded, err := LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	display := append([]types.Todo(nil), loaded...)
+	SortTodosByPriority(display)
+	if display[0].ID != "high0001" {
+		t.Fatalf("expected the high priority todo first in display order, got %s", display[0].ID)
+	}
+
+	if todo, _, err := ResolveTodo(loaded, "1"); err != nil || todo.ID != "low00001" {
+		t.Fatalf("index 1 should be the first todo in file order, got %v (%v)", todo, err)
+	}
+	for _, todos := range [][]types.Todo{loaded, display} {
+		if todo, _, err := ResolveTodo(todos, "high"); err != nil || todo.ID != "high0001" {
+			t.Fatalf("ID prefix should resolve regardless of order, got %v (%v)", todo, err)
+		}
+	}
+}
+
 func TestSortTodosByPriority(t *testing.T) {
 	now := time.Now()
 	todos := []types.Todo{
@@ -128,6 +258,33 @@ func TestTagAndDueFilters(t *testing.T) {
 	}
 }
 
+func TestFilterTodosInWindow(t *testing.T) {
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "old", CreatedAt: now.AddDate(0, 0, -30), UpdatedAt: now},
+		{ID: "week", CreatedAt: now.AddDate(0, 0, -5), UpdatedAt: now.AddDate(0, 0, -5)},
+		{ID: "new", CreatedAt: now, UpdatedAt: now},
+	}
+
+	ids := func(todos []types.Todo) []string {
+		var out []string
+		for _, t := range todos {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	if got := ids(FilterTodosInWindow(todos, now.AddDate(0, 0, -7), time.Time{}, false)); !reflect.DeepEqual(got, []string{"week", "new"}) {
+		t.Fatalf("created since a week ago = %v", got)
+	}
+	if got := ids(FilterTodosInWindow(todos, time.Time{}, now.AddDate(0, 0, -1), false)); !reflect.DeepEqual(got, []string{"old", "week"}) {
+		t.Fatalf("created until yesterday = %v", got)
+	}
+	if got := ids(FilterTodosInWindow(todos, now.AddDate(0, 0, -1), time.Time{}, true)); !reflect.DeepEqual(got, []string{"old", "new"}) {
+		t.Fatalf("updated since yesterday = %v", got)
+	}
+}
+
 func TestFilterTodosByAssignee(t *testing.T) {
 	todos := []types.Todo{
 		{ID: "a1", Text: "one", Assignee: "alice@example.com"},
@@ -140,6 +297,21 @@ func TestFilterTodosByAssignee(t *testing.T) {
 	}
 }
 
+func TestFilterTodosBySource(t *testing.T) {
+	todos := []types.Todo{
+		{ID: "s1", Meta: types.Meta{Source: types.SourceCLI}},
+		{ID: "s2", Meta: types.Meta{Source: types.SourceWeb}},
+		{ID: "s3"},
+	}
+	got := FilterTodosBySource(todos, " Web ")
+	if len(got) != 1 || got[0].ID != "s2" {
+		t.Fatalf("unexpected source filter: %+v", got)
+	}
+	if got := FilterTodosBySource(todos, "import"); len(got) != 0 {
+		t.Fatalf("expected no imported todos, got %+v", got)
+	}
+}
+
 func TestLoadTodosNormalizesTagsAndCompletion(t *testing.T) {
 	dir := t.TempDir()
 	if _, err := InitProject(dir, true); err != nil {
@@ -188,3 +360,94 @@ func TestLoadTodosNormalizesTagsAndCompletion(t *testing.T) {
 		t.Fatalf("expected completedAt cleared for non-done todo")
 	}
 }
+
+func TestFindProjectRootThroughSymlink(t *testing.T) {
+	realRoot := t.TempDir()
+	if _, err := InitProject(realRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(realRoot, "src", "auth"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	link := filepath.Join(t.TempDir(), "project-link")
+	if err := os.Symlink(realRoot, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	canonicalRoot, err := CanonicalPath(realRoot)
+	if err != nil {
+		t.Fatalf("canonical root: %v", err)
+	}
+
+	root, err := FindProjectRoot(filepath.Join(link, "src", "auth"))
+	if err != nil {
+		t.Fatalf("find project root: %v", err)
+	}
+	if root != canonicalRoot {
+		t.Fatalf("expected canonical root %s, got %s", canonicalRoot, root)
+	}
+
+	rel, err := RelativeToRoot(realRoot, filepath.Join(link, "src", "auth"))
+	if err != nil {
+		t.Fatalf("relative to root: %v", err)
+	}
+	if rel != "src/auth" {
+		t.Fatalf("expected src/auth, got %q", rel)
+	}
+
+	// Paths that don't exist yet still resolve through the symlinked ancestor.
+	rel, err = RelativeToRoot(link, filepath.Join(realRoot, "src", "new.go"))
+	if err != nil {
+		t.Fatalf("relative to root: %v", err)
+	}
+	if rel != "src/new.go" {
+		t.Fatalf("expected src/new.go, got %q", rel)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"empty", nil, nil},
+		{"casing", []string{"API", "Backend"}, []string{"api", "backend"}},
+		{"duplicates", []string{"api", "API", " api "}, []string{"api"}},
+		{"commaSeparated", []string{"ui, backend,api"}, []string{"api", "backend", "ui"}},
+		{"mixed", []string{"API, backend", "api", "  ui "}, []string{"api", "backend", "ui"}},
+		{"sorted", []string{"zeta", "alpha", "mid"}, []string{"alpha", "mid", "zeta"}},
+		{"dropsEmpty", []string{"", " , ,"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeTags(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len mismatch: got %v want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("at %d got %q want %q (full %v)", i, got[i], tt.want[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestReorderTodos(t *testing.T) {
+	todos := []types.Todo{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+
+	got := ReorderTodos(todos, []string{"c", "zz", "a", "c"})
+	var ids []string
+	for _, todo := range got {
+		ids = append(ids, todo.ID)
+	}
+	if strings.Join(ids, ",") != "c,a,b,d" {
+		t.Fatalf("order = %v; want c,a,b,d", ids)
+	}
+	if todos[0].ID != "a" {
+		t.Fatal("ReorderTodos must not modify its input")
+	}
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// StreamTodos passes the project's todos to fn one at a time, in LoadTodos
+// order, decoding each user file element by element instead of holding every
+// todo in memory. fn returns false to stop early. When an ID is stored in
+// several user files, which only a bad merge leaves behind, it falls back to
+// LoadTodos so the later copy wins there too. Files that need upgrading are
+// loaded and rewritten as LoadTodos would.
+//
+// Finding duplicates takes a pass over the IDs first, so StreamTodos saves
+// memory, not time: when every todo is needed anyway, LoadTodos is faster.
+func StreamTodos(projectRoot string, fn func(types.Todo) bool) error {
+	if err := migrateLegacyTodos(projectRoot); err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	duplicate := false
+	err := eachTodoID(projectRoot, func(id string) {
+		duplicate = duplicate || seen[id]
+		seen[id] = true
+	})
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		todos, err := loadAllUserTodos(projectRoot)
+		if err != nil {
+			return err
+		}
+		for _, t := range todos {
+			if !fn(t) {
+				break
+			}
+		}
+		return nil
+	}
+
+	return eachUserTodosFile(projectRoot, func(path, slug string) (bool, error) {
+		yield := func(t types.Todo) bool {
+			if t.CreatedBy == "" {
+				t.CreatedBy = slug
+			}
+			one := []types.Todo{t}
+			normalizeTodos(one)
+			return fn(one[0])
+		}
+		streamed, stopped, err := scanTodoFile(path, yield)
+		if err != nil || streamed {
+			return stopped, err
+		}
+		todos, err := loadTodosFile(path)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range todos {
+			if !yield(t) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// todoID is the part of a todo CountTodos decodes.
+type todoID struct {
+	ID string `json:"id"`
+}
+
+// CountTodos returns len(LoadTodos(projectRoot)) while decoding only the ID
+// of each todo.
+func CountTodos(projectRoot string) (int, error) {
+	if err := migrateLegacyTodos(projectRoot); err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool)
+	err := eachTodoID(projectRoot, func(id string) { seen[id] = true })
+	return len(seen), err
+}
+
+// eachTodoID calls fn with the ID of every todo in the user files, in file
+// order and repeats included, decoding only the IDs where it can.
+func eachTodoID(projectRoot string, fn func(id string)) error {
+	return eachUserTodosFile(projectRoot, func(path, _ string) (bool, error) {
+		streamed, _, err := scanTodoFile(path, func(t todoID) bool {
+			fn(t.ID)
+			return true
+		})
+		if err != nil || streamed {
+			return false, err
+		}
+		todos, err := loadTodosFile(path)
+		if err != nil {
+			return false, err
+		}
+		for _, t := range todos {
+			fn(t.ID)
+		}
+		return false, nil
+	})
+}
+
+// eachUserTodosFile calls fn with every per-user todo file and the owner slug
+// its name implies, in directory order, until fn returns true or an error.
+func eachUserTodosFile(projectRoot string, fn func(path, slug string) (stop bool, err error)) error {
+	dir := usersDir(projectRoot)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		stop, err := fn(filepath.Join(dir, entry.Name()), ownerSlugFromFilename(entry.Name()))
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanTodoFile decodes the todos of a current-version todo file one at a
+// time into a fresh T each and passes them to each. streamed is false, with
+// nothing passed on, when the file must go through loadTodosFile instead: a
+// bare array, another version, a byte order mark, or JSON that doesn't parse
+// before the first todo. stopped reports that each returned false.
+func scanTodoFile[T any](path string, each func(T) bool) (streamed, stopped bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, false, nil
+		}
+		return false, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	if head, _ := r.Peek(len(utf8BOM)); bytes.Equal(head, utf8BOM) {
+		return false, false, nil
+	}
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false, false, nil
+	}
+
+	version := 0
+	yielded := false
+	fail := func(err error) (bool, bool, error) {
+		if !yielded {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		switch key {
+		case "version":
+			if err := dec.Decode(&version); err != nil || version != types.TodoFileVersion {
+				return fail(err)
+			}
+		case "todos":
+			if version != types.TodoFileVersion {
+				return false, false, nil
+			}
+			tok, err := dec.Token()
+			if err != nil {
+				return fail(err)
+			}
+			if tok == nil {
+				continue
+			}
+			if tok != json.Delim('[') {
+				return false, false, nil
+			}
+			for dec.More() {
+				var t T
+				if err := dec.Decode(&t); err != nil {
+					return fail(err)
+				}
+				yielded = true
+				if !each(t) {
+					return true, true, nil
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return fail(err)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fail(err)
+			}
+		}
+	}
+	return true, false, nil
+}
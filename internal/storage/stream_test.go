@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestStreamTodosMatchesLoadTodos(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	var todos []types.Todo
+	for i, owner := range []string{"ada-lovelace", "alan-turing", "ada-lovelace", ""} {
+		todo := types.NewTodo(fmt.Sprintf("id%d", i), fmt.Sprintf("task %d", i))
+		todo.CreatedBy = owner
+		todo.Tags = []string{"b", "a"}
+		todos = append(todos, *todo)
+	}
+	todos[1].MarkDone()
+	if err := SaveTodos(dir, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	// A bare-array file takes the full-load path and is upgraded.
+	bare := `[{"id":"legacy1","text":"old format","status":"open"}]`
+	if err := os.WriteFile(GetUserTodosPath(dir, "grace-hopper"), []byte(bare), 0644); err != nil {
+		t.Fatalf("write bare file: %v", err)
+	}
+
+	want, err := LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	var got []types.Todo
+	if err := StreamTodos(dir, func(t types.Todo) bool {
+		got = append(got, t)
+		return true
+	}); err != nil {
+		t.Fatalf("stream todos: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("StreamTodos differs from LoadTodos:\n got %+v\nwant %+v", got, want)
+	}
+
+	count, err := CountTodos(dir)
+	if err != nil || count != len(want) {
+		t.Fatalf("CountTodos = %d, %v; want %d", count, err, len(want))
+	}
+
+	seen := 0
+	if err := StreamTodos(dir, func(types.Todo) bool {
+		seen++
+		return seen < 2
+	}); err != nil || seen != 2 {
+		t.Fatalf("expected streaming to stop after 2 todos, saw %d (%v)", seen, err)
+	}
+}
+
+func TestStreamTodosDuplicateIDKeepsLaterCopy(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	first := types.NewTodo("dup1", "first copy")
+	first.CreatedBy = "ada-lovelace"
+	other := types.NewTodo("other", "unrelated")
+	other.CreatedBy = "ada-lovelace"
+	if err := SaveTodos(dir, []types.Todo{*first, *other}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	later := *first
+	later.Text = "later copy"
+	later.MarkDone()
+	data, err := marshalFile(types.TodoFile{Version: types.TodoFileVersion, Todos: []types.Todo{later}})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(GetUserTodosPath(dir, "alan-turing"), data, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	want, err := LoadTodos(dir)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	var got []types.Todo
+	if err := StreamTodos(dir, func(t types.Todo) bool {
+		got = append(got, t)
+		return true
+	}); err != nil {
+		t.Fatalf("stream todos: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("StreamTodos differs from LoadTodos:\n got %+v\nwant %+v", got, want)
+	}
+	if got[0].Text != "later copy" || got[0].Status != types.StatusDone {
+		t.Fatalf("expected the later copy to win, got %+v", got[0])
+	}
+}
+
+func TestStreamTodosReportsBrokenFile(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	broken := fmt.Sprintf(`{"version": %d, "todos": [{"id": "a", "text": "ok"}, {"id": }]}`, types.TodoFileVersion)
+	if err := os.WriteFile(GetUserTodosPath(dir, "test-user"), []byte(broken), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := StreamTodos(dir, func(types.Todo) bool { return true }); err == nil {
+		t.Fatal("expected an error for a truncated todo")
+	}
+	if _, err := CountTodos(dir); err == nil {
+		t.Fatal("expected CountTodos to report the broken file")
+	}
+}
+
+// benchmarkProject saves n todos split across a few user files.
+func benchmarkProject(b *testing.B, n int) string {
+	b.Helper()
+	dir := b.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		b.Fatalf("init project: %v", err)
+	}
+	todos := make([]types.Todo, n)
+	for i := range todos {
+		todo := types.NewTodo(fmt.Sprintf("id%06d", i), fmt.Sprintf("generated task number %d with some text", i))
+		todo.CreatedBy = fmt.Sprintf("user-%d", i%4)
+		todo.Tags = []string{"generated", "bench"}
+		todo.Context.Paths = []string{"src/module/file.go"}
+		if i%3 == 0 {
+			todo.MarkDone()
+		}
+		todos[i] = *todo
+	}
+	if err := SaveTodos(dir, todos); err != nil {
+		b.Fatalf("save todos: %v", err)
+	}
+	return dir
+}
+
+func BenchmarkLoadTodosCount(b *testing.B) {
+	dir := benchmarkProject(b, 20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadTodos(dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCountTodos(b *testing.B) {
+	dir := benchmarkProject(b, 20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := CountTodos(dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadTodosFilterStatus(b *testing.B) {
+	dir := benchmarkProject(b, 20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		todos, err := LoadTodos(dir)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = FilterTodosByStatus(todos, types.StatusOpen)
+	}
+}
+
+func BenchmarkStreamTodosFilterStatus(b *testing.B) {
+	dir := benchmarkProject(b, 20000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var open []types.Todo
+		err := StreamTodos(dir, func(t types.Todo) bool {
+			if t.Status == types.StatusOpen {
+				open = append(open, t)
+			}
+			return true
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// TemplatesDir holds the project's todo templates, one <name>.json each.
+const TemplatesDir = "templates"
+
+// GetTemplatePath returns the file a named template is stored in.
+func GetTemplatePath(projectRoot, name string) string {
+	return filepath.Join(projectRoot, TodosDir, TemplatesDir, name+".json")
+}
+
+// ValidateTemplateName applies the snapshot naming rules to templates.
+func ValidateTemplateName(name string) error {
+	return validateFileName("template", name)
+}
+
+// SaveTemplate writes tmpl under name, replacing any template of that name.
+func SaveTemplate(projectRoot, name string, tmpl types.TodoTemplate) error {
+	if err := ValidateTemplateName(name); err != nil {
+		return err
+	}
+	if err := tmpl.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(projectRoot, TodosDir, TemplatesDir), 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	data, err := marshalFile(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %w", err)
+	}
+	if err := atomicWriteFile(GetTemplatePath(projectRoot, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write template %q: %w", name, err)
+	}
+	return nil
+}
+
+// LoadTemplate reads the named template. Unknown fields are rejected so a
+// misspelled key in a hand-written template doesn't go unnoticed.
+func LoadTemplate(projectRoot, name string) (types.TodoTemplate, error) {
+	var tmpl types.TodoTemplate
+	if err := ValidateTemplateName(name); err != nil {
+		return tmpl, err
+	}
+	data, err := os.ReadFile(GetTemplatePath(projectRoot, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tmpl, fmt.Errorf("template %q not found (see todo template list)", name)
+		}
+		return tmpl, fmt.Errorf("failed to read template %q: %w", name, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(cleanJSON(data)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&tmpl); err != nil {
+		return tmpl, fmt.Errorf("template %q: %w", name, describeJSONError(data, err))
+	}
+	if tmpl.Priority != "" {
+		priority, err := types.ParsePriority(string(tmpl.Priority))
+		if err != nil {
+			return tmpl, fmt.Errorf("template %q: %w", name, err)
+		}
+		tmpl.Priority = priority
+	}
+	tmpl.Recur = types.Recurrence(strings.ToLower(string(tmpl.Recur)))
+	if err := tmpl.Validate(); err != nil {
+		return tmpl, fmt.Errorf("template %q: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// ListTemplates returns the names of the saved templates, sorted.
+func ListTemplates(projectRoot string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectRoot, TodosDir, TemplatesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || ValidateTemplateName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DeleteTemplate removes the named template.
+func DeleteTemplate(projectRoot, name string) error {
+	if err := ValidateTemplateName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(GetTemplatePath(projectRoot, name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("template %q not found (see todo template list)", name)
+		}
+		return fmt.Errorf("failed to delete template %q: %w", name, err)
+	}
+	return nil
+}
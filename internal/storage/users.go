@@ -1,11 +1,12 @@
 package storage
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/git"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
@@ -62,16 +63,59 @@ func slugFromNameOrError(name string) (string, error) {
 	return slug, nil
 }
 
-// ApplyCreator sets CreatedBy on a new todo from the current user slug.
+// CurrentUserName returns the configured git user.name as written, honoring
+// the TODO_USER_NAME override.
+func CurrentUserName() (string, error) {
+	if override := strings.TrimSpace(os.Getenv("TODO_USER_NAME")); override != "" {
+		return override, nil
+	}
+	return git.GetUserName()
+}
+
+// ApplyCreator sets CreatedBy (owner slug) and Author (display name) on a new
+// todo from the current git user.
 func ApplyCreator(todo *types.Todo) error {
 	slug, err := CurrentUserSlug()
 	if err != nil {
 		return err
 	}
 	todo.CreatedBy = slug
+	if name, err := CurrentUserName(); err == nil {
+		todo.Author = name
+	}
 	return nil
 }
 
+// GetUsersDir returns the directory holding the per-user todo files.
+func GetUsersDir(projectRoot string) string {
+	return usersDir(projectRoot)
+}
+
+// GetUserTodosPath returns the todo file owned by the given user slug.
+func GetUserTodosPath(projectRoot, slug string) string {
+	return userTodosPath(projectRoot, slug)
+}
+
+// TodosModTime returns the most recent modification time across the per-user
+// todo files and the legacy todos.json, for cheap change polling.
+func TodosModTime(projectRoot string) time.Time {
+	var latest time.Time
+	paths := []string{GetTodosPath(projectRoot)}
+	if entries, err := os.ReadDir(usersDir(projectRoot)); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				paths = append(paths, filepath.Join(usersDir(projectRoot), entry.Name()))
+			}
+		}
+	}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
 func usersDir(projectRoot string) string {
 	return filepath.Join(projectRoot, TodosDir, UsersDir)
 }
@@ -121,22 +165,39 @@ func loadTodosFile(path string) ([]types.Todo, error) {
 		}
 		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
-	var todoFile types.TodoFile
-	if err := json.Unmarshal(data, &todoFile); err != nil {
-		var todos []types.Todo
-		if err := json.Unmarshal(data, &todos); err != nil {
-			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	todoFile, bare, err := decodeTodoFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if bare {
+		todos := todoFile.Todos
+		if todos == nil {
+			todos = []types.Todo{}
 		}
 		normalizeTodos(todos)
+		// Bare arrays predate versioning; rewrite them in the current format.
+		if err := saveTodosFile(path, todos); err != nil {
+			return nil, err
+		}
 		return todos, nil
 	}
+	migrated, err := migrateTodoFile(&todoFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 	normalizeTodos(todoFile.Todos)
+	// A byte order mark is repaired like an outdated version: by rewriting.
+	if migrated || bytes.HasPrefix(data, utf8BOM) {
+		if err := saveTodosFile(path, todoFile.Todos); err != nil {
+			return nil, err
+		}
+	}
 	return todoFile.Todos, nil
 }
 
 func saveTodosFile(path string, todos []types.Todo) error {
-	todoFile := &types.TodoFile{Version: 1, Todos: todos}
-	data, err := json.MarshalIndent(todoFile, "", "  ")
+	todoFile := &types.TodoFile{Version: types.TodoFileVersion, Todos: todos}
+	data, err := marshalFile(todoFile)
 	if err != nil {
 		return fmt.Errorf("failed to marshal todos: %w", err)
 	}
@@ -218,37 +279,34 @@ func loadAllUserTodos(projectRoot string) ([]types.Todo, error) {
 		return nil, err
 	}
 
-	dir := usersDir(projectRoot)
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []types.Todo{}, nil
-		}
-		return nil, err
-	}
-
-	byID := make(map[string]types.Todo)
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-			continue
-		}
-		slug := ownerSlugFromFilename(entry.Name())
-		path := filepath.Join(dir, entry.Name())
+	// Keep the first-seen position of each ID so index-based lookups are stable
+	// across runs; later files still win for duplicate IDs.
+	var out []types.Todo
+	position := make(map[string]int)
+	err := eachUserTodosFile(projectRoot, func(path, slug string) (bool, error) {
 		todos, err := loadTodosFile(path)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		for _, t := range todos {
 			if t.CreatedBy == "" {
 				t.CreatedBy = slug
 			}
-			byID[t.ID] = t
+			if i, ok := position[t.ID]; ok {
+				out[i] = t
+				continue
+			}
+			position[t.ID] = len(out)
+			out = append(out, t)
 		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	out := make([]types.Todo, 0, len(byID))
-	for _, t := range byID {
-		out = append(out, t)
+	if out == nil {
+		out = []types.Todo{}
 	}
 	normalizeTodos(out)
 	return out, nil
@@ -296,3 +354,42 @@ func saveTodosByOwner(projectRoot string, todos []types.Todo) error {
 	}
 	return nil
 }
+
+// FilterTodosByAuthor keeps the todos created by the user with git
+// user.name name and owner slug slug: Author matches name, ignoring case and
+// surrounding space, or, for todos without an Author, CreatedBy matches slug.
+func FilterTodosByAuthor(todos []types.Todo, name, slug string) []types.Todo {
+	name = strings.TrimSpace(name)
+	slug = normalizeOwnerSlug(slug)
+	filtered := []types.Todo{}
+	for _, t := range todos {
+		author := strings.TrimSpace(t.Author)
+		if author != "" {
+			if strings.EqualFold(author, name) {
+				filtered = append(filtered, t)
+			}
+			continue
+		}
+		if slug != unknownOwnerSlug && normalizeOwnerSlug(t.CreatedBy) == slug {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// HasAuthorInfo reports whether --mine can tell whose todos are whose: some
+// todo records an Author, or lives in the owner file of slug. Todos in other
+// files, including legacy.json, belong to their file's owner and say nothing
+// about who created them.
+func HasAuthorInfo(todos []types.Todo, slug string) bool {
+	slug = normalizeOwnerSlug(slug)
+	for _, t := range todos {
+		if strings.TrimSpace(t.Author) != "" {
+			return true
+		}
+		if slug != unknownOwnerSlug && normalizeOwnerSlug(t.CreatedBy) == slug {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/types"
 )
@@ -23,6 +24,20 @@ func TestSlugFromGitName(t *testing.T) {
 	}
 }
 
+func TestApplyCreatorSetsAuthor(t *testing.T) {
+	t.Setenv("TODO_USER_NAME", "Alice Example")
+	todo := types.NewTodo("a1", "task")
+	if err := ApplyCreator(todo); err != nil {
+		t.Fatalf("ApplyCreator: %v", err)
+	}
+	if todo.CreatedBy != "alice-example" {
+		t.Fatalf("CreatedBy = %q, want alice-example", todo.CreatedBy)
+	}
+	if todo.Author != "Alice Example" {
+		t.Fatalf("Author = %q, want %q", todo.Author, "Alice Example")
+	}
+}
+
 func TestSaveAndLoadPerUserTodos(t *testing.T) {
 	t.Setenv("TODO_USER_NAME", "Alice Example")
 	dir := t.TempDir()
@@ -90,3 +105,61 @@ func TestMigrateLegacyTodos(t *testing.T) {
 		t.Fatalf("expected legacy todos.json to be empty after migration, got %d", len(legacyTodos))
 	}
 }
+
+func TestTodosModTimeTracksUserFiles(t *testing.T) {
+	t.Setenv("TODO_USER_NAME", "Alice Example")
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	todo := types.NewTodo("m1", "one")
+	todo.CreatedBy = "alice-example"
+	if err := SaveTodos(dir, []types.Todo{*todo}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	before := TodosModTime(dir)
+	if before.IsZero() {
+		t.Fatal("expected a modification time after saving")
+	}
+
+	later := before.Add(time.Minute)
+	if err := os.Chtimes(GetUserTodosPath(dir, "alice-example"), later, later); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if got := TodosModTime(dir); !got.Equal(later) {
+		t.Fatalf("TodosModTime = %v, want %v", got, later)
+	}
+}
+
+func TestFilterTodosByAuthor(t *testing.T) {
+	mine := types.NewTodo("a1", "written by me")
+	mine.Author, mine.CreatedBy = "Ada Lovelace", "ada-lovelace"
+	other := types.NewTodo("a2", "written by someone else")
+	other.Author, other.CreatedBy = "Grace Hopper", "grace-hopper"
+	// Author wins over the file the todo lives in.
+	moved := types.NewTodo("a3", "theirs, in my file")
+	moved.Author, moved.CreatedBy = "Grace Hopper", "ada-lovelace"
+	legacy := types.NewTodo("a4", "no author, my file")
+	legacy.CreatedBy = "ada-lovelace"
+	todos := []types.Todo{*mine, *other, *moved, *legacy}
+
+	got := FilterTodosByAuthor(todos, "  ada lovelace ", "ada-lovelace")
+	if len(got) != 2 || got[0].ID != "a1" || got[1].ID != "a4" {
+		t.Fatalf("expected a1 and a4, got %+v", got)
+	}
+	if got := FilterTodosByAuthor(todos, "Nobody Here", "nobody-here"); len(got) != 0 {
+		t.Fatalf("expected no todos for an unknown author, got %+v", got)
+	}
+
+	if !HasAuthorInfo(todos, "nobody-here") {
+		t.Fatal("expected author info")
+	}
+	if !HasAuthorInfo([]types.Todo{*legacy}, "ada-lovelace") {
+		t.Fatal("expected a todo in the user's own file to count as author info")
+	}
+	shared := types.NewTodo("a5", "no author, legacy file")
+	shared.CreatedBy = legacyOwnerSlug
+	if HasAuthorInfo([]types.Todo{*shared, *legacy}, "grace-hopper") {
+		t.Fatal("expected no author info without Author or a todo in the user's file")
+	}
+}
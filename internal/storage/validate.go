@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TodoProblem describes one integrity problem in a todo file.
+type TodoProblem struct {
+	// File is the todo file, relative to the project root.
+	File string `json:"file"`
+	// Index is the todo's 1-based position in the file; 0 means the problem
+	// is with the file itself.
+	Index   int    `json:"index,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+// Location names the file, and the todo if there is one.
+func (p TodoProblem) Location() string {
+	switch {
+	case p.Index == 0:
+		return p.File
+	case p.ID == "":
+		return fmt.Sprintf("%s todo #%d", p.File, p.Index)
+	}
+	return fmt.Sprintf("%s todo #%d (%s)", p.File, p.Index, p.ID)
+}
+
+func (p TodoProblem) String() string {
+	return p.Location() + ": " + p.Message
+}
+
+// ValidateTodoFiles checks the legacy todos.json and every file under
+// .todos/users without changing them: each must parse, be a version this
+// build reads, and hold todos with an ID unique across all files, a known
+// status and priority, and set timestamps with updatedAt not before
+// createdAt. Custom statuses count as known once registered.
+func ValidateTodoFiles(projectRoot string) ([]TodoProblem, error) {
+	paths := []string{}
+	if _, err := os.Stat(GetTodosPath(projectRoot)); err == nil {
+		paths = append(paths, GetTodosPath(projectRoot))
+	}
+	err := eachUserTodosFile(projectRoot, func(path, _ string) (bool, error) {
+		paths = append(paths, path)
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users directory: %w", err)
+	}
+
+	var problems []TodoProblem
+	seen := make(map[string]TodoProblem)
+	for _, path := range paths {
+		rel, err := filepath.Rel(projectRoot, path)
+		if err != nil {
+			rel = path
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		todoFile, bare, err := decodeTodoFile(data)
+		if err != nil {
+			problems = append(problems, TodoProblem{File: rel, Message: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		if !bare {
+			if _, err := migrateTodoFile(&todoFile); err != nil {
+				problems = append(problems, TodoProblem{File: rel, Message: err.Error()})
+				continue
+			}
+		}
+
+		for i, todo := range todoFile.Todos {
+			at := TodoProblem{File: rel, Index: i + 1, ID: todo.ID}
+			report := func(format string, args ...any) {
+				p := at
+				p.Message = fmt.Sprintf(format, args...)
+				problems = append(problems, p)
+			}
+			if todo.ID == "" {
+				report("missing id")
+			} else if first, dup := seen[todo.ID]; dup {
+				report("duplicate id, first used by todo #%d in %s", first.Index, first.File)
+			} else {
+				seen[todo.ID] = at
+			}
+			if !todo.Status.IsValid() {
+				report("invalid status %q", todo.Status)
+			}
+			if todo.Priority != "" && !todo.Priority.IsValid() {
+				report("invalid priority %q", todo.Priority)
+			}
+			if todo.CreatedAt.IsZero() {
+				report("missing createdAt")
+			}
+			if todo.UpdatedAt.IsZero() {
+				report("missing updatedAt")
+			}
+			if !todo.CreatedAt.IsZero() && !todo.UpdatedAt.IsZero() && todo.UpdatedAt.Before(todo.CreatedAt) {
+				report("updatedAt %s is before createdAt %s", todo.UpdatedAt.Format(time.RFC3339), todo.CreatedAt.Format(time.RFC3339))
+			}
+		}
+	}
+	return problems, nil
+}
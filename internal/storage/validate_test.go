@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func TestValidateTodoFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := InitProject(dir, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	if err := os.MkdirAll(GetUsersDir(dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	good := types.NewTodo("id1", "fine")
+	if err := saveTodosFile(GetUserTodosPath(dir, "ada"), []types.Todo{*good}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	problems, err := ValidateTodoFiles(dir)
+	if err != nil || len(problems) != 0 {
+		t.Fatalf("expected a clean project, got %v, %v", problems, err)
+	}
+
+	dup := types.NewTodo("id1", "same id")
+	noID := types.NewTodo("", "no id")
+	noID.Status = "finished"
+	noID.Priority = "urgent"
+	backwards := types.NewTodo("id3", "backwards")
+	backwards.UpdatedAt = backwards.CreatedAt.Add(-time.Hour)
+	unset := types.NewTodo("id4", "no timestamps")
+	unset.CreatedAt, unset.UpdatedAt = time.Time{}, time.Time{}
+	if err := saveTodosFile(GetUserTodosPath(dir, "bob"), []types.Todo{*dup, *noID, *backwards, *unset}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	broken := filepath.Join(GetUsersDir(dir), "cy.json")
+	if err := os.WriteFile(broken, []byte("{\n  \"version\": 2,\n  \"todos\": [\n    {\"id\": }\n  ]\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err = ValidateTodoFiles(dir)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		"bob.json todo #1 (id1): duplicate id, first used by todo #1 in .todos/users/ada.json",
+		"bob.json todo #2: missing id",
+		"bob.json todo #2: invalid status \"finished\"",
+		"bob.json todo #2: invalid priority \"urgent\"",
+		"bob.json todo #3 (id3): updatedAt",
+		"bob.json todo #4 (id4): missing createdAt",
+		"bob.json todo #4 (id4): missing updatedAt",
+		"cy.json: invalid JSON",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d problems, got %d:\n%s", len(want), len(got), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("problem %d = %q, want it to contain %q", i, got[i], want[i])
+		}
+	}
+	if !strings.Contains(got[len(got)-1], "line 4, column") {
+		t.Errorf("expected the JSON error's location, got %q", got[len(got)-1])
+	}
+}
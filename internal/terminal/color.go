@@ -0,0 +1,62 @@
+package terminal
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI color and style codes. These are variables rather than constants so
+// SetTheme can swap the palette and SetColorsEnabled can blank them for plain
+// output; always reference them through the package instead of copying their
+// values.
+var (
+	Reset     = "\033[0m"
+	Bold      = "\033[1m"
+	Dim       = "\033[2m"
+	Italic    = "\033[3m"
+	Underline = "\033[4m"
+
+	// Colors
+	Black   = "\033[30m"
+	Red     = "\033[31m"
+	Green   = "\033[32m"
+	Yellow  = "\033[33m"
+	Blue    = "\033[34m"
+	Magenta = "\033[35m"
+	Cyan    = "\033[36m"
+	White   = "\033[37m"
+
+	// Bright colors
+	BrightBlack   = "\033[90m"
+	BrightRed     = "\033[91m"
+	BrightGreen   = "\033[92m"
+	BrightYellow  = "\033[93m"
+	BrightBlue    = "\033[94m"
+	BrightMagenta = "\033[95m"
+	BrightCyan    = "\033[96m"
+	BrightWhite   = "\033[97m"
+)
+
+var colorsEnabled = true
+
+// ColorsEnabled reports whether color and style codes are currently emitted.
+func ColorsEnabled() bool {
+	return colorsEnabled
+}
+
+// SetColorsEnabled turns color and style codes on or off for all output.
+// Disabling wins over any theme; enabling restores the active theme.
+func SetColorsEnabled(enabled bool) {
+	colorsEnabled = enabled
+	applyTheme()
+}
+
+// ShouldUseColor reports whether colored output is appropriate: NO_COLOR
+// (any value) disables it, as does stdout not being a terminal.
+func ShouldUseColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
@@ -0,0 +1,123 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = orig
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestSetColorsEnabled(t *testing.T) {
+	t.Cleanup(func() { SetColorsEnabled(true) })
+
+	SetColorsEnabled(false)
+	out := captureStdout(t, func() {
+		PrintSuccess("saved")
+		PrintError("failed")
+	})
+	if strings.Contains(out, "\033[") {
+		t.Fatalf("expected plain output, got %q", out)
+	}
+	if !strings.Contains(out, "✓ saved") || !strings.Contains(out, "✗ failed") {
+		t.Fatalf("expected messages in output, got %q", out)
+	}
+
+	SetColorsEnabled(true)
+	if Reset != "\033[0m" || BrightGreen != "\033[92m" {
+		t.Fatal("expected color codes restored")
+	}
+	out = captureStdout(t, func() { PrintSuccess("saved") })
+	if !strings.Contains(out, BrightGreen) {
+		t.Fatalf("expected colored output, got %q", out)
+	}
+}
+
+func TestShouldUseColorHonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if ShouldUseColor() {
+		t.Fatal("NO_COLOR should disable color")
+	}
+}
+
+func TestPriorityIconAndColor(t *testing.T) {
+	tests := []struct {
+		priority, icon, color string
+	}{
+		{"high", "↑", BrightRed},
+		{"medium", "→", Yellow},
+		{"low", "↓", Dim},
+		{"", "→", Yellow},
+	}
+	for _, tt := range tests {
+		if got := PriorityIcon(tt.priority); got != tt.icon {
+			t.Errorf("PriorityIcon(%q) = %q; want %q", tt.priority, got, tt.icon)
+		}
+		if got := PriorityColor(tt.priority); got != tt.color {
+			t.Errorf("PriorityColor(%q) = %q; want %q", tt.priority, got, tt.color)
+		}
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	t.Cleanup(func() {
+		SetColorsEnabled(true)
+		_ = SetTheme("")
+	})
+
+	for _, name := range types.Themes {
+		if err := SetTheme(name); err != nil {
+			t.Fatalf("theme %s from types.Themes is not defined: %v", name, err)
+		}
+	}
+	if err := SetTheme("neon"); err == nil {
+		t.Fatal("expected an error for an unknown theme")
+	}
+
+	if err := SetTheme("light"); err != nil {
+		t.Fatalf("set light: %v", err)
+	}
+	if BrightWhite != DefaultTheme.Black || BrightYellow != DefaultTheme.Yellow {
+		t.Fatalf("light theme should darken pale colors, got BrightWhite=%q BrightYellow=%q", BrightWhite, BrightYellow)
+	}
+
+	if err := SetTheme("mono"); err != nil {
+		t.Fatalf("set mono: %v", err)
+	}
+	out := captureStdout(t, func() { PrintSuccess("saved") })
+	if strings.Contains(out, "\033[9") || Red != "" || Bold != DefaultTheme.Bold {
+		t.Fatalf("mono should drop colors but keep styles, got %q", out)
+	}
+
+	// Disabling colors wins over the theme, and re-enabling restores it.
+	SetColorsEnabled(false)
+	if Bold != "" || Reset != "" {
+		t.Fatal("expected no codes with colors disabled")
+	}
+	if err := SetTheme("default"); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+	if BrightGreen != "" {
+		t.Fatal("setting a theme must not re-enable colors")
+	}
+	SetColorsEnabled(true)
+	if BrightGreen != DefaultTheme.BrightGreen {
+		t.Fatal("expected the default palette after re-enabling colors")
+	}
+}
@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// HyperlinksEnabled can be cleared (e.g. by --no-hyperlinks) to always print
+// plain text instead of OSC 8 links.
+var HyperlinksEnabled = true
+
+// SupportsHyperlinks reports whether stdout is a terminal known to render OSC 8
+// hyperlinks. FORCE_HYPERLINK=1 forces them on, FORCE_HYPERLINK=0 off.
+func SupportsHyperlinks() bool {
+	if !HyperlinksEnabled {
+		return false
+	}
+	if force, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		return force != "0" && force != ""
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return false
+	}
+
+	termName := os.Getenv("TERM")
+	if termName == "" || termName == "dumb" {
+		return false
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("WEZTERM_EXECUTABLE") != "" {
+		return true
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+	for _, name := range []string{"kitty", "alacritty", "ghostty", "foot", "wezterm"} {
+		if strings.Contains(termName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hyperlink wraps text in an OSC 8 hyperlink to url when the terminal supports
+// it, and returns text unchanged otherwise.
+func Hyperlink(url, text string) string {
+	if url == "" || !SupportsHyperlinks() {
+		return text
+	}
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
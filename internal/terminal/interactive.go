@@ -3,41 +3,18 @@ package terminal
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/bagadi-alnour/todo-cli/internal/types"
 	"github.com/mattn/go-runewidth"
 	"golang.org/x/term"
 )
 
-// ANSI color and style codes
+// Terminal control codes. Unlike the colors in color.go these are never
+// disabled, since raw-mode screens depend on them.
 const (
-	Reset     = "\033[0m"
-	Bold      = "\033[1m"
-	Dim       = "\033[2m"
-	Italic    = "\033[3m"
-	Underline = "\033[4m"
-
-	// Colors
-	Black   = "\033[30m"
-	Red     = "\033[31m"
-	Green   = "\033[32m"
-	Yellow  = "\033[33m"
-	Blue    = "\033[34m"
-	Magenta = "\033[35m"
-	Cyan    = "\033[36m"
-	White   = "\033[37m"
-
-	// Bright colors
-	BrightBlack   = "\033[90m"
-	BrightRed     = "\033[91m"
-	BrightGreen   = "\033[92m"
-	BrightYellow  = "\033[93m"
-	BrightBlue    = "\033[94m"
-	BrightMagenta = "\033[95m"
-	BrightCyan    = "\033[96m"
-	BrightWhite   = "\033[97m"
-
-	// Terminal control
 	ClearScreen  = "\033[2J"
 	ClearLine    = "\033[2K"
 	CursorHome   = "\033[H"
@@ -45,6 +22,10 @@ const (
 	ShowCursor   = "\033[?25h"
 	AltScreenOn  = "\033[?1049h"
 	AltScreenOff = "\033[?1049l"
+	// MouseOn enables click and wheel reporting in both the normal (X10) and
+	// SGR encodings; MouseOff restores the terminal's own selection.
+	MouseOn  = "\033[?1000h\033[?1006h"
+	MouseOff = "\033[?1006l\033[?1000l"
 )
 
 // TermState holds the terminal state for raw mode
@@ -71,31 +52,30 @@ func (t *TermState) Restore() {
 	}
 }
 
+// EscapeTimeout bounds how long ReadKey waits for the rest of an escape
+// sequence after ESC. Terminals send sequences in one burst, so anything
+// slower is a bare Esc press.
+var EscapeTimeout = 25 * time.Millisecond
+
 // ReadKey reads a single key press and returns a string representation
 func ReadKey() string {
-	var buf [3]byte
-	n, err := os.Stdin.Read(buf[:1])
+	return ReadKeyFrom(os.Stdin)
+}
+
+// ReadKeyFrom reads a single key press from f. Arrow keys are returned as
+// UP/DOWN/LEFT/RIGHT, a lone Esc as ESC, and unrecognized escape sequences
+// as an empty string after they have been consumed in full. When mouse
+// reporting is on, a left click is returned as "CLICK row col" (see
+// ParseClick) and the wheel as UP/DOWN.
+func ReadKeyFrom(f *os.File) string {
+	var buf [1]byte
+	n, err := f.Read(buf[:])
 	if err != nil || n == 0 {
 		return ""
 	}
 
-	// Check for escape sequence (arrow keys)
 	if buf[0] == 27 { // ESC
-		// Try to read more bytes for escape sequence
-		os.Stdin.Read(buf[1:3])
-		if buf[1] == '[' {
-			switch buf[2] {
-			case 'A':
-				return "UP"
-			case 'B':
-				return "DOWN"
-			case 'C':
-				return "RIGHT"
-			case 'D':
-				return "LEFT"
-			}
-		}
-		return "ESC"
+		return readEscapeSequence(f)
 	}
 
 	switch buf[0] {
@@ -112,6 +92,138 @@ func ReadKey() string {
 	}
 }
 
+// readEscapeSequence decodes the bytes after an ESC. Each follow-up byte is
+// read with EscapeTimeout, so a lone Esc returns immediately instead of
+// blocking for bytes that never come.
+func readEscapeSequence(f *os.File) string {
+	next, ok := readByteWithin(f, EscapeTimeout)
+	if !ok {
+		return "ESC"
+	}
+	if next != '[' && next != 'O' {
+		// Alt+key or an unknown two-byte sequence
+		return ""
+	}
+
+	// CSI: optional parameter bytes (0x30–0x3F) then a final byte. Reading
+	// through the final byte keeps sequences like ESC[1;5A or ESC[3~ from
+	// leaking their tail into the next ReadKey.
+	var params []byte
+	final, ok := readByteWithin(f, EscapeTimeout)
+	for ok && final >= 0x30 && final <= 0x3F {
+		params = append(params, final)
+		final, ok = readByteWithin(f, EscapeTimeout)
+	}
+	if !ok {
+		return "ESC"
+	}
+
+	if next == '[' && final == 'M' && len(params) == 0 {
+		return readX10Mouse(f)
+	}
+	if next == '[' && (final == 'M' || final == 'm') && len(params) > 0 && params[0] == '<' {
+		return decodeSGRMouse(string(params[1:]), final == 'm')
+	}
+
+	switch final {
+	case 'A':
+		return "UP"
+	case 'B':
+		return "DOWN"
+	case 'C':
+		return "RIGHT"
+	case 'D':
+		return "LEFT"
+	}
+	return ""
+}
+
+// readX10Mouse decodes a normal-encoding mouse report: ESC [ M followed by
+// button, column, and row bytes, each offset by 32.
+func readX10Mouse(f *os.File) string {
+	var raw [3]byte
+	for i := range raw {
+		b, ok := readByteWithin(f, EscapeTimeout)
+		if !ok {
+			return ""
+		}
+		raw[i] = b
+	}
+	button := int(raw[0]) - 32
+	return mouseEvent(button, int(raw[2])-32, int(raw[1])-32, button&3 == 3)
+}
+
+// decodeSGRMouse decodes the "b;x;y" parameters of an SGR mouse report.
+func decodeSGRMouse(params string, release bool) string {
+	parts := strings.Split(params, ";")
+	if len(parts) != 3 {
+		return ""
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return ""
+		}
+		nums[i] = n
+	}
+	return mouseEvent(nums[0], nums[2], nums[1], release)
+}
+
+// mouseEvent maps a decoded report to a key token: wheel to UP/DOWN, a left
+// button press to CLICK, everything else (drags, releases, other buttons)
+// to an empty string.
+func mouseEvent(button, row, col int, release bool) string {
+	switch {
+	case button&64 != 0 && button&1 == 0:
+		return "UP"
+	case button&64 != 0:
+		return "DOWN"
+	case release || button&32 != 0 || button&3 != 0:
+		return ""
+	}
+	return fmt.Sprintf("CLICK %d %d", row, col)
+}
+
+// ParseClick extracts the 1-based screen row and column from a CLICK token.
+func ParseClick(key string) (row, col int, ok bool) {
+	if _, err := fmt.Sscanf(key, "CLICK %d %d", &row, &col); err != nil {
+		return 0, 0, false
+	}
+	return row, col, true
+}
+
+// NonInteractiveEnv names the environment variable that, set to 1, true, or
+// yes, turns off interactive behavior like the --no-interactive flag.
+const NonInteractiveEnv = "TODO_NONINTERACTIVE"
+
+var nonInteractive bool
+
+// SetNonInteractive turns forced non-interactive mode on or off.
+func SetNonInteractive(v bool) {
+	nonInteractive = v
+}
+
+// InteractionDisabled reports whether --no-interactive or TODO_NONINTERACTIVE
+// forbids prompts, editors, and the interactive list.
+func InteractionDisabled() bool {
+	if nonInteractive {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(NonInteractiveEnv))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// ShouldInteract reports whether a command may prompt or take over the
+// terminal. Some CI runners hand out a TTY, so IsInteractiveTerminal alone
+// can leave a job waiting for keys; InteractionDisabled covers those.
+func ShouldInteract() bool {
+	return !InteractionDisabled() && IsInteractiveTerminal()
+}
+
 // IsInteractiveTerminal checks if stdin is a terminal
 func IsInteractiveTerminal() bool {
 	inFD := int(os.Stdin.Fd())
@@ -162,6 +274,31 @@ func StatusColor(status string) string {
 		return Yellow
 	case "tech-debt":
 		return Magenta
+	}
+	if cs, ok := types.LookupCustomStatus(status); ok {
+		return NamedColor(cs.Color)
+	}
+	return White
+}
+
+// NamedColor maps a custom status color name to its current color code,
+// falling back to White for unknown or empty names
+func NamedColor(name string) string {
+	switch name {
+	case "red":
+		return Red
+	case "green":
+		return Green
+	case "yellow":
+		return Yellow
+	case "blue":
+		return Blue
+	case "magenta":
+		return Magenta
+	case "cyan":
+		return Cyan
+	case "gray":
+		return BrightBlack
 	default:
 		return White
 	}
@@ -180,13 +317,62 @@ func StatusIcon(status string) string {
 		return "◔"
 	case "tech-debt":
 		return "⚠"
-	default:
-		return "○"
+	}
+	if cs, ok := types.LookupCustomStatus(status); ok && cs.Icon != "" {
+		return cs.Icon
+	}
+	return "○"
+}
+
+// PriorityColor returns the color for a priority level, matching the web UI
+// badges. Unknown or empty priorities are treated as medium.
+func PriorityColor(priority string) string {
+	switch priority {
+	case "high":
+		return BrightRed
+	case "low":
+		return Dim
+	}
+	return Yellow
+}
+
+// PriorityIcon returns a compact arrow for a priority level
+func PriorityIcon(priority string) string {
+	switch priority {
+	case "high":
+		return "↑"
+	case "low":
+		return "↓"
+	}
+	return "→"
+}
+
+// quiet suppresses decorative output for scripting: headers, success, info
+// and dim lines vanish, while warnings and errors move to stderr.
+var quiet bool
+
+// SetQuiet turns quiet mode on or off.
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// IsQuiet reports whether quiet mode is on.
+func IsQuiet() bool {
+	return quiet
+}
+
+// PrintBlank prints an empty separator line unless quiet.
+func PrintBlank() {
+	if !quiet {
+		fmt.Println()
 	}
 }
 
 // PrintHeader prints a styled header box
 func PrintHeader(title, icon string) {
+	if quiet {
+		return
+	}
 	const baseWidth = 55 // minimum inner width between vertical borders
 
 	iconWidth := runewidth.StringWidth(icon)
@@ -208,26 +394,43 @@ func PrintHeader(title, icon string) {
 
 // PrintSuccess prints a success message
 func PrintSuccess(msg string) {
+	if quiet {
+		return
+	}
 	fmt.Printf("  %s%s✓ %s%s\n", BrightGreen, Bold, msg, Reset)
 }
 
 // PrintError prints an error message
 func PrintError(msg string) {
+	if quiet {
+		fmt.Fprintf(os.Stderr, "%s✗ %s%s\n", BrightRed, msg, Reset)
+		return
+	}
 	fmt.Printf("  %s%s✗ %s%s\n", BrightRed, Bold, msg, Reset)
 }
 
 // PrintWarning prints a warning message
 func PrintWarning(msg string) {
+	if quiet {
+		fmt.Fprintf(os.Stderr, "%s⚠ %s%s\n", BrightYellow, msg, Reset)
+		return
+	}
 	fmt.Printf("  %s%s⚠ %s%s\n", BrightYellow, Bold, msg, Reset)
 }
 
 // PrintInfo prints an info message
 func PrintInfo(msg string) {
+	if quiet {
+		return
+	}
 	fmt.Printf("  %s%sℹ %s%s\n", BrightBlue, Bold, msg, Reset)
 }
 
 // PrintDim prints a dimmed message
 func PrintDim(msg string) {
+	if quiet {
+		return
+	}
 	fmt.Printf("  %s%s%s\n", Dim, msg, Reset)
 }
 
@@ -0,0 +1,99 @@
+package terminal
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadKeyFromEscapeSequences(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	feed := func(b string) {
+		t.Helper()
+		if _, err := w.Write([]byte(b)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"arrows", "\x1b[A\x1b[B\x1b[C\x1b[D", []string{"UP", "DOWN", "RIGHT", "LEFT"}},
+		{"plain keys", "j \r\x7f", []string{"j", "SPACE", "ENTER", "BACKSPACE"}},
+		{"lone escape", "\x1b", []string{"ESC"}},
+		{"truncated CSI", "\x1b[", []string{"ESC"}},
+		{"modified arrow is read whole", "\x1b[1;5Aj", []string{"UP", "j"}},
+		{"delete key is consumed whole", "\x1b[3~k", []string{"", "k"}},
+		{"pasted sequences stay in sync", "\x1b[B\x1b[Bq", []string{"DOWN", "DOWN", "q"}},
+		{"SGR click and release", "\x1b[<0;5;9M\x1b[<0;5;9m", []string{"CLICK 9 5", ""}},
+		{"SGR wheel", "\x1b[<64;1;1M\x1b[<65;1;1M", []string{"UP", "DOWN"}},
+		{"SGR right click ignored", "\x1b[<2;3;4Mj", []string{"", "j"}},
+		{"X10 click", "\x1b[M\x20\x25\x29", []string{"CLICK 9 5"}},
+		{"X10 release", "\x1b[M\x23\x25\x29k", []string{"", "k"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed(tt.input)
+			for i, want := range tt.want {
+				start := time.Now()
+				if got := ReadKeyFrom(r); got != want {
+					t.Fatalf("key %d: got %q, want %q", i, got, want)
+				}
+				if elapsed := time.Since(start); elapsed > time.Second {
+					t.Fatalf("key %d took %v; escape read should be bounded", i, elapsed)
+				}
+			}
+		})
+	}
+}
+
+func TestParseClick(t *testing.T) {
+	if row, col, ok := ParseClick("CLICK 12 3"); !ok || row != 12 || col != 3 {
+		t.Fatalf("ParseClick = %d, %d, %v", row, col, ok)
+	}
+	for _, key := range []string{"", "j", "UP", "CLICK x"} {
+		if _, _, ok := ParseClick(key); ok {
+			t.Errorf("ParseClick(%q) should fail", key)
+		}
+	}
+}
+
+func TestInteractionDisabled(t *testing.T) {
+	defer SetNonInteractive(false)
+
+	tests := []struct {
+		name string
+		flag bool
+		env  string
+		want bool
+	}{
+		{"default", false, "", false},
+		{"flag", true, "", true},
+		{"env 1", false, "1", true},
+		{"env true", false, "TRUE", true},
+		{"env yes", false, " yes ", true},
+		{"env 0", false, "0", false},
+		{"env false", false, "false", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(NonInteractiveEnv, tt.env)
+			SetNonInteractive(tt.flag)
+			if got := InteractionDisabled(); got != tt.want {
+				t.Errorf("InteractionDisabled() = %v, want %v", got, tt.want)
+			}
+			if tt.want && ShouldInteract() {
+				t.Error("ShouldInteract() = true while interaction is disabled")
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package terminal
+
+import (
+	"os"
+	"time"
+)
+
+// readByteWithin reads one byte from f. Without poll(2) the timeout cannot be
+// enforced, so this blocks like a plain read.
+func readByteWithin(f *os.File, timeout time.Duration) (byte, bool) {
+	var buf [1]byte
+	if n, err := f.Read(buf[:]); err != nil || n == 0 {
+		return 0, false
+	}
+	return buf[0], true
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package terminal
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// readByteWithin reads one byte from f if it becomes readable within timeout.
+func readByteWithin(f *os.File, timeout time.Duration) (byte, bool) {
+	fds := []unix.PollFd{{Fd: int32(f.Fd()), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, int(timeout/time.Millisecond))
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil || n == 0 {
+			return 0, false
+		}
+		break
+	}
+
+	var buf [1]byte
+	if n, err := f.Read(buf[:]); err != nil || n == 0 {
+		return 0, false
+	}
+	return buf[0], true
+}
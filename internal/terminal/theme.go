@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bagadi-alnour/todo-cli/internal/types"
+)
+
+// Theme is a palette: the code each style variable holds while it is active.
+type Theme struct {
+	Reset, Bold, Dim, Italic, Underline string
+
+	Black, Red, Green, Yellow, Blue, Magenta, Cyan, White string
+
+	BrightBlack, BrightRed, BrightGreen, BrightYellow  string
+	BrightBlue, BrightMagenta, BrightCyan, BrightWhite string
+}
+
+// DefaultTheme is the standard palette, tuned for dark backgrounds.
+var DefaultTheme = Theme{
+	Reset: "\033[0m", Bold: "\033[1m", Dim: "\033[2m", Italic: "\033[3m", Underline: "\033[4m",
+
+	Black: "\033[30m", Red: "\033[31m", Green: "\033[32m", Yellow: "\033[33m",
+	Blue: "\033[34m", Magenta: "\033[35m", Cyan: "\033[36m", White: "\033[37m",
+
+	BrightBlack: "\033[90m", BrightRed: "\033[91m", BrightGreen: "\033[92m", BrightYellow: "\033[93m",
+	BrightBlue: "\033[94m", BrightMagenta: "\033[95m", BrightCyan: "\033[96m", BrightWhite: "\033[97m",
+}
+
+// LightTheme swaps the pale and bright colors, which wash out on light
+// backgrounds, for their darker counterparts.
+var LightTheme = func() Theme {
+	t := DefaultTheme
+	t.White, t.BrightWhite = DefaultTheme.Black, DefaultTheme.Black
+	t.BrightYellow = DefaultTheme.Yellow
+	t.BrightCyan, t.Cyan = DefaultTheme.Blue, DefaultTheme.Blue
+	t.BrightGreen = DefaultTheme.Green
+	t.BrightRed = DefaultTheme.Red
+	t.BrightBlue = DefaultTheme.Blue
+	t.BrightMagenta = DefaultTheme.Magenta
+	return t
+}()
+
+// MonoTheme drops every color but keeps bold, dim, italic and underline, so
+// emphasis survives without relying on hue.
+var MonoTheme = Theme{
+	Reset: DefaultTheme.Reset, Bold: DefaultTheme.Bold, Dim: DefaultTheme.Dim,
+	Italic: DefaultTheme.Italic, Underline: DefaultTheme.Underline,
+}
+
+// themes maps each name in types.Themes to its palette.
+var themes = map[string]Theme{
+	"default": DefaultTheme,
+	"light":   LightTheme,
+	"mono":    MonoTheme,
+}
+
+var activeTheme = DefaultTheme
+
+// SetTheme switches the palette by name; "" selects the default. With colors
+// disabled the palette is remembered but nothing is emitted until they are
+// enabled again.
+func SetTheme(name string) error {
+	if name == "" {
+		name = "default"
+	}
+	theme, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme: %q (use %s)", name, strings.Join(types.Themes, ", "))
+	}
+	activeTheme = theme
+	applyTheme()
+	return nil
+}
+
+// bindings pairs every style variable with its code in t.
+func (t *Theme) bindings() []struct {
+	ptr  *string
+	code string
+} {
+	return []struct {
+		ptr  *string
+		code string
+	}{
+		{&Reset, t.Reset}, {&Bold, t.Bold}, {&Dim, t.Dim}, {&Italic, t.Italic}, {&Underline, t.Underline},
+		{&Black, t.Black}, {&Red, t.Red}, {&Green, t.Green}, {&Yellow, t.Yellow},
+		{&Blue, t.Blue}, {&Magenta, t.Magenta}, {&Cyan, t.Cyan}, {&White, t.White},
+		{&BrightBlack, t.BrightBlack}, {&BrightRed, t.BrightRed}, {&BrightGreen, t.BrightGreen}, {&BrightYellow, t.BrightYellow},
+		{&BrightBlue, t.BrightBlue}, {&BrightMagenta, t.BrightMagenta}, {&BrightCyan, t.BrightCyan}, {&BrightWhite, t.BrightWhite},
+	}
+}
+
+// applyTheme writes the active palette into the style variables, or blanks
+// them when colors are disabled.
+func applyTheme() {
+	for _, b := range activeTheme.bindings() {
+		if colorsEnabled {
+			*b.ptr = b.code
+		} else {
+			*b.ptr = ""
+		}
+	}
+}
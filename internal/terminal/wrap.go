@@ -0,0 +1,79 @@
+package terminal
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// defaultWidth is the line width assumed when stdout isn't a terminal and
+// COLUMNS is unset.
+const defaultWidth = 80
+
+// escapeSequence matches CSI sequences (colors, cursor movement) and OSC 8
+// hyperlink markers, which take no space on screen.
+var escapeSequence = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]|\x1b\\]8;[^\x1b\a]*(\x1b\\\\|\a)")
+
+// Width returns the width of the terminal on stdout, falling back to
+// $COLUMNS and then 80 columns.
+func Width() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return defaultWidth
+}
+
+// VisibleWidth returns how many columns s takes on screen: escape sequences
+// count for nothing and wide characters (CJK, most emoji) for two.
+func VisibleWidth(s string) int {
+	return runewidth.StringWidth(escapeSequence.ReplaceAllString(s, ""))
+}
+
+// Wrap breaks s into lines of at most width columns, at spaces where it can.
+// Words wider than a line are split. Runs of spaces collapse into one.
+func Wrap(s string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	line, lineWidth := "", 0
+	flush := func() {
+		lines = append(lines, line)
+		line, lineWidth = "", 0
+	}
+	for _, word := range strings.Fields(s) {
+		wordWidth := runewidth.StringWidth(word)
+		if lineWidth > 0 && lineWidth+1+wordWidth <= width {
+			line += " " + word
+			lineWidth += 1 + wordWidth
+			continue
+		}
+		if lineWidth > 0 {
+			flush()
+		}
+		for wordWidth > width {
+			head := runewidth.Truncate(word, width, "")
+			if head == "" {
+				// A single character wider than the line; place it anyway.
+				_, size := utf8.DecodeRuneInString(word)
+				head = word[:size]
+			}
+			lines = append(lines, head)
+			word = word[len(head):]
+			wordWidth = runewidth.StringWidth(word)
+		}
+		line, lineWidth = word, wordWidth
+	}
+	if lineWidth > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
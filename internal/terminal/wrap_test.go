@@ -0,0 +1,33 @@
+package terminal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	cases := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"short", 20, []string{"short"}},
+		{"", 20, []string{""}},
+		{"fix the  login flow today", 10, []string{"fix the", "login flow", "today"}},
+		{"abcdefghijkl xy", 5, []string{"abcde", "fghij", "kl xy"}},
+		{"日本語のテキスト", 6, []string{"日本語", "のテキ", "スト"}},
+		{"a 日本", 3, []string{"a", "日", "本"}},
+	}
+	for _, c := range cases {
+		if got := Wrap(c.text, c.width); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", c.text, c.width, got, c.want)
+		}
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	s := "\033[2m1.\033[0m \033]8;;https://example.com\033\\link\033]8;;\033\\ 日本"
+	if got := VisibleWidth(s); got != 12 {
+		t.Fatalf("VisibleWidth = %d, want 12", got)
+	}
+}
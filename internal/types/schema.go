@@ -0,0 +1,13 @@
+package types
+
+import _ "embed"
+
+//go:embed todo-file.schema.json
+var todoFileSchema []byte
+
+// TodoFileSchema returns the JSON Schema (draft 2020-12) of a TodoFile. It is
+// maintained by hand next to the structs; a test keeps its properties and
+// enums in step with them.
+func TodoFileSchema() []byte {
+	return append([]byte(nil), todoFileSchema...)
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type schemaNode struct {
+	Properties map[string]schemaNode `json:"properties"`
+	Defs       map[string]schemaNode `json:"$defs"`
+	Enum       []string              `json:"enum"`
+	AnyOf      []schemaNode          `json:"anyOf"`
+}
+
+// TestTodoFileSchemaMatchesStructs fails when a field is added to or removed
+// from the todo structs without updating todo-file.schema.json.
+func TestTodoFileSchemaMatchesStructs(t *testing.T) {
+	var root schemaNode
+	if err := json.Unmarshal(TodoFileSchema(), &root); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	jsonFields := func(v any) []string {
+		var names []string
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	keys := func(props map[string]schemaNode) []string {
+		var names []string
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if got, want := keys(root.Properties), jsonFields(TodoFile{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("TodoFile: schema has %v, struct has %v", got, want)
+	}
+	for def, v := range map[string]any{
+		"todo":         Todo{},
+		"context":      Context{},
+		"location":     Location{},
+		"meta":         Meta{},
+		"statusChange": StatusChange{},
+	} {
+		if got, want := keys(root.Defs[def].Properties), jsonFields(v); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: schema has %v, struct has %v", def, got, want)
+		}
+	}
+
+	var statuses []string
+	for _, s := range BuiltinStatuses() {
+		statuses = append(statuses, string(s))
+	}
+	if status := root.Defs["status"]; len(status.AnyOf) == 0 || !reflect.DeepEqual(status.AnyOf[0].Enum, statuses) {
+		t.Errorf("status enum = %+v, want %v", status.AnyOf, statuses)
+	}
+
+	enums := map[string][]string{
+		"priority": {string(PriorityLow), string(PriorityMedium), string(PriorityHigh)},
+		"recur":    {string(RecurDaily), string(RecurWeekly), string(RecurMonthly)},
+	}
+	for field, want := range enums {
+		if got := root.Defs["todo"].Properties[field].Enum; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s enum = %v, want %v", field, got, want)
+		}
+	}
+	if got := root.Defs["meta"].Properties["source"].Enum; !reflect.DeepEqual(got, Sources) {
+		t.Errorf("source enum = %v, want %v", got, Sources)
+	}
+}
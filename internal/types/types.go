@@ -1,8 +1,14 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 )
 
@@ -17,11 +23,21 @@ const (
 	StatusTechDebt Status = "tech-debt"
 )
 
-// ValidStatuses returns all valid status values
-func ValidStatuses() []Status {
+// BuiltinStatuses returns the statuses every project supports
+func BuiltinStatuses() []Status {
 	return []Status{StatusOpen, StatusDone, StatusBlocked, StatusWaiting, StatusTechDebt}
 }
 
+// ValidStatuses returns the built-in statuses followed by the registered
+// custom statuses
+func ValidStatuses() []Status {
+	statuses := BuiltinStatuses()
+	for _, cs := range CustomStatuses() {
+		statuses = append(statuses, Status(cs.Name))
+	}
+	return statuses
+}
+
 // IsValid checks if a status is valid
 func (s Status) IsValid() bool {
 	for _, valid := range ValidStatuses() {
@@ -32,6 +48,93 @@ func (s Status) IsValid() bool {
 	return false
 }
 
+// IsBuiltin reports whether s is one of the built-in statuses
+func (s Status) IsBuiltin() bool {
+	for _, builtin := range BuiltinStatuses() {
+		if s == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomStatus is a project-defined status from config.json
+type CustomStatus struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// StatusColorNames lists the colors a custom status may use
+var StatusColorNames = []string{"red", "green", "yellow", "blue", "magenta", "cyan", "white", "gray"}
+
+var (
+	customStatusesMu sync.RWMutex
+	customStatuses   []CustomStatus
+)
+
+// SetCustomStatuses registers the project's custom statuses so IsValid and
+// the terminal status styles know about them. Pass nil to clear.
+func SetCustomStatuses(statuses []CustomStatus) {
+	customStatusesMu.Lock()
+	defer customStatusesMu.Unlock()
+	customStatuses = append([]CustomStatus(nil), statuses...)
+}
+
+// CustomStatuses returns the registered custom statuses
+func CustomStatuses() []CustomStatus {
+	customStatusesMu.RLock()
+	defer customStatusesMu.RUnlock()
+	return append([]CustomStatus(nil), customStatuses...)
+}
+
+// LookupCustomStatus returns the registered custom status with the given name
+func LookupCustomStatus(name string) (CustomStatus, bool) {
+	for _, cs := range CustomStatuses() {
+		if cs.Name == name {
+			return cs, true
+		}
+	}
+	return CustomStatus{}, false
+}
+
+// ValidateCustomStatuses checks that custom status names are lowercase
+// identifiers that are unique and don't shadow a built-in, and that colors
+// are known.
+func ValidateCustomStatuses(statuses []CustomStatus) error {
+	seen := make(map[string]bool)
+	for _, cs := range statuses {
+		if cs.Name == "" {
+			return fmt.Errorf("custom status name cannot be empty")
+		}
+		for _, r := range cs.Name {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("custom status %q may only contain lowercase letters, digits, and '-'", cs.Name)
+			}
+		}
+		if Status(cs.Name).IsBuiltin() {
+			return fmt.Errorf("custom status %q collides with a built-in status", cs.Name)
+		}
+		if seen[cs.Name] {
+			return fmt.Errorf("custom status %q is defined more than once", cs.Name)
+		}
+		seen[cs.Name] = true
+		if cs.Color != "" && !containsString(StatusColorNames, cs.Color) {
+			return fmt.Errorf("custom status %q has unknown color %q (use %s)", cs.Name, cs.Color, strings.Join(StatusColorNames, ", "))
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // Priority represents the priority level of a todo
 type Priority string
 
@@ -46,6 +149,91 @@ func (p Priority) IsValid() bool {
 	return p == PriorityLow || p == PriorityMedium || p == PriorityHigh
 }
 
+// ParsePriority normalizes a user-supplied priority. Besides the full names it
+// accepts the shorthands h/hi, m/med and l/lo, in any case.
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "high", "hi", "h":
+		return PriorityHigh, nil
+	case "medium", "med", "m":
+		return PriorityMedium, nil
+	case "low", "lo", "l":
+		return PriorityLow, nil
+	}
+	return "", &InvalidPriorityError{Priority: s}
+}
+
+// ParseDueDate parses a due date as the CLI and the web API accept it:
+// today, tomorrow, +N with h, d, or w, RFC3339, YYYY-MM-DDTHH:MM (or with a
+// space), or YYYY-MM-DD. Dates without a time, and day or week offsets, fall
+// at the end of the day in now's location.
+func ParseDueDate(input string, now time.Time) (*time.Time, error) {
+	raw := strings.TrimSpace(strings.ToLower(input))
+	if raw == "" {
+		return nil, fmt.Errorf("due date cannot be empty")
+	}
+
+	switch raw {
+	case "today":
+		due := endOfDay(now)
+		return &due, nil
+	case "tomorrow":
+		due := endOfDay(now.Add(24 * time.Hour))
+		return &due, nil
+	}
+
+	if strings.HasPrefix(raw, "+") && len(raw) > 2 {
+		amount, err := strconv.Atoi(raw[1 : len(raw)-1])
+		if err == nil && amount >= 0 {
+			unit := raw[len(raw)-1]
+			switch unit {
+			case 'h':
+				due := now.Add(time.Duration(amount) * time.Hour)
+				return &due, nil
+			case 'd':
+				due := endOfDay(now.Add(time.Duration(amount) * 24 * time.Hour))
+				return &due, nil
+			case 'w':
+				due := endOfDay(now.Add(time.Duration(amount) * 7 * 24 * time.Hour))
+				return &due, nil
+			}
+		}
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, input); err == nil {
+		due := parsed
+		return &due, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+		if parsed, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			due := parsed
+			return &due, nil
+		}
+	}
+
+	if parsed, err := time.ParseInLocation("2006-01-02", input, now.Location()); err == nil {
+		due := endOfDay(parsed)
+		return &due, nil
+	}
+
+	return nil, fmt.Errorf("invalid due date %q (use YYYY-MM-DD, YYYY-MM-DDTHH:MM, RFC3339, today, tomorrow, +2d, +1w, or +6h)", input)
+}
+
+// endOfDay is the last second of t's day, in t's location.
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 23, 59, 59, 0, t.Location())
+}
+
+// ValidateEstimate checks an effort estimate in story points
+func ValidateEstimate(points int) error {
+	if points < 0 {
+		return fmt.Errorf("invalid estimate: %d. Estimates must be zero or more points", points)
+	}
+	return nil
+}
+
 // PriorityWeight gives a numeric weight for sorting (high first)
 func (p Priority) PriorityWeight() int {
 	switch p {
@@ -60,11 +248,26 @@ func (p Priority) PriorityWeight() int {
 	}
 }
 
+// Location points at a specific line within a file
+type Location struct {
+	Path string `json:"path"`
+	Line int    `json:"line,omitempty"`
+}
+
+// String formats the location as path:line (or just path when no line is set)
+func (l Location) String() string {
+	if l.Line > 0 {
+		return fmt.Sprintf("%s:%d", l.Path, l.Line)
+	}
+	return l.Path
+}
+
 // Context holds contextual information about where the todo applies
 type Context struct {
-	Paths  []string `json:"paths,omitempty"`
-	Branch string   `json:"branch,omitempty"`
-	Commit string   `json:"commit,omitempty"`
+	Paths     []string   `json:"paths,omitempty"`
+	Locations []Location `json:"locations,omitempty"`
+	Branch    string     `json:"branch,omitempty"`
+	Commit    string     `json:"commit,omitempty"`
 }
 
 // Meta holds metadata about the todo
@@ -73,6 +276,17 @@ type Meta struct {
 	AIHint string `json:"aiHint,omitempty"`
 }
 
+// Sources that create todos, recorded in Meta.Source.
+const (
+	SourceCLI    = "cli"
+	SourceWeb    = "web"
+	SourceImport = "import"
+	SourceScan   = "scan"
+)
+
+// Sources lists the known Meta.Source values.
+var Sources = []string{SourceCLI, SourceWeb, SourceImport, SourceScan}
+
 // Recurrence specifies how a todo repeats when completed.
 type Recurrence string
 
@@ -101,25 +315,87 @@ func (r Recurrence) NextDue(from time.Time) time.Time {
 	}
 }
 
+// Duration is a time.Duration stored in JSON as a number of whole seconds,
+// which other tools can read without knowing Go's nanosecond units.
+type Duration time.Duration
+
+// MarshalJSON writes the duration as whole seconds
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(time.Duration(d) / time.Second))
+}
+
+// UnmarshalJSON reads a number of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("duration must be a number of seconds: %w", err)
+	}
+	*d = Duration(seconds * float64(time.Second))
+	return nil
+}
+
 // Todo represents a single todo item
 type Todo struct {
-	ID          string     `json:"id"`
-	Text        string     `json:"text"`
-	Notes       string     `json:"notes,omitempty"`
-	Status      Status     `json:"status"`
-	Priority    Priority   `json:"priority,omitempty"`
-	Tags        []string   `json:"tags,omitempty"`
-	DueAt       *time.Time `json:"dueAt,omitempty"`
-	Recur       Recurrence `json:"recur,omitempty"`
-	BlockedBy   []string   `json:"blockedBy,omitempty"`
-	Blocks      []string   `json:"blocks,omitempty"`
-	Assignee    string     `json:"assignee,omitempty"` // canonical git author email
-	CreatedBy   string     `json:"createdBy,omitempty"` // owner slug: firstname-lastname (git user.name)
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	CompletedAt *time.Time `json:"completedAt,omitempty"`
-	Context     Context    `json:"context"`
-	Meta        Meta       `json:"meta,omitempty"`
+	ID           string         `json:"id"`
+	Text         string         `json:"text"`
+	Notes        string         `json:"notes,omitempty"`
+	Status       Status         `json:"status"`
+	Priority     Priority       `json:"priority,omitempty"`
+	Estimate     int            `json:"estimate,omitempty"` // effort in story points; 0 means unestimated
+	Tags         []string       `json:"tags,omitempty"`
+	DueAt        *time.Time     `json:"dueAt,omitempty"`
+	Recur        Recurrence     `json:"recur,omitempty"`
+	BlockedBy    []string       `json:"blockedBy,omitempty"`
+	Blocks       []string       `json:"blocks,omitempty"`
+	Assignee     string         `json:"assignee,omitempty"`  // canonical git author email
+	CreatedBy    string         `json:"createdBy,omitempty"` // owner slug: firstname-lastname (git user.name)
+	Author       string         `json:"author,omitempty"`    // git user.name as written when the todo was created
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+	CompletedAt  *time.Time     `json:"completedAt,omitempty"`
+	LastReviewed *time.Time     `json:"lastReviewed,omitempty"` // last `todo touch`: still relevant as is
+	TimeSpent    Duration       `json:"timeSpent,omitempty"`    // tracked time from finished start/stop sessions
+	StartedAt    *time.Time     `json:"startedAt,omitempty"`    // set while a timer is running
+	StartedBy    string         `json:"startedBy,omitempty"`    // owner slug of whoever started the running timer
+	Context      Context        `json:"context"`
+	Meta         Meta           `json:"meta,omitempty"`
+	History      []StatusChange `json:"history,omitempty"`
+}
+
+// MaxStatusHistory bounds how many status changes are kept per todo so
+// frequently toggled todos don't grow the file without limit.
+const MaxStatusHistory = 20
+
+// TodoTemplate holds defaults for a kind of todo (bug, chore, ...), saved with
+// todo template save and applied by todo add --template.
+type TodoTemplate struct {
+	Priority Priority   `json:"priority,omitempty"`
+	Paths    []string   `json:"paths,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
+	Notes    string     `json:"notes,omitempty"`
+	Estimate int        `json:"estimate,omitempty"`
+	Recur    Recurrence `json:"recur,omitempty"`
+}
+
+// Validate checks the template's priority, estimate, and recurrence.
+func (t TodoTemplate) Validate() error {
+	if t.Priority != "" && !t.Priority.IsValid() {
+		return &InvalidPriorityError{Priority: string(t.Priority)}
+	}
+	if err := ValidateEstimate(t.Estimate); err != nil {
+		return err
+	}
+	if t.Recur != "" && !t.Recur.IsValid() {
+		return fmt.Errorf("invalid recurrence: %s. Use: daily, weekly, monthly", t.Recur)
+	}
+	return nil
+}
+
+// StatusChange records a single status transition of a todo
+type StatusChange struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
 }
 
 // NewTodo creates a new todo with default values
@@ -134,7 +410,7 @@ func NewTodo(id, text string) *Todo {
 		UpdatedAt: now,
 		Context:   Context{},
 		Meta: Meta{
-			Source: "cli",
+			Source: SourceCLI,
 		},
 	}
 }
@@ -145,6 +421,29 @@ func (t *Todo) SetPaths(paths []string) {
 	t.UpdatedAt = time.Now()
 }
 
+// AddLocation records a file location. The location's path is also added to
+// Paths so path-based filters keep working for older readers.
+func (t *Todo) AddLocation(loc Location) {
+	for _, existing := range t.Context.Locations {
+		if existing == loc {
+			return
+		}
+	}
+	t.Context.Locations = append(t.Context.Locations, loc)
+
+	hasPath := false
+	for _, p := range t.Context.Paths {
+		if p == loc.Path {
+			hasPath = true
+			break
+		}
+	}
+	if !hasPath {
+		t.Context.Paths = append(t.Context.Paths, loc.Path)
+	}
+	t.UpdatedAt = time.Now()
+}
+
 // SetGitContext sets the git context (branch and commit)
 func (t *Todo) SetGitContext(branch, commit string) {
 	t.Context.Branch = branch
@@ -152,21 +451,99 @@ func (t *Todo) SetGitContext(branch, commit string) {
 	t.UpdatedAt = time.Now()
 }
 
-// MarkDone marks the todo as done
+// recordStatus appends a history entry for a change to the given status,
+// dropping the oldest entries beyond MaxStatusHistory. No-op transitions are
+// not recorded.
+func (t *Todo) recordStatus(to Status, at time.Time) {
+	if t.Status == to {
+		return
+	}
+	t.History = append(t.History, StatusChange{From: t.Status, To: to, At: at})
+	if len(t.History) > MaxStatusHistory {
+		t.History = append([]StatusChange(nil), t.History[len(t.History)-MaxStatusHistory:]...)
+	}
+}
+
+// StartTimer begins a timing session for the user with owner slug by. It is
+// a no-op if one is running.
+func (t *Todo) StartTimer(now time.Time, by string) {
+	if t.StartedAt != nil {
+		return
+	}
+	t.StartedAt = &now
+	t.StartedBy = by
+	t.UpdatedAt = now
+}
+
+// StopTimer ends the running session, adds it to TimeSpent and returns its
+// length. ok is false when no timer was running.
+func (t *Todo) StopTimer(now time.Time) (session time.Duration, ok bool) {
+	if t.StartedAt == nil {
+		return 0, false
+	}
+	session = now.Sub(*t.StartedAt)
+	if session < 0 {
+		session = 0
+	}
+	t.TimeSpent += Duration(session)
+	t.StartedAt = nil
+	t.StartedBy = ""
+	t.UpdatedAt = now
+	return session, true
+}
+
+// TrackedTime returns TimeSpent plus the running session, if any
+func (t *Todo) TrackedTime(now time.Time) time.Duration {
+	total := time.Duration(t.TimeSpent)
+	if t.StartedAt != nil && now.After(*t.StartedAt) {
+		total += now.Sub(*t.StartedAt)
+	}
+	return total
+}
+
+// MarkDone marks the todo as done, stopping its timer if one is running
 func (t *Todo) MarkDone() {
-	t.Status = StatusDone
 	now := time.Now()
+	t.StopTimer(now)
+	t.recordStatus(StatusDone, now)
+	t.Status = StatusDone
 	t.UpdatedAt = now
 	t.CompletedAt = &now
 }
 
+// Touch records that the todo was reviewed and is still relevant, without
+// changing anything else
+func (t *Todo) Touch() {
+	now := time.Now()
+	t.LastReviewed = &now
+	t.UpdatedAt = now
+}
+
 // MarkOpen marks the todo as open
 func (t *Todo) MarkOpen() {
+	now := time.Now()
+	t.recordStatus(StatusOpen, now)
 	t.Status = StatusOpen
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
 	t.CompletedAt = nil
 }
 
+// SetStatus sets any status, keeping CompletedAt and History in sync
+func (t *Todo) SetStatus(status Status) {
+	switch status {
+	case StatusDone:
+		t.MarkDone()
+	case StatusOpen:
+		t.MarkOpen()
+	default:
+		now := time.Now()
+		t.recordStatus(status, now)
+		t.Status = status
+		t.UpdatedAt = now
+		t.CompletedAt = nil
+	}
+}
+
 // Toggle toggles between done and open status
 func (t *Todo) Toggle() {
 	if t.Status == StatusDone {
@@ -181,6 +558,182 @@ type Config struct {
 	Version       int    `json:"version"`
 	DefaultBranch string `json:"defaultBranch,omitempty"`
 	AutoGit       bool   `json:"autoGit"`
+	Editor        string `json:"editor,omitempty"`
+	// CustomStatuses adds project-specific statuses alongside the built-ins
+	CustomStatuses []CustomStatus `json:"customStatuses,omitempty"`
+	// LastSelected is the ID of the todo selected when the interactive list
+	// last closed, so the next session starts there
+	LastSelected string `json:"lastSelected,omitempty"`
+	// Theme picks the terminal color palette; empty means "default"
+	Theme string `json:"theme,omitempty"`
+	// FocusScope is what todo focus shows without --all or --branch: "branch"
+	// (the default when empty) or "all"
+	FocusScope string `json:"focusScope,omitempty"`
+	// StatusTransitions limits status changes: each key lists the statuses a
+	// todo in that status may move to. Statuses without a key, and every
+	// status when the map is empty, may move anywhere.
+	StatusTransitions map[Status][]Status `json:"statusTransitions,omitempty"`
+	// AutoCommit commits the todo files after add, done, and delete, as if
+	// --commit were given
+	AutoCommit bool `json:"autoCommit,omitempty"`
+	// CommitMessage is the text/template for those commits; empty means
+	// DefaultCommitMessage
+	CommitMessage string `json:"commitMessage,omitempty"`
+	// HistoryLimit is how many snapshots .todos/snapshots keeps; older ones
+	// are pruned after each write. nil means DefaultHistoryLimit and 0
+	// disables snapshots
+	HistoryLimit *int `json:"historyLimit,omitempty"`
+	// Locale is the BCP 47 language tag the web UI formats dates with;
+	// empty means DefaultLocale
+	Locale string `json:"locale,omitempty"`
+}
+
+// DefaultLocale is the web UI's date locale when Config.Locale is empty
+const DefaultLocale = "en-US"
+
+// DefaultHistoryLimit is how many snapshots are kept when
+// Config.HistoryLimit is unset
+const DefaultHistoryLimit = 20
+
+// DefaultCommitMessage is the commit message template used when
+// Config.CommitMessage is empty
+const DefaultCommitMessage = "todo: {{.Action}} {{.Summary}}"
+
+// CommitMessageData is what a commit message template sees
+type CommitMessageData struct {
+	// Action is add, done, or delete
+	Action string
+	// Summary is the todo's text, or "N todos" when there are several
+	Summary string
+	// Texts holds the text of every todo involved
+	Texts []string
+	Count int
+}
+
+// NewCommitMessageData describes action applied to todos with the given texts
+func NewCommitMessageData(action string, texts []string) CommitMessageData {
+	summary := fmt.Sprintf("%d todos", len(texts))
+	if len(texts) == 1 {
+		summary = texts[0]
+	}
+	return CommitMessageData{Action: action, Summary: summary, Texts: texts, Count: len(texts)}
+}
+
+// RenderCommitMessage executes a commit message template (empty means
+// DefaultCommitMessage) and rejects templates that render nothing.
+func RenderCommitMessage(tmpl string, data CommitMessageData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultCommitMessage
+	}
+	t, err := template.New("commitMessage").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", fmt.Errorf("commit message template renders an empty message")
+	}
+	return message, nil
+}
+
+// ValidateTransition reports whether config allows a todo to move from one
+// status to another. Staying in the same status is always allowed, and a nil
+// config allows everything.
+func ValidateTransition(from, to Status, config *Config) error {
+	if from == to || config == nil {
+		return nil
+	}
+	allowed, ok := config.StatusTransitions[from]
+	if !ok {
+		return nil
+	}
+	for _, s := range allowed {
+		if s == to {
+			return nil
+		}
+	}
+	return &TransitionError{From: from, To: to, Allowed: allowed}
+}
+
+// ValidateStatusTransitions checks that every status named in a transitions
+// map is a built-in status or one of custom.
+func ValidateStatusTransitions(transitions map[Status][]Status, custom []CustomStatus) error {
+	known := make(map[Status]bool)
+	for _, s := range BuiltinStatuses() {
+		known[s] = true
+	}
+	for _, cs := range custom {
+		known[Status(cs.Name)] = true
+	}
+	froms := make([]string, 0, len(transitions))
+	for from := range transitions {
+		froms = append(froms, string(from))
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		if !known[Status(from)] {
+			return fmt.Errorf("unknown status %q", from)
+		}
+		for _, to := range transitions[Status(from)] {
+			if !known[to] {
+				return fmt.Errorf("%s: unknown status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// FocusScopes lists the values Config.FocusScope accepts
+var FocusScopes = []string{"branch", "all"}
+
+// IsValidFocusScope reports whether scope is a known focus scope; empty
+// counts as branch
+func IsValidFocusScope(scope string) bool {
+	return scope == "" || scope == "branch" || scope == "all"
+}
+
+// Themes lists the terminal color themes Config.Theme accepts
+var Themes = []string{"default", "light", "mono"}
+
+// IsValidTheme reports whether name is a known theme; empty counts as default
+func IsValidTheme(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, t := range Themes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidLocale reports whether tag looks like a BCP 47 language tag such
+// as "de", "en-GB", or "zh-Hant-TW"; empty counts as DefaultLocale. Whether
+// the browser knows the locale is left to the browser.
+func IsValidLocale(tag string) bool {
+	if tag == "" {
+		return true
+	}
+	for i, sub := range strings.Split(tag, "-") {
+		if len(sub) < 1 || len(sub) > 8 {
+			return false
+		}
+		for _, r := range sub {
+			isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+		if i == 0 && len(sub) < 2 {
+			return false
+		}
+	}
+	return true
 }
 
 // DefaultConfig returns the default configuration
@@ -191,6 +744,10 @@ func DefaultConfig() *Config {
 	}
 }
 
+// TodoFileVersion is the todo file schema version written by this build.
+// Older files are upgraded on load by the storage migrations.
+const TodoFileVersion = 2
+
 // TodoFile represents the structure of the todos.json file
 type TodoFile struct {
 	Version int    `json:"version"`
@@ -200,7 +757,7 @@ type TodoFile struct {
 // NewTodoFile creates a new todo file with default values
 func NewTodoFile() *TodoFile {
 	return &TodoFile{
-		Version: 1,
+		Version: TodoFileVersion,
 		Todos:   []Todo{},
 	}
 }
@@ -229,13 +786,56 @@ func (e *TodoNotFoundError) Error() string {
 	return fmt.Sprintf("Todo not found: %s\n\nUse 'todo list' to see available todos.", e.ID)
 }
 
+// AmbiguousIDError indicates an ID prefix matched more than one todo
+type AmbiguousIDError struct {
+	ID      string
+	Matches []string
+}
+
+func (e *AmbiguousIDError) Error() string {
+	return fmt.Sprintf("Ambiguous ID: %s matches %d todos\n\nCandidates:\n  %s\n\nUse a longer ID prefix.", e.ID, len(e.Matches), strings.Join(e.Matches, "\n  "))
+}
+
 // InvalidStatusError indicates an invalid status was provided
 type InvalidStatusError struct {
 	Status string
 }
 
 func (e *InvalidStatusError) Error() string {
-	return fmt.Sprintf("Invalid status: %q\n\nValid statuses:\n  open, done, blocked, waiting, tech-debt", e.Status)
+	names := make([]string, 0, len(ValidStatuses()))
+	for _, status := range ValidStatuses() {
+		names = append(names, string(status))
+	}
+	return fmt.Sprintf("Invalid status: %q\n\nValid statuses:\n  %s", e.Status, strings.Join(names, ", "))
+}
+
+// TransitionError indicates a status change the project's statusTransitions
+// config doesn't allow
+type TransitionError struct {
+	From    Status
+	To      Status
+	Allowed []Status
+}
+
+func (e *TransitionError) Error() string {
+	allowed := "none"
+	if len(e.Allowed) > 0 {
+		names := make([]string, len(e.Allowed))
+		for i, s := range e.Allowed {
+			names[i] = string(s)
+		}
+		allowed = strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("Status change %s → %s is not allowed by statusTransitions in config.json (from %s: %s)", e.From, e.To, e.From, allowed)
+}
+
+// InvalidPriorityError indicates an invalid priority was provided
+type InvalidPriorityError struct {
+	Priority string
+}
+
+func (e *InvalidPriorityError) Error() string {
+	return fmt.Sprintf("Invalid priority: %q\n\nValid priorities:\n  high (h, hi), medium (m, med), low (l, lo)", e.Priority)
 }
 
 // AlreadyInitializedError indicates the project is already initialized
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := map[string]Priority{
+		"high":   PriorityHigh,
+		"hi":     PriorityHigh,
+		"h":      PriorityHigh,
+		"HIGH":   PriorityHigh,
+		"H":      PriorityHigh,
+		"medium": PriorityMedium,
+		"med":    PriorityMedium,
+		"m":      PriorityMedium,
+		"Med":    PriorityMedium,
+		"low":    PriorityLow,
+		"lo":     PriorityLow,
+		"l":      PriorityLow,
+		" low ":  PriorityLow,
+		"LO":     PriorityLow,
+	}
+	for in, want := range tests {
+		got, err := ParsePriority(in)
+		if err != nil || got != want {
+			t.Errorf("ParsePriority(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "urgent", "hgh", "mediu"} {
+		_, err := ParsePriority(in)
+		var invalid *InvalidPriorityError
+		if !errors.As(err, &invalid) {
+			t.Errorf("ParsePriority(%q) error = %v; want InvalidPriorityError", in, err)
+		}
+	}
+}
+
+func TestTimer(t *testing.T) {
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	todo := NewTodo("t1", "timed")
+
+	if _, ok := todo.StopTimer(start); ok {
+		t.Fatal("expected StopTimer without a running timer to report false")
+	}
+	todo.StartTimer(start, "ada-lovelace")
+	todo.StartTimer(start.Add(time.Hour), "alan-turing") // already running: keeps the first start
+	if todo.StartedBy != "ada-lovelace" {
+		t.Fatalf("started by = %q; want ada-lovelace", todo.StartedBy)
+	}
+	if got := todo.TrackedTime(start.Add(30 * time.Minute)); got != 30*time.Minute {
+		t.Fatalf("running tracked time = %v; want 30m", got)
+	}
+	if session, ok := todo.StopTimer(start.Add(90 * time.Minute)); !ok || session != 90*time.Minute {
+		t.Fatalf("session = %v, %v; want 90m, true", session, ok)
+	}
+	todo.StartTimer(start.Add(2*time.Hour), "ada-lovelace")
+	todo.MarkDone()
+	if todo.StartedAt != nil || todo.StartedBy != "" {
+		t.Fatal("expected MarkDone to stop the timer")
+	}
+	if todo.TimeSpent < Duration(90*time.Minute) {
+		t.Fatalf("time spent = %v; want at least 90m", time.Duration(todo.TimeSpent))
+	}
+}
+
+func TestDurationJSONSeconds(t *testing.T) {
+	data, err := json.Marshal(Todo{ID: "d1", TimeSpent: Duration(90*time.Second + 400*time.Millisecond)})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"timeSpent":90`) {
+		t.Fatalf("expected timeSpent in seconds, got %s", data)
+	}
+	if strings.Contains(string(data), "startedAt") {
+		t.Fatalf("expected startedAt to be omitted, got %s", data)
+	}
+
+	var todo Todo
+	if err := json.Unmarshal([]byte(`{"id":"d1","timeSpent":3600}`), &todo); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if time.Duration(todo.TimeSpent) != time.Hour {
+		t.Fatalf("time spent = %v; want 1h", time.Duration(todo.TimeSpent))
+	}
+	if err := json.Unmarshal([]byte(`{"timeSpent":"1h"}`), &todo); err == nil {
+		t.Fatal("expected an error for a non-numeric duration")
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	config := DefaultConfig()
+	if err := ValidateTransition(StatusDone, StatusTechDebt, config); err != nil {
+		t.Fatalf("empty map should allow everything, got %v", err)
+	}
+
+	config.StatusTransitions = map[Status][]Status{
+		StatusDone:    {StatusOpen},
+		StatusBlocked: {},
+	}
+	tests := []struct {
+		from, to Status
+		ok       bool
+	}{
+		{StatusDone, StatusOpen, true},
+		{StatusDone, StatusTechDebt, false},
+		{StatusDone, StatusDone, true},
+		{StatusBlocked, StatusOpen, false},
+		{StatusOpen, StatusWaiting, true},
+	}
+	for _, tt := range tests {
+		err := ValidateTransition(tt.from, tt.to, config)
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidateTransition(%s, %s) = %v, want ok=%v", tt.from, tt.to, err, tt.ok)
+		}
+	}
+	if err := ValidateTransition(StatusDone, StatusTechDebt, nil); err != nil {
+		t.Errorf("nil config should allow everything, got %v", err)
+	}
+}
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2026, 2, 18, 10, 0, 0, 0, time.UTC)
+
+	due, err := ParseDueDate("today", now)
+	if err != nil {
+		t.Fatalf("parse today: %v", err)
+	}
+	if due.Hour() != 23 || due.Minute() != 59 {
+		t.Fatalf("expected end-of-day for today, got %s", due.Format(time.RFC3339))
+	}
+
+	due, err = ParseDueDate("+2d", now)
+	if err != nil {
+		t.Fatalf("parse +2d: %v", err)
+	}
+	if due.Day() != 20 {
+		t.Fatalf("expected day 20 for +2d, got %s", due.Format("2006-01-02"))
+	}
+
+	due, err = ParseDueDate("2026-03-01T14:30", now)
+	if err != nil {
+		t.Fatalf("parse absolute datetime: %v", err)
+	}
+	if due.Year() != 2026 || due.Month() != time.March || due.Day() != 1 || due.Hour() != 14 {
+		t.Fatalf("unexpected due result: %s", due.Format(time.RFC3339))
+	}
+}
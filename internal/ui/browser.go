@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenBrowser opens url in the user's default browser. It returns once the
+// launcher has started, without waiting for the browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch browser: %w", err)
+	}
+	// Reap the launcher in the background; xdg-open and open exit quickly.
+	go cmd.Wait()
+	return nil
+}
@@ -1,24 +1,31 @@
 package ui
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/contributors"
+	"github.com/bagadi-alnour/todo-cli/internal/stats"
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
 )
 
 // Server represents the web UI server
 type Server struct {
-	projectRoot string
-	port        int
+	projectRoot   string
+	port          int
+	token         string
+	allowedOrigin string
+	readOnly      bool
 }
 
 // NewServer creates a new UI server
@@ -29,6 +36,20 @@ func NewServer(projectRoot string, port int) *Server {
 	}
 }
 
+// SetToken requires an "Authorization: Bearer <token>" header on all /api/*
+// requests. When a token is set, CORS responses only allow origin instead of
+// "*". An empty token disables authentication.
+func (s *Server) SetToken(token, origin string) {
+	s.token = token
+	s.allowedOrigin = origin
+}
+
+// SetReadOnly makes the API reject every request that would change todos with
+// 403 Forbidden, and has the page hide its editing controls.
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
 // Handler returns the HTTP handler for the server
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
@@ -37,15 +58,77 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/", s.handleIndex)
 
 	// API endpoints
-	mux.HandleFunc("/api/todos", s.handleTodos)
-	mux.HandleFunc("/api/todos/", s.handleTodoByID)
-	mux.HandleFunc("/api/project", s.handleProject)
-	mux.HandleFunc("/api/files", s.handleFiles)
-	mux.HandleFunc("/api/contributors", s.handleContributors)
+	api := func(next http.HandlerFunc) http.HandlerFunc {
+		return s.requireToken(s.rejectWrites(next))
+	}
+	mux.HandleFunc("/api/todos", api(s.handleTodos))
+	mux.HandleFunc("/api/todos/", api(s.handleTodoByID))
+	mux.HandleFunc("/api/todos/batch", api(s.handleBatch))
+	mux.HandleFunc("/api/todos/order", api(s.handleOrder))
+	mux.HandleFunc("/api/project", api(s.handleProject))
+	mux.HandleFunc("/api/files", api(s.handleFiles))
+	mux.HandleFunc("/api/contributors", api(s.handleContributors))
+	mux.HandleFunc("/api/stats", api(s.handleStats))
+	mux.HandleFunc("/api/statuses", api(s.handleStatuses))
+	mux.HandleFunc("/api/config", api(s.handleConfig))
 
 	return mux
 }
 
+// writeError writes a JSON {"error": msg} body with the given status code
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// corsOrigin returns the Access-Control-Allow-Origin value for API responses.
+func (s *Server) corsOrigin() string {
+	if s.token != "" {
+		return s.allowedOrigin
+	}
+	return "*"
+}
+
+// requireToken rejects API requests without a valid bearer token when one is
+// configured. CORS preflight requests pass through since browsers do not send
+// credentials with them.
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.token == "" || r.Method == http.MethodOptions {
+			next(w, r)
+			return
+		}
+
+		auth := r.Header.Get("Authorization")
+		given, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(given)), []byte(s.token)) != 1 {
+			w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+			w.Header().Set("WWW-Authenticate", `Bearer realm="todo"`)
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// rejectWrites answers requests that would modify todos with 403 Forbidden
+// when the server is read-only. GET, HEAD, and CORS preflight requests pass.
+func (s *Server) rejectWrites(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if s.readOnly {
+				w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+				writeError(w, http.StatusForbidden, "Server is read-only")
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
 // handleIndex serves the main HTML page
 func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
@@ -53,15 +136,20 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(indexHTML))
+	page := indexHTML
+	if s.readOnly {
+		// Flag the page before any script runs so editing controls never show.
+		page = strings.Replace(page, "<body>", `<body class="read-only">`, 1)
+	}
+	w.Write([]byte(page))
 }
 
 // handleTodos handles GET (list) and POST (create) for todos
 func (s *Server) handleTodos(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 	if r.Method == "OPTIONS" {
 		return
@@ -73,16 +161,16 @@ func (s *Server) handleTodos(w http.ResponseWriter, r *http.Request) {
 	case "POST":
 		s.createTodo(w, r)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
 // handleTodoByID handles operations on a single todo
 func (s *Server) handleTodoByID(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
 	w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, DELETE, POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 	if r.Method == "OPTIONS" {
 		return
@@ -92,7 +180,7 @@ func (s *Server) handleTodoByID(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(path, "/")
 
 	if len(parts) == 0 || parts[0] == "" {
-		http.NotFound(w, r)
+		writeError(w, http.StatusNotFound, "Todo not found")
 		return
 	}
 
@@ -105,63 +193,63 @@ func (s *Server) handleTodoByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	switch r.Method {
+	case "GET":
+		s.getTodo(w, r, todoID)
 	case "PUT":
 		s.updateTodo(w, r, todoID)
 	case "DELETE":
 		s.deleteTodo(w, r, todoID)
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
 
 // handleProject returns project information
 func (s *Server) handleProject(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
 
 	projectName := filepath.Base(s.projectRoot)
 	if projectName == "." || projectName == "" {
 		projectName = "Project"
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{
-		"name": projectName,
-		"path": s.projectRoot,
+	json.NewEncoder(w).Encode(map[string]any{
+		"name":     projectName,
+		"path":     s.projectRoot,
+		"readOnly": s.readOnly,
 	})
 }
 
 // handleFiles returns a project-relative directory listing for the path picker.
 func (s *Server) handleFiles(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
 	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
 
 	if r.Method == http.MethodOptions {
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	dir, err := cleanProjectDir(r.URL.Query().Get("dir"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	absDir := filepath.Join(s.projectRoot, dir)
 	if !isInsideProject(s.projectRoot, absDir) {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "path is outside project"})
+		writeError(w, http.StatusBadRequest, "path is outside project")
 		return
 	}
 
 	dirEntries, err := os.ReadDir(absDir)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -211,15 +299,15 @@ func (s *Server) handleFiles(w http.ResponseWriter, r *http.Request) {
 // handleContributors returns cached git contributors for assignee pickers.
 func (s *Server) handleContributors(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
 	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 	if r.Method == http.MethodOptions {
 		return
 	}
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -232,26 +320,189 @@ func (s *Server) handleContributors(w http.ResponseWriter, r *http.Request) {
 		f, err = contributors.EnsureLoaded(s.projectRoot)
 	}
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	json.NewEncoder(w).Encode(f)
 }
 
+// streamTodosNDJSON writes one JSON todo per line, flushing after each so
+// clients can process large lists incrementally.
+func streamTodosNDJSON(w http.ResponseWriter, todos []types.Todo) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for _, t := range todos {
+		if err := enc.Encode(t); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// handleStats returns summary counts computed the same way as `todo stats`
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	todos, err := storage.LoadTodos(s.projectRoot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	json.NewEncoder(w).Encode(stats.Compute(todos, time.Now()))
+}
+
+// statusInfo describes one selectable status for the status dropdown
+type statusInfo struct {
+	Name    string `json:"name"`
+	Icon    string `json:"icon,omitempty"`
+	Color   string `json:"color,omitempty"`
+	Builtin bool   `json:"builtin"`
+}
+
+// handleStatuses returns the built-in statuses followed by the project's
+// custom statuses, re-reading config.json so edits apply without a restart
+func (s *Server) handleStatuses(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if err := storage.RegisterCustomStatuses(s.projectRoot); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	statuses := []statusInfo{}
+	for _, status := range types.BuiltinStatuses() {
+		statuses = append(statuses, statusInfo{Name: string(status), Builtin: true})
+	}
+	for _, cs := range types.CustomStatuses() {
+		statuses = append(statuses, statusInfo{Name: cs.Name, Icon: cs.Icon, Color: cs.Color})
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"statuses": statuses})
+}
+
+// handleConfig returns the config.json settings the page uses. It reads the
+// file on every request so a change made with todo config shows up on reload.
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	config, err := storage.LoadConfig(s.projectRoot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	locale := config.Locale
+	if locale == "" {
+		locale = types.DefaultLocale
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"locale": locale})
+}
+
 // listTodos returns all todos
 func (s *Server) listTodos(w http.ResponseWriter, r *http.Request) {
 	todos, err := storage.LoadTodos(s.projectRoot)
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	offset, err := parseNonNegativeParam(query.Get("offset"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "offset "+err.Error())
+		return
+	}
+	limit, err := parseNonNegativeParam(query.Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "limit "+err.Error())
+		return
+	}
+	paginate := query.Has("offset") || query.Has("limit")
+
+	total := len(todos)
+	if paginate {
+		if offset > total {
+			offset = total
+		}
+		end := total
+		if limit > 0 && offset+limit < total {
+			end = offset + limit
+		}
+		todos = todos[offset:end]
+	}
+
+	if query.Get("format") == "ndjson" {
+		streamTodosNDJSON(w, todos)
+		return
+	}
+
+	if !paginate {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"todos": todos,
+			"count": len(todos),
+		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"todos": todos,
-		"count": len(todos),
+		"todos":  todos,
+		"count":  len(todos),
+		"total":  total,
+		"offset": offset,
+		"limit":  limit,
 	})
 }
 
+// parseNonNegativeParam parses an optional query parameter as a
+// non-negative integer; an empty value is 0.
+func parseNonNegativeParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("must be a non-negative integer")
+	}
+	return n, nil
+}
+
 // createTodo creates a new todo
 func (s *Server) createTodo(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -260,63 +511,67 @@ func (s *Server) createTodo(w http.ResponseWriter, r *http.Request) {
 		Paths    []string `json:"paths"`
 		Priority string   `json:"priority"`
 		Tags     []string `json:"tags"`
+		Notes    string   `json:"notes"`
 		Due      *string  `json:"due"`
 		Assignee string   `json:"assignee"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	if strings.TrimSpace(req.Text) == "" {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Todo text is required"})
+		writeError(w, http.StatusBadRequest, "Todo text is required")
 		return
 	}
 
-	priority := types.Priority(strings.ToLower(req.Priority))
-	if req.Priority != "" && !priority.IsValid() {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid priority"})
-		return
+	priority := types.PriorityMedium
+	if req.Priority != "" {
+		p, err := types.ParsePriority(req.Priority)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid priority")
+			return
+		}
+		priority = p
 	}
 
 	todos, err := storage.LoadTodos(s.projectRoot)
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	id, err := storage.GenerateID()
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to generate ID"})
+		writeError(w, http.StatusInternalServerError, "Failed to generate ID")
 		return
 	}
 
 	todo := types.NewTodo(id, strings.TrimSpace(req.Text))
+	todo.Meta.Source = types.SourceWeb
 	if err := storage.ApplyCreator(todo); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	paths, err := normalizeAPIPaths(s.projectRoot, req.Path, req.Paths)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	if len(paths) > 0 {
 		todo.SetPaths(paths)
 	}
-	if req.Priority != "" && priority.IsValid() {
-		todo.Priority = priority
-	}
-	todo.Tags = normalizeAPITags(req.Tags)
+	todo.Priority = priority
+	todo.Tags = storage.NormalizeTags(req.Tags)
+	todo.Notes = strings.TrimSpace(req.Notes)
 	if req.Due != nil {
 		if strings.TrimSpace(*req.Due) == "" {
 			todo.DueAt = nil
 		} else {
-			dueAt, err := parseAPIDueDate(*req.Due)
+			dueAt, err := types.ParseDueDate(*req.Due, time.Now())
 			if err != nil {
-				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				writeError(w, http.StatusBadRequest, err.Error())
 				return
 			}
 			todo.DueAt = dueAt
@@ -325,7 +580,7 @@ func (s *Server) createTodo(w http.ResponseWriter, r *http.Request) {
 	if req.Assignee != "" {
 		email, _, err := contributors.Resolve(s.projectRoot, req.Assignee)
 		if err != nil {
-			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		todo.Assignee = email
@@ -334,31 +589,56 @@ func (s *Server) createTodo(w http.ResponseWriter, r *http.Request) {
 	todos = append(todos, *todo)
 
 	if err := storage.SaveTodos(s.projectRoot, todos); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "todo": todo})
 }
 
+// getTodo returns a single todo by ID
+func (s *Server) getTodo(w http.ResponseWriter, r *http.Request, todoID string) {
+	todos, err := storage.LoadTodos(s.projectRoot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	todo, _ := storage.FindTodoByID(todos, todoID)
+	if todo == nil {
+		writeError(w, http.StatusNotFound, "Todo not found")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"todo": todo})
+}
+
 // toggleTodo toggles a todo's status
 func (s *Server) toggleTodo(w http.ResponseWriter, r *http.Request, todoID string) {
 	todos, err := storage.LoadTodos(s.projectRoot)
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	todo, idx := storage.FindTodoByID(todos, todoID)
 	if todo == nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Todo not found"})
+		writeError(w, http.StatusNotFound, "Todo not found")
 		return
 	}
 
+	to := types.StatusDone
+	if todo.Status == types.StatusDone {
+		to = types.StatusOpen
+	}
+	if err := s.checkTransition(*todo, to); err != nil {
+		writeTransitionError(w, err)
+		return
+	}
 	todos[idx].Toggle()
 
 	if err := storage.SaveTodos(s.projectRoot, todos); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -374,24 +654,25 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 		Paths    *[]string `json:"paths"`
 		Priority string    `json:"priority"`
 		Tags     *[]string `json:"tags"`
+		Notes    *string   `json:"notes"`
 		Due      *string   `json:"due"`
 		Assignee *string   `json:"assignee"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid request body"})
+		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	todos, err := storage.LoadTodos(s.projectRoot)
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	todo, idx := storage.FindTodoByID(todos, todoID)
 	if todo == nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Todo not found"})
+		writeError(w, http.StatusNotFound, "Todo not found")
 		return
 	}
 
@@ -401,23 +682,19 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 	if req.Status != "" {
 		status := types.Status(strings.ToLower(req.Status))
 		if !status.IsValid() {
-			json.NewEncoder(w).Encode(map[string]string{"error": "Invalid status"})
+			writeError(w, http.StatusBadRequest, "Invalid status")
 			return
 		}
-		switch status {
-		case types.StatusDone:
-			todos[idx].MarkDone()
-		case types.StatusOpen:
-			todos[idx].MarkOpen()
-		default:
-			todos[idx].Status = status
-			todos[idx].CompletedAt = nil
+		if err := s.checkTransition(todos[idx], status); err != nil {
+			writeTransitionError(w, err)
+			return
 		}
+		applyAPIStatus(&todos[idx], status)
 	}
 	if req.Priority != "" {
-		p := types.Priority(strings.ToLower(req.Priority))
-		if !p.IsValid() {
-			json.NewEncoder(w).Encode(map[string]string{"error": "Invalid priority"})
+		p, err := types.ParsePriority(req.Priority)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid priority")
 			return
 		}
 		todos[idx].Priority = p
@@ -425,8 +702,7 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 	if req.Path != nil {
 		paths, err := normalizeAPIPaths(s.projectRoot, req.Path, nil)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		todos[idx].Context.Paths = paths
@@ -434,22 +710,24 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 	if req.Paths != nil {
 		paths, err := normalizeAPIPaths(s.projectRoot, nil, *req.Paths)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		todos[idx].Context.Paths = paths
 	}
 	if req.Tags != nil {
-		todos[idx].Tags = normalizeAPITags(*req.Tags)
+		todos[idx].Tags = storage.NormalizeTags(*req.Tags)
+	}
+	if req.Notes != nil {
+		todos[idx].Notes = strings.TrimSpace(*req.Notes)
 	}
 	if req.Due != nil {
 		if strings.TrimSpace(*req.Due) == "" {
 			todos[idx].DueAt = nil
 		} else {
-			dueAt, err := parseAPIDueDate(*req.Due)
+			dueAt, err := types.ParseDueDate(*req.Due, time.Now())
 			if err != nil {
-				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				writeError(w, http.StatusBadRequest, err.Error())
 				return
 			}
 			todos[idx].DueAt = dueAt
@@ -461,7 +739,7 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 		} else {
 			email, _, err := contributors.Resolve(s.projectRoot, *req.Assignee)
 			if err != nil {
-				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				writeError(w, http.StatusBadRequest, err.Error())
 				return
 			}
 			todos[idx].Assignee = email
@@ -470,7 +748,7 @@ func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request, todoID strin
 	todos[idx].UpdatedAt = time.Now()
 
 	if err := storage.SaveTodos(s.projectRoot, todos); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -571,65 +849,266 @@ func cleanAPIPath(projectRoot, raw string) (string, error) {
 	return filepath.ToSlash(path), nil
 }
 
-func normalizeAPITags(tags []string) []string {
-	if len(tags) == 0 {
-		return nil
+// checkTransition applies the project's statusTransitions to a status change
+// made through the API. The config is read per request so edits apply
+// without a restart.
+func (s *Server) checkTransition(todo types.Todo, to types.Status) error {
+	config, err := storage.LoadConfig(s.projectRoot)
+	if err != nil {
+		return err
 	}
-	seen := make(map[string]struct{}, len(tags))
-	out := make([]string, 0, len(tags))
-	for _, tag := range tags {
-		tag = strings.ToLower(strings.TrimSpace(tag))
-		if tag == "" {
-			continue
+	return types.ValidateTransition(todo.Status, to, config)
+}
+
+// writeTransitionError answers a refused status change with 409 Conflict and
+// any other failure with 500.
+func writeTransitionError(w http.ResponseWriter, err error) {
+	var transitionErr *types.TransitionError
+	if errors.As(err, &transitionErr) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+// applyAPIStatus sets a todo's status, keeping CompletedAt in sync
+func applyAPIStatus(todo *types.Todo, status types.Status) {
+	todo.SetStatus(status)
+}
+
+// batchResult reports the outcome of a batch action for a single todo
+type batchResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// checkBatchTransition applies statusTransitions to each status change a
+// batch action makes to todo: done or reopen first, then status.
+func checkBatchTransition(todo types.Todo, action string, status types.Status, config *types.Config) error {
+	from := todo.Status
+	switch action {
+	case "done", "reopen":
+		to := types.StatusDone
+		if action == "reopen" {
+			to = types.StatusOpen
 		}
-		if _, ok := seen[tag]; ok {
-			continue
+		if err := types.ValidateTransition(from, to, config); err != nil {
+			return err
 		}
-		seen[tag] = struct{}{}
-		out = append(out, tag)
+		from = to
 	}
-	if len(out) == 0 {
-		return nil
+	if action != "delete" && status != "" {
+		return types.ValidateTransition(from, status, config)
 	}
-	return out
+	return nil
 }
 
-func parseAPIDueDate(input string) (*time.Time, error) {
-	raw := strings.TrimSpace(input)
-	if raw == "" {
-		return nil, nil
+// handleBatch applies one action to several todos with a single load and save
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req struct {
+		IDs      []string `json:"ids"`
+		Action   string   `json:"action"`
+		Status   string   `json:"status"`
+		Priority string   `json:"priority"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, "No ids given")
+		return
+	}
+
+	action := strings.ToLower(strings.TrimSpace(req.Action))
+	switch action {
+	case "", "done", "delete", "reopen":
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid action. Use: done, delete, reopen")
+		return
+	}
+
+	var status types.Status
+	if req.Status != "" {
+		status = types.Status(strings.ToLower(req.Status))
+		if !status.IsValid() {
+			writeError(w, http.StatusBadRequest, "Invalid status")
+			return
+		}
+	}
+	var priority types.Priority
+	if req.Priority != "" {
+		p, err := types.ParsePriority(req.Priority)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid priority")
+			return
+		}
+		priority = p
+	}
+	if action == "" && status == "" && priority == "" {
+		writeError(w, http.StatusBadRequest, "Nothing to do: set action, status, or priority")
+		return
+	}
+
+	results := make([]batchResult, 0, len(req.IDs))
+	err := storage.WithLock(s.projectRoot, func() error {
+		todos, err := storage.LoadTodos(s.projectRoot)
+		if err != nil {
+			return err
+		}
+
+		config, err := storage.LoadConfig(s.projectRoot)
+		if err != nil {
+			return err
+		}
+
+		toDelete := make(map[string]bool)
+		changed := 0
+		for _, id := range req.IDs {
+			todo, _ := storage.FindTodoByID(todos, id)
+			if todo == nil || toDelete[id] {
+				results = append(results, batchResult{ID: id, Error: "Todo not found"})
+				continue
+			}
+
+			if err := checkBatchTransition(*todo, action, status, config); err != nil {
+				results = append(results, batchResult{ID: id, Error: err.Error()})
+				continue
+			}
+
+			switch action {
+			case "delete":
+				toDelete[id] = true
+			case "done":
+				todo.MarkDone()
+			case "reopen":
+				todo.MarkOpen()
+			}
+			if action != "delete" {
+				if status != "" {
+					applyAPIStatus(todo, status)
+				}
+				if priority != "" {
+					todo.Priority = priority
+				}
+				todo.UpdatedAt = time.Now()
+			}
+			changed++
+			results = append(results, batchResult{ID: id, Success: true})
+		}
+
+		if changed == 0 {
+			return nil
+		}
+		if len(toDelete) > 0 {
+			kept := todos[:0]
+			for _, t := range todos {
+				if !toDelete[t.ID] {
+					kept = append(kept, t)
+				}
+			}
+			todos = kept
+		}
+		return storage.SaveTodos(s.projectRoot, todos)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
 	}
-	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
-		return &parsed, nil
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "results": results})
+}
+
+// handleOrder reorders todos to match a JSON array of IDs. Unknown IDs are
+// ignored and omitted todos keep their relative order at the end.
+func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", s.corsOrigin())
+	w.Header().Set("Access-Control-Allow-Methods", "PUT, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
 	}
-	if parsed, err := time.ParseInLocation("2006-01-02T15:04", raw, time.Local); err == nil {
-		return &parsed, nil
+
+	var ids []string
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: expected a JSON array of todo IDs")
+		return
 	}
-	if parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local); err == nil {
-		eod := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 23, 59, 59, 0, time.Local)
-		return &eod, nil
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "No ids given")
+		return
 	}
-	return nil, fmt.Errorf("invalid due date (use RFC3339, YYYY-MM-DDTHH:MM, or YYYY-MM-DD)")
+
+	var order []string
+	err := storage.WithLock(s.projectRoot, func() error {
+		todos, err := storage.LoadTodos(s.projectRoot)
+		if err != nil {
+			return err
+		}
+		reordered := storage.ReorderTodos(todos, ids)
+		if len(reordered) != len(todos) {
+			return fmt.Errorf("reordering changed the number of todos")
+		}
+		if err := storage.SaveTodos(s.projectRoot, reordered); err != nil {
+			return err
+		}
+		// Order is stored per owner file, so report what a reload yields.
+		saved, err := storage.LoadTodos(s.projectRoot)
+		if err != nil {
+			return err
+		}
+		order = make([]string, len(saved))
+		for i, t := range saved {
+			order[i] = t.ID
+		}
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "order": order})
 }
 
 // deleteTodo deletes a todo
 func (s *Server) deleteTodo(w http.ResponseWriter, r *http.Request, todoID string) {
 	todos, err := storage.LoadTodos(s.projectRoot)
 	if err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	_, idx := storage.FindTodoByID(todos, todoID)
 	if idx == -1 {
-		json.NewEncoder(w).Encode(map[string]string{"error": "Todo not found"})
+		writeError(w, http.StatusNotFound, "Todo not found")
 		return
 	}
 
 	todos = storage.DeleteTodo(todos, idx)
 
 	if err := storage.SaveTodos(s.projectRoot, todos); err != nil {
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -780,6 +1259,10 @@ var indexHTML = `<!DOCTYPE html>
         .stat.tech-debt .stat-value { color: var(--accent-orange); }
 
         /* Add Form */
+        .read-only .add-form, .read-only .write-only, .read-only .action-btn.edit, .read-only .action-btn.delete { display: none; }
+        .read-only .todo-checkbox { visibility: hidden; pointer-events: none; }
+        .read-only-badge { display: none; }
+        .read-only .read-only-badge { display: inline-block; }
         .add-form {
             margin-bottom: 20px;
             padding: 16px;
@@ -1316,6 +1799,7 @@ var indexHTML = `<!DOCTYPE html>
                     <span class="terminal-icon">▶</span>
                     <h1>todo<span>::cli</span></h1>
                 </div>
+                <div class="project-badge read-only-badge" title="Editing is disabled on this server">read-only</div>
                 <div class="project-badge" id="project-name">loading...</div>
             </div>
         </header>
@@ -1378,11 +1862,11 @@ var indexHTML = `<!DOCTYPE html>
             <div class="shortcuts-title">keybindings</div>
             <div class="shortcuts-grid">
                 <div class="shortcut"><kbd>↑</kbd><kbd>↓</kbd> navigate</div>
-                <div class="shortcut"><kbd>space</kbd> toggle</div>
+                <div class="shortcut write-only"><kbd>space</kbd> toggle</div>
                 <div class="shortcut"><kbd>i</kbd> details</div>
-                <div class="shortcut"><kbd>e</kbd> edit</div>
-                <div class="shortcut"><kbd>d</kbd> delete</div>
-                <div class="shortcut"><kbd>n</kbd> new</div>
+                <div class="shortcut write-only"><kbd>e</kbd> edit</div>
+                <div class="shortcut write-only"><kbd>d</kbd> delete</div>
+                <div class="shortcut write-only"><kbd>n</kbd> new</div>
                 <div class="shortcut"><kbd>t</kbd> theme</div>
             </div>
         </div>
@@ -1438,6 +1922,20 @@ var indexHTML = `<!DOCTYPE html>
     <div class="toast" id="toast"><span id="toast-message"></span></div>
 
     <script>
+        const apiToken = (() => {
+            const params = new URLSearchParams(window.location.search);
+            const fromURL = params.get('token');
+            if (fromURL) {
+                sessionStorage.setItem('todo-api-token', fromURL);
+                history.replaceState(null, '', window.location.pathname);
+            }
+            return sessionStorage.getItem('todo-api-token') || '';
+        })();
+        function apiFetch(url, options = {}) {
+            const headers = Object.assign({}, options.headers || {});
+            if (apiToken) headers['Authorization'] = 'Bearer ' + apiToken;
+            return fetch(url, Object.assign({}, options, { headers }));
+        }
         let currentFilter = 'all';
         let currentPriorityFilter = 'all';
         let currentAssigneeFilter = 'all';
@@ -1454,12 +1952,18 @@ var indexHTML = `<!DOCTYPE html>
         let pathPickerSelected = new Set();
         let projectRootPath = '';
         let expandedTodoIDs = new Set();
+        let customStatusColors = {};
+        let uiLocale = 'en-US';
+        let readOnly = document.body.classList.contains('read-only');
+        const statusColorVars = { red: '--accent-red', green: '--accent-green', yellow: '--accent-yellow', blue: '--accent-blue', magenta: '--accent-purple', cyan: '--accent-cyan', white: '--text-primary', gray: '--text-secondary' };
 
         document.addEventListener('DOMContentLoaded', () => {
             applyTheme(currentTheme);
             loadTodos();
             loadProjectInfo();
             loadContributors();
+            loadStatuses();
+            loadConfig();
             setupEventListeners();
         });
 
@@ -1622,8 +2126,8 @@ var indexHTML = `<!DOCTYPE html>
 
         async function loadPathEntries(dir) {
             try {
-                const res = await fetch('/api/files?dir=' + encodeURIComponent(dir || ''));
-                const data = await res.json();
+                const res = await apiFetch('/api/files?dir=' + encodeURIComponent(dir || ''));
+                const data = await readAPIResponse(res);
                 if (!res.ok || data.error) throw new Error(data.error || 'Failed');
                 pathPickerDir = data.dir || '';
                 pathPickerParent = data.parent || '';
@@ -1681,7 +2185,7 @@ var indexHTML = `<!DOCTYPE html>
 
         async function loadContributors() {
             try {
-                const res = await fetch('/api/contributors');
+                const res = await apiFetch('/api/contributors');
                 const data = await res.json();
                 if (!res.ok || data.error) throw new Error(data.error || 'Failed');
                 contributorList = data.contributors || [];
@@ -1694,6 +2198,40 @@ var indexHTML = `<!DOCTYPE html>
             }
         }
 
+        async function loadStatuses() {
+            try {
+                const res = await apiFetch('/api/statuses');
+                const data = await res.json();
+                if (!res.ok || data.error) throw new Error(data.error || 'Failed');
+                const statuses = data.statuses || [];
+                const select = document.getElementById('edit-todo-status');
+                const prev = select.value;
+                select.innerHTML = statuses.map(s => '<option value="' + escapeAttr(s.name) + '">' + escapeHtml(s.name) + '</option>').join('');
+                if (prev) select.value = prev;
+                customStatusColors = {};
+                statuses.forEach(s => { if (!s.builtin && statusColorVars[s.color]) customStatusColors[s.name] = statusColorVars[s.color]; });
+                renderTodos();
+            } catch (err) {
+                console.warn('statuses', err);
+            }
+        }
+
+        async function loadConfig() {
+            try {
+                const res = await apiFetch('/api/config');
+                const data = await res.json();
+                if (!res.ok || data.error) throw new Error(data.error || 'Failed');
+                if (data.locale && data.locale !== uiLocale) { uiLocale = data.locale; renderTodos(); }
+            } catch (err) {
+                console.warn('config', err);
+            }
+        }
+
+        function statusStyle(status) {
+            const v = customStatusColors[status];
+            return v ? ' style="color: var(' + v + '); border-color: var(' + v + ')"' : '';
+        }
+
         function contributorLabel(email) {
             if (!email) return '';
             const c = contributorByEmail[(email || '').toLowerCase()];
@@ -1736,24 +2274,26 @@ var indexHTML = `<!DOCTYPE html>
 
         async function loadProjectInfo() {
             try {
-                const res = await fetch('/api/project');
+                const res = await apiFetch('/api/project');
                 const data = await res.json();
                 projectRootPath = normalizeRootPath(data.path || '');
+                if (data.readOnly) { readOnly = true; document.body.classList.add('read-only'); }
                 document.getElementById('project-name').textContent = data.name || 'project';
             } catch (err) { document.getElementById('project-name').textContent = 'project'; }
         }
 
         async function loadTodos() {
             try {
-                const res = await fetch('/api/todos');
-                const data = await res.json();
+                const res = await apiFetch('/api/todos');
+                const data = await readAPIResponse(res);
+                if (!res.ok || data.error) throw new Error(data.error || 'Failed to load todos');
                 allTodos = data.todos || [];
                 const activeIDs = new Set(allTodos.map(t => t.id));
                 expandedTodoIDs = new Set(Array.from(expandedTodoIDs).filter(id => activeIDs.has(id)));
                 renderStats();
                 populateAssigneeFilter();
                 renderTodos();
-            } catch (err) { showToast('Failed to load todos', 'error'); }
+            } catch (err) { showToast(err.message || 'Failed to load todos', 'error'); }
         }
 
         function renderStats() {
@@ -1810,7 +2350,7 @@ var indexHTML = `<!DOCTYPE html>
                     '<span class="todo-index">' + String(i + 1).padStart(2, '0') + '</span>' +
                     '<div class="todo-checkbox" onclick="toggleTodo(\'' + idArg + '\')"><svg viewBox="0 0 24 24" fill="none" stroke="currentColor"><polyline points="20 6 9 17 4 12"/></svg></div>' +
                     '<div class="todo-content" onclick="toggleTodoDetails(\'' + idArg + '\')" title="' + (isExpanded ? 'Hide details' : 'Show details') + '"><div class="todo-text">' + escapeHtml(todo.text) + '</div><div class="todo-meta">' +
-                    '<span class="todo-status status-' + todo.status + '">' + todo.status + '</span>' +
+                    '<span class="todo-status status-' + escapeAttr(todo.status) + '"' + statusStyle(todo.status) + '>' + escapeHtml(todo.status) + '</span>' +
                     '<span class="todo-priority priority-' + priority.key + '">' + priority.label + '</span>' +
                     '<span class="todo-date">' + formatDate(todo.createdAt) + '</span>' +
                     (paths.length > 0 ? '<span class="todo-path" title="' + escapeAttr(paths.join(', ')) + '">' + escapeHtml(formatPathSummary(paths)) + '</span>' : '') +
@@ -1819,7 +2359,7 @@ var indexHTML = `<!DOCTYPE html>
                     '</div></div>' +
                     '<div class="todo-actions">' +
                     '<button class="action-btn details' + (isExpanded ? ' expanded' : '') + '" onclick="toggleTodoDetails(\'' + idArg + '\')" title="' + (isExpanded ? 'Hide details' : 'Show details') + '"><svg class="details-chevron' + (isExpanded ? ' expanded' : '') + '" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2"><polyline points="9 18 15 12 9 6"/></svg></button>' +
-                    '<button class="action-btn" onclick="openEditModal(\'' + idArg + '\')" title="Edit"><svg viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2"><path d="M11 4H4a2 2 0 0 0-2 2v14a2 2 0 0 0 2 2h14a2 2 0 0 0 2-2v-7"/><path d="M18.5 2.5a2.121 2.121 0 0 1 3 3L12 15l-4 1 1-4 9.5-9.5z"/></svg></button>' +
+                    '<button class="action-btn edit" onclick="openEditModal(\'' + idArg + '\')" title="Edit"><svg viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2"><path d="M11 4H4a2 2 0 0 0-2 2v14a2 2 0 0 0 2 2h14a2 2 0 0 0 2-2v-7"/><path d="M18.5 2.5a2.121 2.121 0 0 1 3 3L12 15l-4 1 1-4 9.5-9.5z"/></svg></button>' +
                     '<button class="action-btn delete" onclick="openDeleteModal(\'' + idArg + '\')" title="Delete"><svg viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2"><polyline points="3 6 5 6 21 6"/><path d="M19 6v14a2 2 0 0 1-2 2H7a2 2 0 0 1-2-2V6m3 0V4a2 2 0 0 1 2-2h4a2 2 0 0 1 2 2v2"/></svg></button>' +
                     '</div></div>' +
                     (isExpanded ? renderTodoDetails(todo) : '') +
@@ -1874,7 +2414,7 @@ var indexHTML = `<!DOCTYPE html>
             try {
                 const payload = { text, paths, priority };
                 if (assignee) payload.assignee = assignee;
-                const res = await fetch('/api/todos', { method: 'POST', headers: { 'Content-Type': 'application/json' }, body: JSON.stringify(payload) });
+                const res = await apiFetch('/api/todos', { method: 'POST', headers: { 'Content-Type': 'application/json' }, body: JSON.stringify(payload) });
                 const data = await readAPIResponse(res);
                 if (!res.ok || data.error) throw new Error(data.error || 'Failed to add');
                 document.getElementById('new-todo-text').value = '';
@@ -1886,7 +2426,14 @@ var indexHTML = `<!DOCTYPE html>
             } catch (err) { showToast(err.message || 'Failed to add', 'error'); }
         }
 
-        async function toggleTodo(id) { try { await fetch('/api/todos/' + id + '/toggle', { method: 'POST' }); await loadTodos(); } catch (err) { showToast('Toggle failed', 'error'); } }
+        async function toggleTodo(id) {
+            try {
+                const res = await apiFetch('/api/todos/' + id + '/toggle', { method: 'POST' });
+                const data = await readAPIResponse(res);
+                if (!res.ok || data.error) throw new Error(data.error || 'Toggle failed');
+                await loadTodos();
+            } catch (err) { showToast(err.message || 'Toggle failed', 'error'); }
+        }
 
         function openEditModal(id) {
             const todo = allTodos.find(t => t.id === id);
@@ -1913,7 +2460,7 @@ var indexHTML = `<!DOCTYPE html>
             if (!text) { showToast('Text required', 'error'); return; }
             try {
                 const assignee = document.getElementById('edit-todo-assignee').value;
-                const res = await fetch('/api/todos/' + id, { method: 'PUT', headers: { 'Content-Type': 'application/json' }, body: JSON.stringify({ text, status, priority, paths, assignee }) });
+                const res = await apiFetch('/api/todos/' + id, { method: 'PUT', headers: { 'Content-Type': 'application/json' }, body: JSON.stringify({ text, status, priority, paths, assignee }) });
                 const data = await readAPIResponse(res);
                 if (!res.ok || data.error) throw new Error(data.error || 'Update failed');
                 closeEditModal();
@@ -1928,9 +2475,11 @@ var indexHTML = `<!DOCTYPE html>
         async function confirmDelete() {
             const id = document.getElementById('delete-todo-id').value;
             try {
-                const res = await fetch('/api/todos/' + id, { method: 'DELETE' });
-                if (res.ok) { closeDeleteModal(); await loadTodos(); showToast('Deleted', 'success'); } else throw new Error('Failed');
-            } catch (err) { showToast('Delete failed', 'error'); }
+                const res = await apiFetch('/api/todos/' + id, { method: 'DELETE' });
+                const data = await readAPIResponse(res);
+                if (!res.ok || data.error) throw new Error(data.error || 'Delete failed');
+                closeDeleteModal(); await loadTodos(); showToast('Deleted', 'success');
+            } catch (err) { showToast(err.message || 'Delete failed', 'error'); }
         }
 
         function handleKeyboard(e) {
@@ -1938,6 +2487,7 @@ var indexHTML = `<!DOCTYPE html>
             const isModalOpen = document.querySelector('.modal-overlay.active');
             const isInputFocused = ['INPUT', 'TEXTAREA', 'SELECT'].includes(document.activeElement.tagName);
             if (isModalOpen || isInputFocused) return;
+            if (readOnly && [' ', 'Enter', 'e', 'E', 'd', 'D', 'n', 'N'].includes(e.key)) return;
             switch (e.key) {
                 case 'ArrowDown': case 'j': e.preventDefault(); selectedIndex = Math.min(selectedIndex + 1, filtered.length - 1); renderTodos(); scrollToSelected(); break;
                 case 'ArrowUp': case 'k': e.preventDefault(); selectedIndex = Math.max(selectedIndex - 1, 0); renderTodos(); scrollToSelected(); break;
@@ -1951,8 +2501,10 @@ var indexHTML = `<!DOCTYPE html>
         }
 
         function scrollToSelected() { const selected = document.querySelector('.todo-item.selected'); if (selected) selected.scrollIntoView({ behavior: 'smooth', block: 'nearest' }); }
-        function formatDate(dateStr) { const d = new Date(dateStr); return d.toLocaleDateString('en-US', { month: 'short', day: 'numeric' }); }
-        function formatDateTime(dateStr) { const d = new Date(dateStr); return d.toLocaleString('en-US', { dateStyle: 'medium', timeStyle: 'short' }); }
+        // A locale the browser rejects falls back to en-US rather than breaking every row.
+        function localeFormat(fn) { try { return fn(uiLocale); } catch (err) { return fn('en-US'); } }
+        function formatDate(dateStr) { const d = new Date(dateStr); return localeFormat(l => d.toLocaleDateString(l, { month: 'short', day: 'numeric' })); }
+        function formatDateTime(dateStr) { const d = new Date(dateStr); return localeFormat(l => d.toLocaleString(l, { dateStyle: 'medium', timeStyle: 'short' })); }
         function formatPathSummary(paths) { if (paths.length <= 2) return paths.join(', '); return paths[0] + ' +' + (paths.length - 1); }
         function escapeHtml(text) { const div = document.createElement('div'); div.textContent = text; return div.innerHTML; }
         function escapeAttr(text) { return escapeHtml(text).replace(/"/g, '&quot;'); }
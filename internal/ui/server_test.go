@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bagadi-alnour/todo-cli/internal/storage"
 	"github.com/bagadi-alnour/todo-cli/internal/types"
@@ -67,6 +68,9 @@ func TestServerCRUD(t *testing.T) {
 	if got := createResp.Todo.Context.Paths; len(got) != 2 || got[0] != "src" || got[1] != "README.md" {
 		t.Fatalf("expected paths [src README.md], got %+v", got)
 	}
+	if createResp.Todo.Meta.Source != types.SourceWeb {
+		t.Fatalf("expected source %q, got %q", types.SourceWeb, createResp.Todo.Meta.Source)
+	}
 
 	// List
 	resp, err = http.Get(ts.URL + "/api/todos")
@@ -86,6 +90,38 @@ func TestServerCRUD(t *testing.T) {
 		t.Fatalf("expected 1 todo, got %+v", listResp)
 	}
 
+	// Get
+	resp, err = http.Get(ts.URL + "/api/todos/" + todoID)
+	if err != nil {
+		t.Fatalf("get todo request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected get status 200, got %d", resp.StatusCode)
+	}
+
+	var getResp struct {
+		Todo types.Todo `json:"todo"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&getResp); err != nil {
+		t.Fatalf("decode get response: %v", err)
+	}
+	if getResp.Todo.ID != todoID || getResp.Todo.Text != createResp.Todo.Text || getResp.Todo.Priority != createResp.Todo.Priority {
+		t.Fatalf("get returned %+v, want created todo %+v", getResp.Todo, createResp.Todo)
+	}
+	if len(getResp.Todo.Tags) != len(createResp.Todo.Tags) || len(getResp.Todo.Context.Paths) != len(createResp.Todo.Context.Paths) {
+		t.Fatalf("get returned different tags/paths: %+v", getResp.Todo)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/todos/does-not-exist")
+	if err != nil {
+		t.Fatalf("get missing todo request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing todo, got %d", resp.StatusCode)
+	}
+
 	// Update
 	updatePayload := map[string]any{
 		"status":   "blocked",
@@ -294,3 +330,569 @@ func TestServerPathsStayProjectRelative(t *testing.T) {
 		t.Fatalf("expected bad request for path traversal, got %d", rec.Code)
 	}
 }
+
+func TestServerTokenAuth(t *testing.T) {
+	projectRoot := t.TempDir()
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	server.SetToken("s3cret", "http://localhost:17887")
+	handler := server.Handler()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		want   int
+	}{
+		{"missing token", http.MethodGet, "/api/todos", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodGet, "/api/todos", "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", http.MethodGet, "/api/todos", "Bearer s3cret", http.StatusOK},
+		{"preflight", http.MethodOptions, "/api/todos", "", http.StatusOK},
+		{"index page", http.MethodGet, "/", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if strings.HasPrefix(tt.path, "/api/") {
+				if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:17887" {
+					t.Fatalf("expected CORS origin to be restricted, got %q", got)
+				}
+			}
+		})
+	}
+}
+
+func TestServerReadOnly(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	now := time.Now()
+	if err := storage.SaveTodos(projectRoot, []types.Todo{{ID: "a1", Text: "one", Status: types.StatusOpen, CreatedAt: now, UpdatedAt: now}}); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	server.SetReadOnly(true)
+	handler := server.Handler()
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "/api/todos", "", http.StatusOK},
+		{http.MethodGet, "/api/todos/a1", "", http.StatusOK},
+		{http.MethodOptions, "/api/todos", "", http.StatusOK},
+		{http.MethodPost, "/api/todos", `{"text":"new"}`, http.StatusForbidden},
+		{http.MethodPut, "/api/todos/a1", `{"text":"changed"}`, http.StatusForbidden},
+		{http.MethodDelete, "/api/todos/a1", "", http.StatusForbidden},
+		{http.MethodPost, "/api/todos/a1/toggle", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Fatalf("%s %s: expected status %d, got %d: %s", tt.method, tt.path, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Text != "one" || todos[0].Status != types.StatusOpen {
+		t.Fatalf("read-only server changed todos: %+v", todos)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/project", nil))
+	var project map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &project); err != nil || project["readOnly"] != true {
+		t.Fatalf("expected project to report readOnly, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), `<body class="read-only">`) {
+		t.Fatal("expected the page to be flagged read-only")
+	}
+}
+
+func TestServerStats(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "a1", Text: "one", Status: types.StatusOpen, Priority: types.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "b2", Text: "two", Status: types.StatusDone, Priority: types.PriorityLow, CreatedAt: now, UpdatedAt: now, CompletedAt: &now},
+		{ID: "c3", Text: "three", Status: types.StatusBlocked, Priority: types.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "d4", Text: "four", Status: types.StatusDone, Priority: types.PriorityMedium, CreatedAt: now, UpdatedAt: now, CompletedAt: &now},
+	}
+	if err := storage.SaveTodos(projectRoot, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status OK, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Fatalf("expected CORS header, got %q", got)
+	}
+
+	var report struct {
+		Total          int            `json:"total"`
+		ByStatus       map[string]int `json:"byStatus"`
+		ByPriority     map[string]int `json:"byPriority"`
+		CompletionRate float64        `json:"completionRate"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("decode stats: %v", err)
+	}
+	if report.Total != 4 {
+		t.Fatalf("expected total 4, got %d", report.Total)
+	}
+	if report.ByStatus["open"] != 1 || report.ByStatus["done"] != 2 || report.ByStatus["blocked"] != 1 {
+		t.Fatalf("unexpected status counts: %v", report.ByStatus)
+	}
+	if report.ByPriority["high"] != 2 || report.ByPriority["medium"] != 1 || report.ByPriority["low"] != 1 {
+		t.Fatalf("unexpected priority counts: %v", report.ByPriority)
+	}
+	if report.CompletionRate != 50 {
+		t.Fatalf("expected completion rate 50, got %v", report.CompletionRate)
+	}
+}
+
+func TestServerListNDJSON(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "a1", Text: "one", Status: types.StatusOpen, Priority: types.PriorityHigh, CreatedAt: now, UpdatedAt: now},
+		{ID: "b2", Text: "two", Status: types.StatusOpen, Priority: types.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	}
+	if err := storage.SaveTodos(projectRoot, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	req := httptest.NewRequest(http.MethodGet, "/api/todos?format=ndjson", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("expected ndjson content type, got %q", ct)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), rec.Body.String())
+	}
+	for i, line := range lines {
+		var got types.Todo
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not a todo: %v", i, err)
+		}
+		if got.ID != todos[i].ID {
+			t.Fatalf("line %d: expected id %s, got %s", i, todos[i].ID, got.ID)
+		}
+	}
+}
+
+func TestServerBatch(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "a1", Text: "one", Status: types.StatusOpen, Priority: types.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "b2", Text: "two", Status: types.StatusOpen, Priority: types.PriorityLow, CreatedAt: now, UpdatedAt: now},
+		{ID: "c3", Text: "three", Status: types.StatusOpen, Priority: types.PriorityLow, CreatedAt: now, UpdatedAt: now},
+	}
+	if err := storage.SaveTodos(projectRoot, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	handler := server.Handler()
+
+	post := func(body string) (results []batchResult, errMsg string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/todos/batch", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		var resp struct {
+			Results []batchResult `json:"results"`
+			Error   string        `json:"error"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode batch response: %v", err)
+		}
+		return resp.Results, resp.Error
+	}
+
+	results, errMsg := post(`{"ids":["a1","b2","zz"],"action":"done","priority":"high"}`)
+	if errMsg != "" {
+		t.Fatalf("batch done failed: %s", errMsg)
+	}
+	if len(results) != 3 || !results[0].Success || !results[1].Success || results[2].Success {
+		t.Fatalf("unexpected per-id results: %+v", results)
+	}
+
+	loaded, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	for _, id := range []string{"a1", "b2"} {
+		todo, _ := storage.FindTodoByID(loaded, id)
+		if todo.Status != types.StatusDone || todo.Priority != types.PriorityHigh {
+			t.Fatalf("todo %s not updated: %+v", id, todo)
+		}
+	}
+
+	if _, errMsg := post(`{"ids":["c3"],"action":"explode"}`); errMsg == "" {
+		t.Fatal("expected invalid action error")
+	}
+
+	results, errMsg = post(`{"ids":["a1","c3"],"action":"delete"}`)
+	if errMsg != "" || len(results) != 2 {
+		t.Fatalf("batch delete failed: %s %+v", errMsg, results)
+	}
+	loaded, err = storage.LoadTodos(projectRoot)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].ID != "b2" {
+		t.Fatalf("expected only b2 to remain, got %+v", loaded)
+	}
+}
+
+func TestServerErrorStatusCodes(t *testing.T) {
+	projectRoot := t.TempDir()
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	handler := NewServer(projectRoot, 0).Handler()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"invalid body", http.MethodPost, "/api/todos", "{", http.StatusBadRequest},
+		{"missing text", http.MethodPost, "/api/todos", `{"text":"  "}`, http.StatusBadRequest},
+		{"invalid priority", http.MethodPost, "/api/todos", `{"text":"x","priority":"urgent"}`, http.StatusBadRequest},
+		{"invalid due", http.MethodPost, "/api/todos", `{"text":"x","due":"someday"}`, http.StatusBadRequest},
+		{"update missing todo", http.MethodPut, "/api/todos/nope", `{"text":"x"}`, http.StatusNotFound},
+		{"delete missing todo", http.MethodDelete, "/api/todos/nope", "", http.StatusNotFound},
+		{"toggle missing todo", http.MethodPost, "/api/todos/nope/toggle", "", http.StatusNotFound},
+		{"bad method", http.MethodPatch, "/api/todos", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			var body map[string]string
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body["error"] == "" {
+				t.Fatalf("expected JSON error body, got %v (%v)", body, err)
+			}
+		})
+	}
+}
+
+func TestServerTagsNotesDue(t *testing.T) {
+	projectRoot := t.TempDir()
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	handler := NewServer(projectRoot, 0).Handler()
+	do := func(method, path, body string) types.Todo {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: status %d: %s", method, path, rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Todo types.Todo `json:"todo"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode %s %s: %v", method, path, err)
+		}
+		return resp.Todo
+	}
+
+	created := do(http.MethodPost, "/api/todos", `{"text":"ship it","tags":["Release","api"],"notes":"  check the changelog  ","due":"+2d"}`)
+	if len(created.Tags) != 2 || created.Tags[0] != "api" || created.Tags[1] != "release" {
+		t.Fatalf("expected normalized tags [api release], got %v", created.Tags)
+	}
+	if created.Notes != "check the changelog" {
+		t.Fatalf("expected trimmed notes, got %q", created.Notes)
+	}
+	wantDue := time.Now().Add(48 * time.Hour)
+	if created.DueAt == nil || created.DueAt.Day() != wantDue.Day() || created.DueAt.Hour() != 23 {
+		t.Fatalf("expected +2d to be the end of the day after tomorrow, got %v", created.DueAt)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/todos", nil))
+	var list struct {
+		Todos []map[string]any `json:"todos"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil || len(list.Todos) != 1 {
+		t.Fatalf("list: %v %v", list, err)
+	}
+	for _, key := range []string{"tags", "notes", "dueAt"} {
+		if _, ok := list.Todos[0][key]; !ok {
+			t.Fatalf("expected %q in the listed todo, got %v", key, list.Todos[0])
+		}
+	}
+
+	// Omitted fields are left alone; empty values clear them.
+	updated := do(http.MethodPut, "/api/todos/"+created.ID, `{"notes":"done when tagged"}`)
+	if updated.Notes != "done when tagged" || len(updated.Tags) != 2 || updated.DueAt == nil {
+		t.Fatalf("unexpected partial update: %+v", updated)
+	}
+	updated = do(http.MethodPut, "/api/todos/"+created.ID, `{"notes":"","tags":[],"due":""}`)
+	if updated.Notes != "" || len(updated.Tags) != 0 || updated.DueAt != nil {
+		t.Fatalf("expected notes, tags, and due cleared, got %+v", updated)
+	}
+
+	todos, err := storage.LoadTodos(projectRoot)
+	if err != nil || len(todos) != 1 || todos[0].Notes != "" || todos[0].DueAt != nil {
+		t.Fatalf("expected the cleared fields to be saved, got %+v (%v)", todos, err)
+	}
+}
+
+func TestServerListPagination(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	now := time.Now()
+	var todos []types.Todo
+	for _, id := range []string{"a1", "b2", "c3", "d4", "e5"} {
+		todos = append(todos, types.Todo{ID: id, Text: id, Status: types.StatusOpen, Priority: types.PriorityMedium, CreatedAt: now, UpdatedAt: now})
+	}
+	if err := storage.SaveTodos(projectRoot, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+	handler := NewServer(projectRoot, 0).Handler()
+
+	type page struct {
+		Todos  []types.Todo `json:"todos"`
+		Count  int          `json:"count"`
+		Total  int          `json:"total"`
+		Offset int          `json:"offset"`
+		Limit  int          `json:"limit"`
+	}
+	get := func(query string) (int, page) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/todos"+query, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		var p page
+		json.NewDecoder(rec.Body).Decode(&p)
+		return rec.Code, p
+	}
+
+	code, p := get("?limit=2&offset=1")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if p.Count != 2 || p.Total != 5 || p.Offset != 1 || p.Limit != 2 {
+		t.Fatalf("unexpected page metadata: %+v", p)
+	}
+	if p.Todos[0].ID != "b2" || p.Todos[1].ID != "c3" {
+		t.Fatalf("unexpected page contents: %v, %v", p.Todos[0].ID, p.Todos[1].ID)
+	}
+
+	if _, p = get("?offset=4&limit=10"); p.Count != 1 || p.Todos[0].ID != "e5" {
+		t.Fatalf("expected last todo only, got %+v", p)
+	}
+	if _, p = get("?offset=9"); p.Count != 0 || p.Total != 5 {
+		t.Fatalf("expected empty page past the end, got %+v", p)
+	}
+	if _, p = get(""); p.Count != 5 {
+		t.Fatalf("expected unpaginated list of 5, got %d", p.Count)
+	}
+
+	for _, q := range []string{"?limit=-1", "?offset=abc", "?limit=1.5"} {
+		if code, _ := get(q); code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", q, code)
+		}
+	}
+}
+
+func TestServerStatusesIncludeCustom(t *testing.T) {
+	projectRoot := t.TempDir()
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	t.Cleanup(func() { types.SetCustomStatuses(nil) })
+	cfg := types.DefaultConfig()
+	cfg.CustomStatuses = []types.CustomStatus{{Name: "in-review", Color: "cyan"}}
+	if err := storage.SaveConfig(projectRoot, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	server := NewServer(projectRoot, 0)
+	req := httptest.NewRequest(http.MethodGet, "/api/statuses", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Statuses []statusInfo `json:"statuses"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Statuses) != len(types.BuiltinStatuses())+1 {
+		t.Fatalf("expected built-ins plus in-review, got %+v", resp.Statuses)
+	}
+	last := resp.Statuses[len(resp.Statuses)-1]
+	if last.Name != "in-review" || last.Builtin || last.Color != "cyan" {
+		t.Fatalf("unexpected custom status entry: %+v", last)
+	}
+}
+
+func TestServerConfigLocale(t *testing.T) {
+	projectRoot := t.TempDir()
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+	handler := NewServer(projectRoot, 0).Handler()
+	locale := func() string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp struct {
+			Locale string `json:"locale"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp.Locale
+	}
+
+	if got := locale(); got != types.DefaultLocale {
+		t.Fatalf("expected the default locale, got %q", got)
+	}
+
+	// The server reads config.json per request, so no restart is needed.
+	cfg := types.DefaultConfig()
+	cfg.Locale = "de-DE"
+	if err := storage.SaveConfig(projectRoot, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	if got := locale(); got != "de-DE" {
+		t.Fatalf("expected de-DE, got %q", got)
+	}
+}
+
+func TestServerOrder(t *testing.T) {
+	projectRoot := t.TempDir()
+	t.Setenv("TODO_USER_NAME", "Test User")
+	if _, err := storage.InitProject(projectRoot, true); err != nil {
+		t.Fatalf("init project: %v", err)
+	}
+
+	now := time.Now()
+	todos := []types.Todo{
+		{ID: "a1", Text: "one", Status: types.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "b2", Text: "two", Status: types.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "c3", Text: "three", Status: types.StatusOpen, CreatedAt: now, UpdatedAt: now},
+	}
+	if err := storage.SaveTodos(projectRoot, todos); err != nil {
+		t.Fatalf("save todos: %v", err)
+	}
+
+	handler := NewServer(projectRoot, 0).Handler()
+	put := func(body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPut, "/api/todos/order", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(`["c3","zz","a1"]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Order []string `json:"order"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode order response: %v", err)
+	}
+	if strings.Join(resp.Order, ",") != "c3,a1,b2" {
+		t.Fatalf("order = %v; want c3,a1,b2", resp.Order)
+	}
+
+	loaded, err := storage.LoadTodos(projectRoot)
+	if err != nil {
+		t.Fatalf("load todos: %v", err)
+	}
+	if len(loaded) != 3 || loaded[0].ID != "c3" || loaded[1].ID != "a1" || loaded[2].ID != "b2" {
+		t.Fatalf("stored order not updated: %+v", loaded)
+	}
+
+	for _, body := range []string{`{"ids":["a1"]}`, `["a1", 2]`, `[]`} {
+		if rec := put(body); rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: expected 400, got %d", body, rec.Code)
+		}
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/todos/order", strings.NewReader(`["a1"]`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for POST, got %d", rec.Code)
+	}
+}